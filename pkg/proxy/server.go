@@ -71,6 +71,9 @@ type Server interface {
 	// LatencyAccept returns current latency on accepting
 	// new incoming connections.
 	LatencyAccept() time.Duration
+	// DelayNextAccept delays only the next accepted connection,
+	// simulating cost of setting up the first connection.
+	DelayNextAccept(latency time.Duration)
 
 	// DelayTx adds latency ± random variable for "outgoing" traffic
 	// in "sending" layer.
@@ -88,6 +91,42 @@ type Server interface {
 	// LatencyRx returns current receive latency.
 	LatencyRx() time.Duration
 
+	// BandwidthDelay adds latency proportional to the size of packets
+	// in both directions, simulating link with limited bandwidth.
+	// Small packets are barely delayed, while large ones take longer.
+	// Latency is added on top of the one set by "DelayTx" and "DelayRx".
+	BandwidthDelay(bytesPerSec int64)
+	// UnbandwidthDelay removes bandwidth latency.
+	UnbandwidthDelay()
+	// LimitTxBandwidth adds bandwidth latency only to "outgoing" traffic.
+	// Can be adjusted at any time, zero removes the limit.
+	LimitTxBandwidth(bytesPerSec int64)
+	// LimitRxBandwidth adds bandwidth latency only to "incoming" traffic.
+	// Can be adjusted at any time, zero removes the limit.
+	LimitRxBandwidth(bytesPerSec int64)
+	// SetAsymmetricBandwidth sets different bandwidth for "outgoing"
+	// and "incoming" traffic, for example modeling slow uplink.
+	SetAsymmetricBandwidth(txBytesPerSec, rxBytesPerSec int64)
+	// RecoverBandwidth ramps bandwidth linearly from "from" to "to" bytes
+	// per second over given duration, simulating link recovering from
+	// congestion. Returned function cancels the ramp. In both cases
+	// the link is left at the final bandwidth.
+	RecoverBandwidth(from, to int64, over time.Duration) (cancel func())
+
+	// ThrottleTx limits "outgoing" traffic of all connections to given
+	// bytes per second, using token bucket shared between connections.
+	// Unlike "LimitTxBandwidth", concurrent connections compete for
+	// the bandwidth. Can be adjusted at any time, also while packets
+	// are waiting for tokens.
+	ThrottleTx(bytesPerSec int64)
+	// UnthrottleTx removes throttling of "outgoing" traffic.
+	UnthrottleTx()
+	// ThrottleRx limits "incoming" traffic of all connections to given
+	// bytes per second, using token bucket shared between connections.
+	ThrottleRx(bytesPerSec int64)
+	// UnthrottleRx removes throttling of "incoming" traffic.
+	UnthrottleRx()
+
 	// ModifyTx alters/corrupts/drops "outgoing" packets from the listener
 	// with the given edit function.
 	ModifyTx(f func(data []byte) []byte)
@@ -100,6 +139,18 @@ type Server interface {
 	// UnmodifyRx removes modify operation on "receiving".
 	UnmodifyRx()
 
+	// OnMessageTrigger calls action, inline before forwarding, whenever
+	// data read in either direction matches. Triggering data is affected
+	// by faults injected by the action. Both callbacks must be fast and
+	// non-blocking as they delay all traffic. Proxy doesn't decode
+	// traffic, so match sees raw bytes and can only recognize messages of
+	// plaintext connections. Peer proxy of e2e clusters requires peer TLS,
+	// so raft messages can't be matched there, use raftBlackholeOnCampaign
+	// failpoint to react to a member campaigning instead.
+	OnMessageTrigger(match func(data []byte) bool, action func())
+	// RemoveMessageTrigger removes trigger set by OnMessageTrigger.
+	RemoveMessageTrigger()
+
 	// BlackholeTx drops all "outgoing" packets before "forwarding".
 	// "BlackholeTx" operation is a wrapper around "ModifyTx" with
 	// a function that returns empty bytes.
@@ -114,6 +165,22 @@ type Server interface {
 	// UnblackholeRx removes blackhole operation on "receiving".
 	UnblackholeRx()
 
+	// SetDropRate drops given fraction of packets, between 0 and 1, in both
+	// directions. Zero stops dropping packets.
+	SetDropRate(rate float64)
+	// RampDropRate gradually increases drop rate from the given rate to the
+	// target over given duration, modeling a link progressively failing.
+	// Calling returned cancel stops the ramp and stops dropping packets.
+	RampDropRate(from, to float64, over time.Duration) (cancel func())
+	// DropTx drops given fraction of "outgoing" packets, between 0 and 1.
+	// Rate of 1 drops all packets like "BlackholeTx", zero stops dropping.
+	// Decisions are reproducible with "ServerConfig.DropSeed", as long
+	// as packets are read in the same order.
+	DropTx(rate float64)
+	// DropRx drops given fraction of "incoming" packets, between 0 and 1.
+	// Rate of 1 drops all packets like "BlackholeRx", zero stops dropping.
+	DropRx(rate float64)
+
 	// PauseTx stops "forwarding" packets; "outgoing" traffic blocks.
 	PauseTx()
 	// UnpauseTx removes "forwarding" pause operation.
@@ -124,8 +191,25 @@ type Server interface {
 	// UnpauseRx removes "receiving" pause operation.
 	UnpauseRx()
 
+	// StallStreamAfter forwards given number of reads of "outgoing" traffic
+	// on a connection, then stalls it, holding subsequent data without
+	// closing the connection, to reproduce head-of-line blocking of messages
+	// multiplexed on a stream. Only the first connection to reach the limit
+	// is stalled, others keep flowing. Proxy doesn't decode traffic, so a
+	// single read may contain part of a message or multiple messages.
+	StallStreamAfter(messages int)
+	// UnstallStream releases the stalled stream and removes the stall.
+	UnstallStream()
+
 	// ResetListener closes and restarts listener.
 	ResetListener() error
+
+	// InjectFault injects fault described by the spec, allowing to compose
+	// faults from data, e.g. drop half of "outgoing" packets and delay the
+	// rest. Convenience methods above are shorthands for common specs.
+	InjectFault(spec FaultSpec) error
+	// RemoveFault removes fault injected with the same direction and mode.
+	RemoveFault(spec FaultSpec) error
 }
 
 // ServerConfig defines proxy server configuration.
@@ -137,6 +221,87 @@ type ServerConfig struct {
 	DialTimeout   time.Duration
 	BufferSize    int
 	RetryInterval time.Duration
+	// DropSeed seeds random decisions of dropping packets.
+	// Zero uses a random seed, which is logged on start.
+	DropSeed int64
+}
+
+// FaultDirection selects traffic the fault is injected into.
+type FaultDirection string
+
+const (
+	// FaultBoth injects fault into both "outgoing" and "incoming" traffic.
+	FaultBoth FaultDirection = ""
+	// FaultTx injects fault into "outgoing" traffic.
+	FaultTx FaultDirection = "tx"
+	// FaultRx injects fault into "incoming" traffic.
+	FaultRx FaultDirection = "rx"
+)
+
+func (d FaultDirection) proxyTypes() []proxyType {
+	switch d {
+	case FaultTx:
+		return []proxyType{proxyTx}
+	case FaultRx:
+		return []proxyType{proxyRx}
+	default:
+		return []proxyType{proxyTx, proxyRx}
+	}
+}
+
+// FaultMode selects how traffic is affected by the fault.
+type FaultMode string
+
+const (
+	// FaultDelay delays forwarded packets.
+	FaultDelay FaultMode = "delay"
+	// FaultDrop drops a fraction of packets and optionally delays the rest.
+	FaultDrop FaultMode = "drop"
+	// FaultBlackhole drops all packets.
+	FaultBlackhole FaultMode = "blackhole"
+	// FaultPause blocks forwarding of packets.
+	FaultPause FaultMode = "pause"
+)
+
+// FaultSpec describes a fault injected with InjectFault. It can be serialized
+// to describe faults of a test scenario. Proxy forwards raw bytes, encrypted if
+// TLS is used, so faults can't target a raft message type. Faults on message
+// types, like dropping MsgApp, are injected by rafthttp failpoints instead.
+type FaultSpec struct {
+	Direction FaultDirection `json:"direction,omitempty"`
+	// Peer is the address traffic is proxied to, as returned by To. Proxy
+	// serves a single link, so faults on links to other peers need to be
+	// injected into their proxies, and specs with other Peer are rejected.
+	// Empty Peer injects fault into the link of the proxy.
+	Peer string    `json:"peer,omitempty"`
+	Mode FaultMode `json:"mode"`
+	// Rate is the fraction of packets, between 0 and 1, dropped in FaultDrop mode.
+	Rate float64 `json:"rate,omitempty"`
+	// Delay is latency of forwarded packets in FaultDelay mode, and of packets
+	// that were not dropped in FaultDrop mode.
+	Delay time.Duration `json:"delay,omitempty"`
+	// DelayJitter is random variable of the latency, see DelayTx.
+	DelayJitter time.Duration `json:"delayJitter,omitempty"`
+}
+
+func (spec FaultSpec) validate() error {
+	switch spec.Direction {
+	case FaultBoth, FaultTx, FaultRx:
+	default:
+		return fmt.Errorf("unknown fault direction %q", spec.Direction)
+	}
+	switch spec.Mode {
+	case FaultDelay, FaultDrop, FaultBlackhole, FaultPause:
+	default:
+		return fmt.Errorf("unknown fault mode %q", spec.Mode)
+	}
+	if spec.Rate < 0 || spec.Rate > 1 {
+		return fmt.Errorf("fault drop rate %v out of range [0, 1]", spec.Rate)
+	}
+	if spec.Delay < 0 {
+		return fmt.Errorf("negative fault delay %s", spec.Delay)
+	}
+	return nil
 }
 
 type server struct {
@@ -166,8 +331,9 @@ type server struct {
 	pauseAcceptMu sync.Mutex
 	pauseAcceptc  chan struct{}
 
-	latencyAcceptMu sync.RWMutex
-	latencyAccept   time.Duration
+	latencyAcceptMu   sync.RWMutex
+	latencyAccept     time.Duration
+	latencyNextAccept time.Duration
 
 	modifyTxMu sync.RWMutex
 	modifyTx   func(data []byte) []byte
@@ -186,6 +352,33 @@ type server struct {
 
 	latencyRxMu sync.RWMutex
 	latencyRx   time.Duration
+
+	faultLatencyMu sync.Mutex
+	faultLatency   map[proxyType]map[FaultMode]time.Duration
+
+	bandwidthMu            sync.RWMutex
+	bandwidthTxBytesPerSec int64
+	bandwidthRxBytesPerSec int64
+
+	throttleTx throttle
+	throttleRx throttle
+
+	dropRateMu sync.RWMutex
+	dropRateTx float64
+	dropRateRx float64
+
+	dropRandMu sync.Mutex
+	dropRand   *mrand.Rand
+
+	triggerMu     sync.RWMutex
+	triggerMatch  func(data []byte) bool
+	triggerAction func()
+
+	// stallc is nil if no stream stall is set, and is closed to release it.
+	stallMu      sync.Mutex
+	stallAfter   int
+	stallc       chan struct{}
+	stallClaimed bool
 }
 
 // NewServer returns a proxy implementation with no iptables/tc dependencies.
@@ -231,6 +424,11 @@ func NewServer(cfg ServerConfig) Server {
 	if s.retryInterval == 0 {
 		s.retryInterval = defaultRetryInterval
 	}
+	dropSeed := cfg.DropSeed
+	if dropSeed == 0 {
+		dropSeed = time.Now().UnixNano()
+	}
+	s.dropRand = mrand.New(mrand.NewSource(dropSeed))
 
 	close(s.pauseAcceptc)
 	close(s.pauseTxc)
@@ -264,7 +462,7 @@ func NewServer(cfg ServerConfig) Server {
 	s.closeWg.Add(1)
 	go s.listenAndServe()
 
-	s.lg.Info("started proxying", zap.String("from", s.From()), zap.String("to", s.To()))
+	s.lg.Info("started proxying", zap.String("from", s.From()), zap.String("to", s.To()), zap.Int64("drop-seed", dropSeed))
 	return s
 }
 
@@ -353,6 +551,19 @@ func (s *server) listenAndServe() {
 			continue
 		}
 
+		s.latencyAcceptMu.Lock()
+		lat = s.latencyNextAccept
+		s.latencyNextAccept = 0
+		s.latencyAcceptMu.Unlock()
+		if lat > 0 {
+			select {
+			case <-time.After(lat):
+			case <-s.donec:
+				in.Close()
+				return
+			}
+		}
+
 		var out net.Conn
 		if !s.tlsInfo.Empty() {
 			var tp *http.Transport
@@ -424,6 +635,7 @@ const (
 
 func (s *server) ioCopy(dst io.Writer, src io.Reader, ptype proxyType) {
 	buf := make([]byte, s.bufferSize)
+	var stall streamStall
 	for {
 		nr1, err := src.Read(buf)
 		if err != nil {
@@ -455,6 +667,9 @@ func (s *server) ioCopy(dst io.Writer, src io.Reader, ptype proxyType) {
 		}
 		data := buf[:nr1]
 
+		// react to observed data before injecting any fault
+		s.trigger(data)
+
 		// alters/corrupts/drops data
 		switch ptype {
 		case proxyTx:
@@ -515,7 +730,7 @@ func (s *server) ioCopy(dst io.Writer, src io.Reader, ptype proxyType) {
 		}
 
 		// pause first, and then drop packets
-		if nr2 == 0 {
+		if nr2 == 0 || s.dropPacket(ptype) {
 			continue
 		}
 
@@ -533,6 +748,7 @@ func (s *server) ioCopy(dst io.Writer, src io.Reader, ptype proxyType) {
 		default:
 			panic("unknown proxy type")
 		}
+		lat += s.bandwidthLatency(ptype, nr2)
 		if lat > 0 {
 			select {
 			case <-time.After(lat):
@@ -541,6 +757,20 @@ func (s *server) ioCopy(dst io.Writer, src io.Reader, ptype proxyType) {
 			}
 		}
 
+		// throttle by bandwidth shared with other connections
+		if !s.waitThrottle(ptype, nr2) {
+			return
+		}
+
+		// stall the stream, holding data of the connection
+		if stallc := s.stallStream(ptype, &stall); stallc != nil {
+			select {
+			case <-stallc:
+			case <-s.donec:
+				return
+			}
+		}
+
 		// now forward packets to target
 		var nw int
 		nw, err = dst.Write(data)
@@ -708,6 +938,22 @@ func (s *server) UndelayAccept() {
 	)
 }
 
+func (s *server) DelayNextAccept(latency time.Duration) {
+	if latency <= 0 {
+		return
+	}
+	s.latencyAcceptMu.Lock()
+	s.latencyNextAccept = latency
+	s.latencyAcceptMu.Unlock()
+
+	s.lg.Info(
+		"set next accept latency",
+		zap.Duration("latency", latency),
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
+	)
+}
+
 func (s *server) LatencyAccept() time.Duration {
 	s.latencyAcceptMu.RLock()
 	d := s.latencyAccept
@@ -719,80 +965,294 @@ func (s *server) DelayTx(latency, rv time.Duration) {
 	if latency <= 0 {
 		return
 	}
-	d := computeLatency(latency, rv)
-	s.latencyTxMu.Lock()
-	s.latencyTx = d
-	s.latencyTxMu.Unlock()
+	s.injectFault(FaultSpec{Direction: FaultTx, Mode: FaultDelay, Delay: latency, DelayJitter: rv})
+}
+
+func (s *server) UndelayTx() {
+	s.removeFault(FaultSpec{Direction: FaultTx, Mode: FaultDelay})
+}
+
+func (s *server) LatencyTx() time.Duration {
+	s.latencyTxMu.RLock()
+	d := s.latencyTx
+	s.latencyTxMu.RUnlock()
+	return d
+}
+
+func (s *server) DelayRx(latency, rv time.Duration) {
+	if latency <= 0 {
+		return
+	}
+	s.injectFault(FaultSpec{Direction: FaultRx, Mode: FaultDelay, Delay: latency, DelayJitter: rv})
+}
+
+func (s *server) UndelayRx() {
+	s.removeFault(FaultSpec{Direction: FaultRx, Mode: FaultDelay})
+}
+
+func (s *server) LatencyRx() time.Duration {
+	s.latencyRxMu.RLock()
+	d := s.latencyRx
+	s.latencyRxMu.RUnlock()
+	return d
+}
+
+func (s *server) BandwidthDelay(bytesPerSec int64) {
+	if bytesPerSec <= 0 {
+		return
+	}
+	s.setBandwidth(bytesPerSec)
 
 	s.lg.Info(
-		"set transmit latency",
-		zap.Duration("latency", d),
-		zap.Duration("given-latency", latency),
-		zap.Duration("given-latency-random-variable", rv),
+		"set bandwidth latency",
+		zap.String("bandwidth", humanize.Bytes(uint64(bytesPerSec))+"/s"),
 		zap.String("from", s.From()),
 		zap.String("to", s.To()),
 	)
 }
 
-func (s *server) UndelayTx() {
-	s.latencyTxMu.Lock()
-	d := s.latencyTx
-	s.latencyTx = 0
-	s.latencyTxMu.Unlock()
+func (s *server) UnbandwidthDelay() {
+	s.bandwidthMu.Lock()
+	txBytesPerSec, rxBytesPerSec := s.bandwidthTxBytesPerSec, s.bandwidthRxBytesPerSec
+	s.bandwidthTxBytesPerSec, s.bandwidthRxBytesPerSec = 0, 0
+	s.bandwidthMu.Unlock()
 
 	s.lg.Info(
-		"removed transmit latency",
-		zap.Duration("latency", d),
+		"removed bandwidth latency",
+		zap.String("tx-bandwidth", humanize.Bytes(uint64(txBytesPerSec))+"/s"),
+		zap.String("rx-bandwidth", humanize.Bytes(uint64(rxBytesPerSec))+"/s"),
 		zap.String("from", s.From()),
 		zap.String("to", s.To()),
 	)
 }
 
-func (s *server) LatencyTx() time.Duration {
-	s.latencyTxMu.RLock()
-	d := s.latencyTx
-	s.latencyTxMu.RUnlock()
-	return d
+func (s *server) LimitTxBandwidth(bytesPerSec int64) {
+	s.bandwidthMu.Lock()
+	s.bandwidthTxBytesPerSec = bytesPerSec
+	s.bandwidthMu.Unlock()
+
+	s.lg.Info(
+		"set tx bandwidth latency",
+		zap.String("bandwidth", humanize.Bytes(uint64(bytesPerSec))+"/s"),
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
+	)
 }
 
-func (s *server) DelayRx(latency, rv time.Duration) {
-	if latency <= 0 {
-		return
+func (s *server) LimitRxBandwidth(bytesPerSec int64) {
+	s.bandwidthMu.Lock()
+	s.bandwidthRxBytesPerSec = bytesPerSec
+	s.bandwidthMu.Unlock()
+
+	s.lg.Info(
+		"set rx bandwidth latency",
+		zap.String("bandwidth", humanize.Bytes(uint64(bytesPerSec))+"/s"),
+		zap.String("from", s.To()),
+		zap.String("to", s.From()),
+	)
+}
+
+func (s *server) SetAsymmetricBandwidth(txBytesPerSec, rxBytesPerSec int64) {
+	s.LimitTxBandwidth(txBytesPerSec)
+	s.LimitRxBandwidth(rxBytesPerSec)
+}
+
+// bandwidthRampSteps is the number of bandwidth changes made by "RecoverBandwidth".
+const bandwidthRampSteps = 10
+
+func (s *server) RecoverBandwidth(from, to int64, over time.Duration) (cancel func()) {
+	s.setBandwidth(from)
+	s.lg.Info(
+		"recovering bandwidth",
+		zap.String("from-bandwidth", humanize.Bytes(uint64(from))+"/s"),
+		zap.String("to-bandwidth", humanize.Bytes(uint64(to))+"/s"),
+		zap.Duration("over", over),
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
+	)
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(donec)
+		defer func() {
+			s.setBandwidth(to)
+			s.lg.Info(
+				"recovered bandwidth",
+				zap.String("bandwidth", humanize.Bytes(uint64(to))+"/s"),
+				zap.String("from", s.From()),
+				zap.String("to", s.To()),
+			)
+		}()
+		if over <= 0 {
+			return
+		}
+		ticker := time.NewTicker(over / bandwidthRampSteps)
+		defer ticker.Stop()
+		for step := int64(1); step < bandwidthRampSteps; step++ {
+			select {
+			case <-ticker.C:
+				s.setBandwidth(from + (to-from)*step/bandwidthRampSteps)
+			case <-stopc:
+				return
+			case <-s.donec:
+				return
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-stopc:
+		case <-s.donec:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stopc) })
+		<-donec
 	}
-	d := computeLatency(latency, rv)
-	s.latencyRxMu.Lock()
-	s.latencyRx = d
-	s.latencyRxMu.Unlock()
+}
+
+func (s *server) setBandwidth(bytesPerSec int64) {
+	s.bandwidthMu.Lock()
+	s.bandwidthTxBytesPerSec = bytesPerSec
+	s.bandwidthRxBytesPerSec = bytesPerSec
+	s.bandwidthMu.Unlock()
+}
+
+// bandwidthLatency returns time needed to transfer given number of bytes in given direction.
+func (s *server) bandwidthLatency(ptype proxyType, size int) time.Duration {
+	var bytesPerSec int64
+	s.bandwidthMu.RLock()
+	switch ptype {
+	case proxyTx:
+		bytesPerSec = s.bandwidthTxBytesPerSec
+	case proxyRx:
+		bytesPerSec = s.bandwidthRxBytesPerSec
+	default:
+		panic("unknown proxy type")
+	}
+	s.bandwidthMu.RUnlock()
+	if bytesPerSec <= 0 {
+		return 0
+	}
+	return time.Duration(int64(size) * int64(time.Second) / bytesPerSec)
+}
+
+func (s *server) ThrottleTx(bytesPerSec int64) {
+	s.throttleTx.set(bytesPerSec)
 
 	s.lg.Info(
-		"set receive latency",
-		zap.Duration("latency", d),
-		zap.Duration("given-latency", latency),
-		zap.Duration("given-latency-random-variable", rv),
+		"set tx throttle",
+		zap.String("bandwidth", humanize.Bytes(uint64(bytesPerSec))+"/s"),
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
+	)
+}
+
+func (s *server) UnthrottleTx() {
+	s.throttleTx.set(0)
+
+	s.lg.Info(
+		"removed tx throttle",
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
+	)
+}
+
+func (s *server) ThrottleRx(bytesPerSec int64) {
+	s.throttleRx.set(bytesPerSec)
+
+	s.lg.Info(
+		"set rx throttle",
+		zap.String("bandwidth", humanize.Bytes(uint64(bytesPerSec))+"/s"),
 		zap.String("from", s.To()),
 		zap.String("to", s.From()),
 	)
 }
 
-func (s *server) UndelayRx() {
-	s.latencyRxMu.Lock()
-	d := s.latencyRx
-	s.latencyRx = 0
-	s.latencyRxMu.Unlock()
+func (s *server) UnthrottleRx() {
+	s.throttleRx.set(0)
 
 	s.lg.Info(
-		"removed receive latency",
-		zap.Duration("latency", d),
+		"removed rx throttle",
 		zap.String("from", s.To()),
 		zap.String("to", s.From()),
 	)
 }
 
-func (s *server) LatencyRx() time.Duration {
-	s.latencyRxMu.RLock()
-	d := s.latencyRx
-	s.latencyRxMu.RUnlock()
-	return d
+// waitThrottle blocks until throttle of given direction has tokens to forward
+// given number of bytes. Returns false if server was closed while waiting.
+func (s *server) waitThrottle(ptype proxyType, size int) bool {
+	var t *throttle
+	switch ptype {
+	case proxyTx:
+		t = &s.throttleTx
+	case proxyRx:
+		t = &s.throttleRx
+	default:
+		panic("unknown proxy type")
+	}
+	for {
+		wait, changedc := t.reserve(size)
+		if wait <= 0 {
+			return true
+		}
+		select {
+		case <-time.After(wait):
+			return true
+		case <-changedc:
+			// throttle changed, reserve again with new bandwidth
+		case <-s.donec:
+			return false
+		}
+	}
+}
+
+// throttleBurst is the amount of traffic, expressed as transfer time, that
+// throttle token bucket can hold.
+const throttleBurst = 100 * time.Millisecond
+
+// throttle is a token bucket limiting bandwidth of all connections in one direction.
+// Packets larger than the bucket take tokens in advance, making following ones wait.
+type throttle struct {
+	mu          sync.Mutex
+	bytesPerSec int64
+	tokens      float64
+	last        time.Time
+	// changedc is closed when bandwidth is changed, releasing waiting packets.
+	changedc chan struct{}
+}
+
+// set changes bandwidth and refills the bucket, zero removes the limit.
+func (t *throttle) set(bytesPerSec int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bytesPerSec = bytesPerSec
+	t.tokens = float64(bytesPerSec) * throttleBurst.Seconds()
+	t.last = time.Now()
+	if t.changedc != nil {
+		close(t.changedc)
+	}
+	t.changedc = make(chan struct{})
+}
+
+// reserve takes tokens for given number of bytes. Returns time to wait
+// before forwarding them and channel closed if bandwidth changes meanwhile.
+func (t *throttle) reserve(size int) (time.Duration, <-chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.bytesPerSec <= 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	rate := float64(t.bytesPerSec)
+	t.tokens = min(rate*throttleBurst.Seconds(), t.tokens+now.Sub(t.last).Seconds()*rate)
+	t.last = now
+	t.tokens -= float64(size)
+	if t.tokens >= 0 {
+		return 0, nil
+	}
+	return time.Duration(-t.tokens / rate * float64(time.Second)), t.changedc
 }
 
 func computeLatency(lat, rv time.Duration) time.Duration {
@@ -813,6 +1273,41 @@ func computeLatency(lat, rv time.Duration) time.Duration {
 	return lat + time.Duration(int64(sign)*mrand.Int63n(rv.Nanoseconds()))
 }
 
+func (s *server) OnMessageTrigger(match func(data []byte) bool, action func()) {
+	s.triggerMu.Lock()
+	s.triggerMatch, s.triggerAction = match, action
+	s.triggerMu.Unlock()
+
+	s.lg.Info(
+		"set message trigger",
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
+	)
+}
+
+func (s *server) RemoveMessageTrigger() {
+	s.triggerMu.Lock()
+	s.triggerMatch, s.triggerAction = nil, nil
+	s.triggerMu.Unlock()
+
+	s.lg.Info(
+		"removed message trigger",
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
+	)
+}
+
+// trigger calls trigger action if data matches. Lock is not held while calling
+// callbacks, so the action can inject faults or remove the trigger.
+func (s *server) trigger(data []byte) {
+	s.triggerMu.RLock()
+	match, action := s.triggerMatch, s.triggerAction
+	s.triggerMu.RUnlock()
+	if match != nil && match(data) {
+		action()
+	}
+}
+
 func (s *server) ModifyTx(f func([]byte) []byte) {
 	s.modifyTxMu.Lock()
 	s.modifyTx = f
@@ -860,102 +1355,368 @@ func (s *server) UnmodifyRx() {
 	)
 }
 
-func (s *server) BlackholeTx() {
-	s.ModifyTx(func([]byte) []byte { return nil })
+func (s *server) SetDropRate(rate float64) {
+	s.injectFault(FaultSpec{Mode: FaultDrop, Rate: rate})
+}
+
+// dropRampSteps is number of times drop rate is increased during ramp.
+const dropRampSteps = 10
+
+func (s *server) RampDropRate(from, to float64, over time.Duration) (cancel func()) {
+	s.setDropRate(from)
 	s.lg.Info(
-		"blackholed tx",
+		"ramping drop rate",
+		zap.Float64("from-drop-rate", from),
+		zap.Float64("to-drop-rate", to),
+		zap.Duration("over", over),
 		zap.String("from", s.From()),
 		zap.String("to", s.To()),
 	)
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(donec)
+		if over <= 0 {
+			s.setDropRate(to)
+			return
+		}
+		ticker := time.NewTicker(over / dropRampSteps)
+		defer ticker.Stop()
+		for step := 1; step <= dropRampSteps; step++ {
+			select {
+			case <-ticker.C:
+				s.setDropRate(from + (to-from)*float64(step)/dropRampSteps)
+			case <-stopc:
+				return
+			case <-s.donec:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stopc) })
+		<-donec
+		s.setDropRate(0)
+		s.lg.Info(
+			"reset drop rate",
+			zap.String("from", s.From()),
+			zap.String("to", s.To()),
+		)
+	}
+}
+
+func (s *server) setDropRate(rate float64) {
+	s.dropRateMu.Lock()
+	s.dropRateTx = rate
+	s.dropRateRx = rate
+	s.dropRateMu.Unlock()
+}
+
+// dropPacket randomly decides whether packet should be dropped based on drop rate.
+func (s *server) dropPacket(ptype proxyType) bool {
+	s.dropRateMu.RLock()
+	rate := s.dropRateTx
+	if ptype == proxyRx {
+		rate = s.dropRateRx
+	}
+	s.dropRateMu.RUnlock()
+	if rate <= 0 {
+		return false
+	}
+	s.dropRandMu.Lock()
+	defer s.dropRandMu.Unlock()
+	return s.dropRand.Float64() < rate
+}
+
+func (s *server) DropTx(rate float64) {
+	s.injectFault(FaultSpec{Direction: FaultTx, Mode: FaultDrop, Rate: rate})
+}
+
+func (s *server) DropRx(rate float64) {
+	s.injectFault(FaultSpec{Direction: FaultRx, Mode: FaultDrop, Rate: rate})
+}
+
+func (s *server) BlackholeTx() {
+	s.injectFault(FaultSpec{Direction: FaultTx, Mode: FaultBlackhole})
 }
 
 func (s *server) UnblackholeTx() {
-	s.UnmodifyTx()
-	s.lg.Info(
-		"unblackholed tx",
-		zap.String("from", s.From()),
-		zap.String("to", s.To()),
-	)
+	s.removeFault(FaultSpec{Direction: FaultTx, Mode: FaultBlackhole})
 }
 
 func (s *server) BlackholeRx() {
-	s.ModifyRx(func([]byte) []byte { return nil })
-	s.lg.Info(
-		"blackholed rx",
-		zap.String("from", s.To()),
-		zap.String("to", s.From()),
-	)
+	s.injectFault(FaultSpec{Direction: FaultRx, Mode: FaultBlackhole})
 }
 
 func (s *server) UnblackholeRx() {
-	s.UnmodifyRx()
+	s.removeFault(FaultSpec{Direction: FaultRx, Mode: FaultBlackhole})
+}
+
+func (s *server) PauseTx() {
+	s.injectFault(FaultSpec{Direction: FaultTx, Mode: FaultPause})
+}
+
+func (s *server) UnpauseTx() {
+	s.removeFault(FaultSpec{Direction: FaultTx, Mode: FaultPause})
+}
+
+func (s *server) PauseRx() {
+	s.injectFault(FaultSpec{Direction: FaultRx, Mode: FaultPause})
+}
+
+func (s *server) UnpauseRx() {
+	s.removeFault(FaultSpec{Direction: FaultRx, Mode: FaultPause})
+}
+
+func (s *server) InjectFault(spec FaultSpec) error {
+	if err := s.validateFault(spec); err != nil {
+		return err
+	}
+	s.injectFault(spec)
+	return nil
+}
+
+func (s *server) RemoveFault(spec FaultSpec) error {
+	if err := s.validateFault(spec); err != nil {
+		return err
+	}
+	s.removeFault(spec)
+	return nil
+}
+
+func (s *server) validateFault(spec FaultSpec) error {
+	if err := spec.validate(); err != nil {
+		return err
+	}
+	if spec.Peer != "" && spec.Peer != s.To() {
+		return fmt.Errorf("fault on peer %q is not supported by proxy to %q, inject it into proxy of that peer", spec.Peer, s.To())
+	}
+	return nil
+}
+
+func (s *server) injectFault(spec FaultSpec) {
+	var latency time.Duration
+	if spec.Delay > 0 {
+		latency = computeLatency(spec.Delay, spec.DelayJitter)
+	}
+	for _, ptype := range spec.Direction.proxyTypes() {
+		switch spec.Mode {
+		case FaultDelay:
+			s.setFaultLatency(ptype, FaultDelay, latency)
+		case FaultDrop:
+			s.setDirectionDropRate(ptype, spec.Rate)
+			s.setFaultLatency(ptype, FaultDrop, latency)
+		case FaultBlackhole:
+			s.setModify(ptype, func([]byte) []byte { return nil })
+		case FaultPause:
+			s.pause(ptype)
+		}
+	}
+
 	s.lg.Info(
-		"unblackholed rx",
-		zap.String("from", s.To()),
-		zap.String("to", s.From()),
+		"injected fault",
+		zap.String("direction", string(spec.Direction)),
+		zap.String("mode", string(spec.Mode)),
+		zap.Float64("drop-rate", spec.Rate),
+		zap.Duration("latency", latency),
+		zap.Duration("given-latency", spec.Delay),
+		zap.Duration("given-latency-random-variable", spec.DelayJitter),
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
 	)
 }
 
-func (s *server) PauseTx() {
-	s.pauseTxMu.Lock()
-	s.pauseTxc = make(chan struct{})
-	s.pauseTxMu.Unlock()
+func (s *server) removeFault(spec FaultSpec) {
+	for _, ptype := range spec.Direction.proxyTypes() {
+		switch spec.Mode {
+		case FaultDelay:
+			s.setFaultLatency(ptype, FaultDelay, 0)
+		case FaultDrop:
+			s.setDirectionDropRate(ptype, 0)
+			s.setFaultLatency(ptype, FaultDrop, 0)
+		case FaultBlackhole:
+			s.setModify(ptype, nil)
+		case FaultPause:
+			s.unpause(ptype)
+		}
+	}
 
 	s.lg.Info(
-		"paused tx",
+		"removed fault",
+		zap.String("direction", string(spec.Direction)),
+		zap.String("mode", string(spec.Mode)),
 		zap.String("from", s.From()),
 		zap.String("to", s.To()),
 	)
 }
 
-func (s *server) UnpauseTx() {
-	s.pauseTxMu.Lock()
+// setFaultLatency sets latency added by fault of given mode. Latencies of
+// delay and drop faults are tracked separately and add up, so removing one
+// of them restores latency of the other.
+func (s *server) setFaultLatency(ptype proxyType, mode FaultMode, latency time.Duration) {
+	s.faultLatencyMu.Lock()
+	defer s.faultLatencyMu.Unlock()
+	if s.faultLatency == nil {
+		s.faultLatency = make(map[proxyType]map[FaultMode]time.Duration)
+	}
+	if s.faultLatency[ptype] == nil {
+		s.faultLatency[ptype] = make(map[FaultMode]time.Duration)
+	}
+	s.faultLatency[ptype][mode] = latency
+	var total time.Duration
+	for _, l := range s.faultLatency[ptype] {
+		total += l
+	}
+	s.setLatency(ptype, total)
+}
+
+func (s *server) setLatency(ptype proxyType, latency time.Duration) {
+	switch ptype {
+	case proxyTx:
+		s.latencyTxMu.Lock()
+		s.latencyTx = latency
+		s.latencyTxMu.Unlock()
+	case proxyRx:
+		s.latencyRxMu.Lock()
+		s.latencyRx = latency
+		s.latencyRxMu.Unlock()
+	default:
+		panic("unknown proxy type")
+	}
+}
+
+func (s *server) setDirectionDropRate(ptype proxyType, rate float64) {
+	s.dropRateMu.Lock()
+	defer s.dropRateMu.Unlock()
+	switch ptype {
+	case proxyTx:
+		s.dropRateTx = rate
+	case proxyRx:
+		s.dropRateRx = rate
+	default:
+		panic("unknown proxy type")
+	}
+}
+
+func (s *server) setModify(ptype proxyType, f func([]byte) []byte) {
+	switch ptype {
+	case proxyTx:
+		s.modifyTxMu.Lock()
+		s.modifyTx = f
+		s.modifyTxMu.Unlock()
+	case proxyRx:
+		s.modifyRxMu.Lock()
+		s.modifyRx = f
+		s.modifyRxMu.Unlock()
+	default:
+		panic("unknown proxy type")
+	}
+}
+
+func (s *server) pause(ptype proxyType) {
+	switch ptype {
+	case proxyTx:
+		s.pauseTxMu.Lock()
+		s.pauseTxc = make(chan struct{})
+		s.pauseTxMu.Unlock()
+	case proxyRx:
+		s.pauseRxMu.Lock()
+		s.pauseRxc = make(chan struct{})
+		s.pauseRxMu.Unlock()
+	default:
+		panic("unknown proxy type")
+	}
+}
+
+func (s *server) unpause(ptype proxyType) {
+	var mu *sync.Mutex
+	var pausec *chan struct{}
+	switch ptype {
+	case proxyTx:
+		mu, pausec = &s.pauseTxMu, &s.pauseTxc
+	case proxyRx:
+		mu, pausec = &s.pauseRxMu, &s.pauseRxc
+	default:
+		panic("unknown proxy type")
+	}
+	mu.Lock()
+	defer mu.Unlock()
 	select {
-	case <-s.pauseTxc: // already unpaused
+	case <-*pausec: // already unpaused
 	case <-s.donec:
-		s.pauseTxMu.Unlock()
-		return
 	default:
-		close(s.pauseTxc)
+		close(*pausec)
 	}
-	s.pauseTxMu.Unlock()
+}
+
+func (s *server) StallStreamAfter(messages int) {
+	s.stallMu.Lock()
+	if s.stallc != nil {
+		close(s.stallc)
+	}
+	s.stallAfter, s.stallc, s.stallClaimed = messages, make(chan struct{}), false
+	s.stallMu.Unlock()
 
 	s.lg.Info(
-		"unpaused tx",
+		"set stream stall",
+		zap.Int("after", messages),
 		zap.String("from", s.From()),
 		zap.String("to", s.To()),
 	)
 }
 
-func (s *server) PauseRx() {
-	s.pauseRxMu.Lock()
-	s.pauseRxc = make(chan struct{})
-	s.pauseRxMu.Unlock()
+func (s *server) UnstallStream() {
+	s.stallMu.Lock()
+	if s.stallc != nil {
+		close(s.stallc)
+	}
+	s.stallc, s.stallClaimed = nil, false
+	s.stallMu.Unlock()
 
 	s.lg.Info(
-		"paused rx",
-		zap.String("from", s.To()),
-		zap.String("to", s.From()),
+		"removed stream stall",
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
 	)
 }
 
-func (s *server) UnpauseRx() {
-	s.pauseRxMu.Lock()
-	select {
-	case <-s.pauseRxc: // already unpaused
-	case <-s.donec:
-		s.pauseRxMu.Unlock()
-		return
-	default:
-		close(s.pauseRxc)
-	}
-	s.pauseRxMu.Unlock()
+// streamStall tracks data forwarded on a connection since the stall was set.
+type streamStall struct {
+	stallc    chan struct{}
+	forwarded int
+}
 
+// stallStream returns channel to wait on before forwarding, if connection
+// forwarded enough data to stall, or nil otherwise.
+func (s *server) stallStream(ptype proxyType, stall *streamStall) <-chan struct{} {
+	if ptype != proxyTx {
+		return nil
+	}
+	s.stallMu.Lock()
+	defer s.stallMu.Unlock()
+	if stall.stallc != s.stallc {
+		stall.stallc, stall.forwarded = s.stallc, 0
+	}
+	if s.stallc == nil {
+		return nil
+	}
+	if stall.forwarded < s.stallAfter {
+		stall.forwarded++
+		return nil
+	}
+	if s.stallClaimed {
+		return nil
+	}
+	s.stallClaimed = true
 	s.lg.Info(
-		"unpaused rx",
-		zap.String("from", s.To()),
-		zap.String("to", s.From()),
+		"stalled stream",
+		zap.Int("forwarded", stall.forwarded),
+		zap.String("from", s.From()),
+		zap.String("to", s.To()),
 	)
+	return s.stallc
 }
 
 func (s *server) ResetListener() error {
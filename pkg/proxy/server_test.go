@@ -234,6 +234,46 @@ func testServerDelayAccept(t *testing.T, secure bool) {
 	}
 }
 
+func TestServer_DelayNextAccept(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	data := []byte("Hello World!")
+	transfer := func() time.Duration {
+		now := time.Now()
+		send(t, data, scheme, srcAddr, transport.TLSInfo{})
+		if d := receive(t, ln); !bytes.Equal(data, d) {
+			t.Fatalf("expected %q, got %q", string(data), string(d))
+		}
+		return time.Since(now)
+	}
+
+	p.DelayNextAccept(500 * time.Millisecond)
+	if took := transfer(); took < 400*time.Millisecond {
+		t.Fatalf("expected first connection to be delayed, took %v", took)
+	}
+	if took := transfer(); took > 100*time.Millisecond {
+		t.Fatalf("expected following connection not to be delayed, took %v", took)
+	}
+}
+
 func TestServer_PauseTx(t *testing.T) {
 	lg := zaptest.NewLogger(t)
 	scheme := "unix"
@@ -413,6 +453,782 @@ func TestServer_BlackholeTx(t *testing.T) {
 	}
 }
 
+func TestServer_RampDropRate(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	// ramp ends with all packets dropped
+	cancel := p.RampDropRate(0, 1, 100*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+
+	data := []byte("Hello World!")
+	send(t, data, scheme, srcAddr, transport.TLSInfo{})
+
+	recvc := make(chan []byte, 1)
+	go func() {
+		recvc <- receive(t, ln)
+	}()
+
+	select {
+	case d := <-recvc:
+		t.Fatalf("unexpected data receive %q with full drop rate", string(d))
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// canceling ramp stops dropping packets
+	cancel()
+	data[0]++
+	send(t, data, scheme, srcAddr, transport.TLSInfo{})
+	select {
+	case d := <-recvc:
+		if !bytes.Equal(data, d) {
+			t.Fatalf("expected %q, got %q", string(data), string(d))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("took too long to receive after canceling drop rate ramp")
+	}
+}
+
+func TestServer_DropTx(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	recvc := receiveAll(ln)
+	data := []byte("Hello World!")
+
+	// dropping "incoming" packets does not affect "outgoing" traffic
+	p.DropRx(1)
+	send(t, data, scheme, srcAddr, transport.TLSInfo{})
+	select {
+	case d := <-recvc:
+		if !bytes.Equal(data, d) {
+			t.Fatalf("expected %q, got %q", string(data), string(d))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("took too long to receive with only rx dropped")
+	}
+	p.DropRx(0)
+
+	// full drop rate is equivalent to blackhole
+	p.DropTx(1)
+	data[0]++
+	send(t, data, scheme, srcAddr, transport.TLSInfo{})
+	select {
+	case d := <-recvc:
+		t.Fatalf("unexpected data receive %q with full tx drop rate", string(d))
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	p.DropTx(0)
+	data[0]++
+	send(t, data, scheme, srcAddr, transport.TLSInfo{})
+	select {
+	case d := <-recvc:
+		if !bytes.Equal(data, d) {
+			t.Fatalf("expected %q, got %q", string(data), string(d))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("took too long to receive after removing tx drop rate")
+	}
+}
+
+func TestServer_DropSeed(t *testing.T) {
+	decisions := func(seed int64) []bool {
+		srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+		defer func() {
+			os.RemoveAll(srcAddr)
+			os.RemoveAll(dstAddr)
+		}()
+		p := NewServer(ServerConfig{
+			Logger:   zaptest.NewLogger(t),
+			From:     url.URL{Scheme: "unix", Host: srcAddr},
+			To:       url.URL{Scheme: "unix", Host: dstAddr},
+			DropSeed: seed,
+		})
+		waitForServer(t, p)
+		defer p.Close()
+
+		p.DropTx(0.5)
+		var dropped []bool
+		for i := 0; i < 100; i++ {
+			dropped = append(dropped, p.(*server).dropPacket(proxyTx))
+		}
+		return dropped
+	}
+	assert.Equal(t, decisions(1), decisions(1), "expected proxies with the same seed to drop the same packets")
+	assert.NotEqual(t, decisions(1), decisions(2), "expected proxies with different seeds to drop different packets")
+}
+
+func TestServer_OnMessageTrigger(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	triggered := make(chan struct{}, 1)
+	p.OnMessageTrigger(
+		func(data []byte) bool { return bytes.Contains(data, []byte("vote")) },
+		func() {
+			p.BlackholeTx()
+			triggered <- struct{}{}
+		},
+	)
+
+	// non matching data is forwarded
+	data := []byte("heartbeat")
+	send(t, data, scheme, srcAddr, transport.TLSInfo{})
+	if d := receive(t, ln); !bytes.Equal(data, d) {
+		t.Fatalf("expected %q, got %q", string(data), string(d))
+	}
+
+	// matching data triggers blackhole, which drops it
+	send(t, []byte("vote"), scheme, srcAddr, transport.TLSInfo{})
+	select {
+	case <-triggered:
+	case <-time.After(time.Second):
+		t.Fatal("expected trigger to be called")
+	}
+	recvc := make(chan []byte, 1)
+	go func() {
+		recvc <- receive(t, ln)
+	}()
+	select {
+	case d := <-recvc:
+		t.Fatalf("unexpected data receive %q after trigger", string(d))
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	p.RemoveMessageTrigger()
+	p.UnblackholeTx()
+	send(t, data, scheme, srcAddr, transport.TLSInfo{})
+	select {
+	case d := <-recvc:
+		if !bytes.Equal(data, d) {
+			t.Fatalf("expected %q, got %q", string(data), string(d))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("took too long to receive after removing trigger")
+	}
+}
+
+func TestServer_BandwidthDelay(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	// 10KB per second
+	p.BandwidthDelay(10 * 1024)
+
+	small := []byte("Hello World!")
+	now := time.Now()
+	send(t, small, scheme, srcAddr, transport.TLSInfo{})
+	if d := receive(t, ln); !bytes.Equal(small, d) {
+		t.Fatalf("expected %q, got %q", string(small), string(d))
+	}
+	tookSmall := time.Since(now)
+
+	large := bytes.Repeat([]byte("a"), 5*1024)
+	now = time.Now()
+	send(t, large, scheme, srcAddr, transport.TLSInfo{})
+	if d := receive(t, ln); !bytes.Equal(large, d) {
+		t.Fatalf("expected %d bytes, got %d", len(large), len(d))
+	}
+	tookLarge := time.Since(now)
+	t.Logf("took %v for small packet, %v for large packet", tookSmall, tookLarge)
+
+	if tookSmall > 100*time.Millisecond {
+		t.Fatalf("expected small packet to be forwarded quickly, took %v", tookSmall)
+	}
+	if tookLarge < 400*time.Millisecond {
+		t.Fatalf("expected large packet to be delayed by bandwidth, took %v", tookLarge)
+	}
+
+	p.UnbandwidthDelay()
+	now = time.Now()
+	send(t, large, scheme, srcAddr, transport.TLSInfo{})
+	if d := receive(t, ln); !bytes.Equal(large, d) {
+		t.Fatalf("expected %d bytes, got %d", len(large), len(d))
+	}
+	if took := time.Since(now); took > 100*time.Millisecond {
+		t.Fatalf("expected large packet to be forwarded quickly after removing bandwidth latency, took %v", took)
+	}
+}
+
+func TestServer_RecoverBandwidth(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	large := bytes.Repeat([]byte("a"), 5*1024)
+	transfer := func() time.Duration {
+		now := time.Now()
+		send(t, large, scheme, srcAddr, transport.TLSInfo{})
+		if d := receive(t, ln); !bytes.Equal(large, d) {
+			t.Fatalf("expected %d bytes, got %d", len(large), len(d))
+		}
+		return time.Since(now)
+	}
+
+	// ramp from 10KB per second to 10MB per second
+	cancel := p.RecoverBandwidth(10*1024, 10*1024*1024, time.Second)
+	if took := transfer(); took < 400*time.Millisecond {
+		t.Fatalf("expected large packet to be delayed by low bandwidth, took %v", took)
+	}
+	time.Sleep(time.Second)
+	if took := transfer(); took > 100*time.Millisecond {
+		t.Fatalf("expected large packet to be forwarded quickly after bandwidth recovered, took %v", took)
+	}
+	cancel()
+
+	// canceled ramp leaves the link at the final bandwidth
+	cancel = p.RecoverBandwidth(1024, 10*1024*1024, time.Hour)
+	cancel()
+	if took := transfer(); took > 100*time.Millisecond {
+		t.Fatalf("expected large packet to be forwarded quickly after canceling ramp, took %v", took)
+	}
+}
+
+func TestServer_AsymmetricBandwidth(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	large := bytes.Repeat([]byte("a"), 5*1024)
+	transfer := func() time.Duration {
+		now := time.Now()
+		send(t, large, scheme, srcAddr, transport.TLSInfo{})
+		if d := receive(t, ln); !bytes.Equal(large, d) {
+			t.Fatalf("expected %d bytes, got %d", len(large), len(d))
+		}
+		return time.Since(now)
+	}
+
+	// slow "incoming" link does not delay "outgoing" traffic
+	p.SetAsymmetricBandwidth(0, 1024)
+	if took := transfer(); took > 100*time.Millisecond {
+		t.Fatalf("expected large packet to be forwarded quickly with only rx limited, took %v", took)
+	}
+
+	// slow "outgoing" link delays "outgoing" traffic
+	p.LimitTxBandwidth(10 * 1024)
+	if took := transfer(); took < 400*time.Millisecond {
+		t.Fatalf("expected large packet to be delayed by low tx bandwidth, took %v", took)
+	}
+
+	// adjusting tx limit takes effect immediately
+	p.LimitTxBandwidth(10 * 1024 * 1024)
+	if took := transfer(); took > 100*time.Millisecond {
+		t.Fatalf("expected large packet to be forwarded quickly after raising tx bandwidth, took %v", took)
+	}
+
+	p.UnbandwidthDelay()
+	if took := transfer(); took > 100*time.Millisecond {
+		t.Fatalf("expected large packet to be forwarded quickly after removing bandwidth limits, took %v", took)
+	}
+}
+
+func TestServer_ThrottleTx(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	large := bytes.Repeat([]byte("a"), 5*1024)
+	recvc := receiveAll(ln)
+	// transfer sends large packet over each of given number of connections,
+	// which are forwarded concurrently by the proxy
+	transfer := func(conns int) time.Duration {
+		now := time.Now()
+		for i := 0; i < conns; i++ {
+			send(t, large, scheme, srcAddr, transport.TLSInfo{})
+		}
+		for received := 0; received < conns*len(large); {
+			select {
+			case d := <-recvc:
+				received += len(d)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out, received %d bytes", received)
+			}
+		}
+		return time.Since(now)
+	}
+
+	// 10KB per second shared by connections
+	p.ThrottleTx(10 * 1024)
+	if took := transfer(2); took < 800*time.Millisecond {
+		t.Fatalf("expected concurrent connections to share throttled bandwidth, took %v", took)
+	}
+
+	// "incoming" throttle does not delay "outgoing" traffic
+	p.UnthrottleTx()
+	p.ThrottleRx(1024)
+	if took := transfer(2); took > 100*time.Millisecond {
+		t.Fatalf("expected packets to be forwarded quickly with only rx throttled, took %v", took)
+	}
+	p.UnthrottleRx()
+
+	// removing throttle releases waiting packets
+	p.ThrottleTx(1024)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		p.UnthrottleTx()
+	}()
+	if took := transfer(1); took > time.Second {
+		t.Fatalf("expected waiting packet to be released after removing throttle, took %v", took)
+	}
+}
+
+func TestServer_InjectFault(t *testing.T) {
+	tcs := []struct {
+		name            string
+		spec            FaultSpec
+		expectDelivered bool
+		expectLatency   time.Duration
+	}{
+		{
+			name: "blackhole tx",
+			spec: FaultSpec{Direction: FaultTx, Mode: FaultBlackhole},
+		},
+		{
+			name:            "blackhole rx doesn't affect tx",
+			spec:            FaultSpec{Direction: FaultRx, Mode: FaultBlackhole},
+			expectDelivered: true,
+		},
+		{
+			name: "drop all packets in both directions",
+			spec: FaultSpec{Mode: FaultDrop, Rate: 1},
+		},
+		{
+			name:            "drop all rx packets doesn't affect tx",
+			spec:            FaultSpec{Direction: FaultRx, Mode: FaultDrop, Rate: 1},
+			expectDelivered: true,
+		},
+		{
+			name:            "delay tx",
+			spec:            FaultSpec{Direction: FaultTx, Mode: FaultDelay, Delay: 100 * time.Millisecond},
+			expectDelivered: true,
+			expectLatency:   100 * time.Millisecond,
+		},
+		{
+			name: "pause tx",
+			spec: FaultSpec{Direction: FaultTx, Mode: FaultPause},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := "unix"
+			srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+			defer func() {
+				os.RemoveAll(srcAddr)
+				os.RemoveAll(dstAddr)
+			}()
+			ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+			defer ln.Close()
+
+			p := NewServer(ServerConfig{
+				Logger: zaptest.NewLogger(t),
+				From:   url.URL{Scheme: scheme, Host: srcAddr},
+				To:     url.URL{Scheme: scheme, Host: dstAddr},
+			})
+			waitForServer(t, p)
+			defer p.Close()
+			recvc := receiveAll(ln)
+
+			if err := p.InjectFault(tc.spec); err != nil {
+				t.Fatal(err)
+			}
+			data := []byte("Hello World!")
+			now := time.Now()
+			send(t, data, scheme, srcAddr, transport.TLSInfo{})
+			select {
+			case d := <-recvc:
+				if !tc.expectDelivered {
+					t.Fatalf("unexpected data receive %q with fault %+v", string(d), tc.spec)
+				}
+				if took := time.Since(now); took < tc.expectLatency {
+					t.Fatalf("expected latency at least %v, took %v", tc.expectLatency, took)
+				}
+			case <-time.After(200*time.Millisecond + tc.expectLatency):
+				if tc.expectDelivered {
+					t.Fatalf("took too long to receive with fault %+v", tc.spec)
+				}
+			}
+
+			// removing fault restores forwarding
+			if err := p.RemoveFault(tc.spec); err != nil {
+				t.Fatal(err)
+			}
+			data[0]++
+			send(t, data, scheme, srcAddr, transport.TLSInfo{})
+			timeout := time.After(2 * time.Second)
+			for {
+				select {
+				case d := <-recvc:
+					if bytes.Equal(data, d) {
+						return
+					}
+				case <-timeout:
+					t.Fatalf("took too long to receive after removing fault %+v", tc.spec)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_InjectFault_DropAndDelay(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+	recvc := receiveAll(ln)
+
+	// drop half of "outgoing" packets to the peer and delay the rest
+	latency := 30 * time.Millisecond
+	spec := FaultSpec{Direction: FaultTx, Peer: p.To(), Mode: FaultDrop, Rate: 0.5, Delay: latency}
+	if err := p.InjectFault(spec); err != nil {
+		t.Fatal(err)
+	}
+	sent := 20
+	received := 0
+	for i := 0; i < sent; i++ {
+		data := []byte(fmt.Sprintf("Hello World %d!", i))
+		now := time.Now()
+		send(t, data, scheme, srcAddr, transport.TLSInfo{})
+		select {
+		case d := <-recvc:
+			if !bytes.Equal(data, d) {
+				t.Fatalf("expected %q, got %q", string(data), string(d))
+			}
+			if took := time.Since(now); took < latency {
+				t.Fatalf("expected forwarded packet to be delayed by %v, took %v", latency, took)
+			}
+			received++
+		case <-time.After(latency + 200*time.Millisecond):
+		}
+	}
+	if received == 0 || received == sent {
+		t.Fatalf("expected about half of %d packets to be dropped, received %d", sent, received)
+	}
+}
+
+func TestServer_InjectFault_InvalidSpec(t *testing.T) {
+	tcs := []struct {
+		name        string
+		spec        FaultSpec
+		expectError bool
+	}{
+		{
+			name: "valid spec",
+			spec: FaultSpec{Direction: FaultRx, Mode: FaultDrop, Rate: 0.5, Delay: time.Millisecond},
+		},
+		{
+			name:        "unknown direction",
+			spec:        FaultSpec{Direction: "sideways", Mode: FaultBlackhole},
+			expectError: true,
+		},
+		{
+			name:        "unknown mode",
+			spec:        FaultSpec{Mode: "corrupt"},
+			expectError: true,
+		},
+		{
+			name:        "drop rate above 1",
+			spec:        FaultSpec{Mode: FaultDrop, Rate: 2},
+			expectError: true,
+		},
+		{
+			name:        "negative delay",
+			spec:        FaultSpec{Mode: FaultDelay, Delay: -time.Second},
+			expectError: true,
+		},
+		{
+			name:        "other peer",
+			spec:        FaultSpec{Peer: "unix://other", Mode: FaultBlackhole},
+			expectError: true,
+		},
+	}
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	p := NewServer(ServerConfig{
+		Logger: zaptest.NewLogger(t),
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+	waitForServer(t, p)
+	defer p.Close()
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := p.InjectFault(tc.spec)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("InjectFault(%+v), got error: %v, expect error: %v", tc.spec, err, tc.expectError)
+			}
+			err = p.RemoveFault(tc.spec)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("RemoveFault(%+v), got error: %v, expect error: %v", tc.spec, err, tc.expectError)
+			}
+		})
+	}
+}
+
+func TestServer_InjectFault_DelayWithDropAndDelay(t *testing.T) {
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	p := NewServer(ServerConfig{
+		Logger: zaptest.NewLogger(t),
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+	waitForServer(t, p)
+	defer p.Close()
+
+	steps := []struct {
+		inject        *FaultSpec
+		remove        *FaultSpec
+		expectLatency time.Duration
+	}{
+		{inject: &FaultSpec{Direction: FaultTx, Mode: FaultDelay, Delay: 100 * time.Millisecond}, expectLatency: 100 * time.Millisecond},
+		{inject: &FaultSpec{Direction: FaultTx, Mode: FaultDrop, Rate: 0.5, Delay: 30 * time.Millisecond}, expectLatency: 130 * time.Millisecond},
+		// removing by direction and mode only clears latency of the drop fault
+		{remove: &FaultSpec{Direction: FaultTx, Mode: FaultDrop}, expectLatency: 100 * time.Millisecond},
+		{inject: &FaultSpec{Direction: FaultTx, Mode: FaultDrop, Rate: 0.5, Delay: 30 * time.Millisecond}, expectLatency: 130 * time.Millisecond},
+		{remove: &FaultSpec{Direction: FaultTx, Mode: FaultDelay}, expectLatency: 30 * time.Millisecond},
+		{remove: &FaultSpec{Direction: FaultTx, Mode: FaultDrop}},
+	}
+	for i, step := range steps {
+		if step.inject != nil {
+			if err := p.InjectFault(*step.inject); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if step.remove != nil {
+			if err := p.RemoveFault(*step.remove); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if latency := p.LatencyTx(); latency != step.expectLatency {
+			t.Fatalf("#%d: expected tx latency %v, got %v", i, step.expectLatency, latency)
+		}
+		if latency := p.LatencyRx(); latency != 0 {
+			t.Fatalf("#%d: expected rx latency to be unaffected, got %v", i, latency)
+		}
+	}
+}
+
+func TestServer_StallStreamAfter(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	scheme := "unix"
+	srcAddr, dstAddr := newUnixAddr(), newUnixAddr()
+	defer func() {
+		os.RemoveAll(srcAddr)
+		os.RemoveAll(dstAddr)
+	}()
+	ln := listen(t, scheme, dstAddr, transport.TLSInfo{})
+	defer ln.Close()
+
+	p := NewServer(ServerConfig{
+		Logger: lg,
+		From:   url.URL{Scheme: scheme, Host: srcAddr},
+		To:     url.URL{Scheme: scheme, Host: dstAddr},
+	})
+
+	waitForServer(t, p)
+
+	defer p.Close()
+
+	p.StallStreamAfter(1)
+
+	// Large message stalls the stream after the first one was forwarded.
+	stream, err := net.Dial(scheme, srcAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	in, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	first := []byte("first")
+	writeAndWait(t, stream, first)
+	if d := readFor(in, len(first), time.Second); !bytes.Equal(first, d) {
+		t.Fatalf("expected %q, got %q", string(first), string(d))
+	}
+	large := bytes.Repeat([]byte("x"), 1024)
+	writeAndWait(t, stream, large)
+	if d := readFor(in, len(large), 200*time.Millisecond); len(d) != 0 {
+		t.Fatalf("received %d bytes on stalled stream", len(d))
+	}
+
+	// Heartbeat sent on a separate stream is not blocked behind the stalled message.
+	heartbeat := []byte("heartbeat")
+	recvc := receiveAll(ln)
+	send(t, heartbeat, scheme, srcAddr, transport.TLSInfo{})
+	select {
+	case d := <-recvc:
+		if !bytes.Equal(heartbeat, d) {
+			t.Fatalf("expected %q, got %q", string(heartbeat), string(d))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("heartbeat on separate stream was blocked by stalled stream")
+	}
+
+	p.UnstallStream()
+
+	if d := readFor(in, len(large), 2*time.Second); !bytes.Equal(large, d) {
+		t.Fatalf("expected %d bytes after unstall, got %d", len(large), len(d))
+	}
+}
+
+// writeAndWait writes data and gives proxy time to read it as a separate chunk.
+func writeAndWait(t *testing.T, conn net.Conn, data []byte) {
+	if _, err := conn.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+}
+
+// readFor reads up to n bytes from conn, until timeout elapses.
+func readFor(conn net.Conn, n int, timeout time.Duration) []byte {
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	data, _ := io.ReadAll(io.LimitReader(conn, int64(n)))
+	return data
+}
+
 func TestServer_Shutdown(t *testing.T) {
 	lg := zaptest.NewLogger(t)
 	scheme := "unix"
@@ -688,6 +1504,25 @@ func receive(t *testing.T, ln net.Listener) (data []byte) {
 	return buf.Bytes()
 }
 
+// receiveAll receives data of all non-empty connections until listener is closed.
+func receiveAll(ln net.Listener) <-chan []byte {
+	recvc := make(chan []byte, 100)
+	go func() {
+		for {
+			in, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			data, err := io.ReadAll(in)
+			in.Close()
+			if err == nil && len(data) > 0 {
+				recvc <- data
+			}
+		}
+	}()
+	return recvc
+}
+
 // Waits until a proxy is ready to serve.
 // Aborts test on proxy start-up error.
 func waitForServer(t *testing.T, s Server) {
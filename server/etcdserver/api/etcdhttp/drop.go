@@ -0,0 +1,89 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdhttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var errDroppedRequest = errors.New("peer request dropped by failpoint")
+
+// dropRequests wraps peer handler to drop requests to paths set by
+// raftDropRequestPaths failpoint, allowing to mock partition of raft traffic
+// while keeping membership, version and probing endpoints available.
+// Streams established before the failpoint was set are torn down on the next
+// write, so they have to be re-established through the failpoint.
+func dropRequests(lg *zap.Logger, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if matchRequestPath(dropRequestPaths(), r.URL.Path) {
+			lg.Debug("dropped peer request", zap.String("path", r.URL.Path))
+			io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		h.ServeHTTP(&dropResponseWriter{ResponseWriter: w, path: r.URL.Path}, r)
+	})
+}
+
+func dropRequestPaths() string {
+	// gofail: var raftDropRequestPaths string
+	// return raftDropRequestPaths
+	return ""
+}
+
+// dropResponseWriter fails writes of response to request, whose path
+// started matching raftDropRequestPaths failpoint.
+type dropResponseWriter struct {
+	http.ResponseWriter
+	path string
+}
+
+func (w *dropResponseWriter) Write(p []byte) (int, error) {
+	if matchRequestPath(dropRequestPaths(), w.path) {
+		return 0, errDroppedRequest
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *dropResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// matchRequestPath reports whether path matches any of comma separated
+// patterns. Like in http.ServeMux, pattern ending with slash matches all
+// paths under it, other patterns match only the exact path, so "/raft"
+// matches pipeline messages but not "/raft/probing".
+func matchRequestPath(patterns, path string) bool {
+	if patterns == "" {
+		return false
+	}
+	for _, p := range strings.Split(patterns, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if p == path || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
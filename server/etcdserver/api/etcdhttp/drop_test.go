@@ -0,0 +1,41 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdhttp
+
+import "testing"
+
+func TestMatchRequestPath(t *testing.T) {
+	tests := []struct {
+		patterns string
+		path     string
+		want     bool
+	}{
+		{patterns: "", path: "/raft", want: false},
+		{patterns: "/raft", path: "/raft", want: true},
+		{patterns: "/raft", path: "/raft/probing", want: false},
+		{patterns: "/raft", path: "/raft/stream/message/1", want: false},
+		{patterns: "/raft,/raft/stream/", path: "/raft/stream/msgappv2/1", want: true},
+		{patterns: "/raft, /raft/stream/", path: "/raft/stream/message/1", want: true},
+		{patterns: "/raft,/raft/stream/", path: "/raft/probing", want: false},
+		{patterns: "/raft,/raft/stream/", path: "/members", want: false},
+		{patterns: "/raft,/raft/stream/", path: "/version", want: false},
+		{patterns: "/raft/stream/", path: "/raft/stream", want: false},
+	}
+	for _, tt := range tests {
+		if got := matchRequestPath(tt.patterns, tt.path); got != tt.want {
+			t.Errorf("matchRequestPath(%q, %q) = %v, want %v", tt.patterns, tt.path, got, tt.want)
+		}
+	}
+}
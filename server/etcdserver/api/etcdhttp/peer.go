@@ -73,7 +73,7 @@ func newPeerHandler(
 		mux.Handle(etcdserver.PeerHashKVPath, hashKVHandler)
 	}
 	mux.HandleFunc(versionPath, versionHandler(s, serveVersion))
-	return mux
+	return dropRequests(lg, mux)
 }
 
 func newPeerMembersHandler(lg *zap.Logger, cluster api.Cluster) http.Handler {
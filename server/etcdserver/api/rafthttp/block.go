@@ -0,0 +1,95 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var errPeerBlocked = errors.New("peer blocked by failpoint")
+
+// blockingRoundTripper fails raft requests to peers set by raftBlockPeerURLs
+// failpoint, allowing to mock partition between selected peers without
+// proxy. Only raft requests carry X-PeerURLs header, so probing requests
+// always pass through. Response bodies of requests to a peer that becomes
+// blocked fail on the next read, tearing down established streams.
+type blockingRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func newBlockingRoundTripper(rt http.RoundTripper) *blockingRoundTripper {
+	return &blockingRoundTripper{rt: rt}
+}
+
+func (b *blockingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("X-PeerURLs") == "" {
+		return b.rt.RoundTrip(req)
+	}
+	if isPeerBlocked(blockedPeerURLs(), req) {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, errPeerBlocked
+	}
+	resp, err := b.rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &blockingReadCloser{ReadCloser: resp.Body, req: req}
+	return resp, nil
+}
+
+func (b *blockingRoundTripper) CloseIdleConnections() {
+	if tr, ok := b.rt.(interface{ CloseIdleConnections() }); ok {
+		tr.CloseIdleConnections()
+	}
+}
+
+type blockingReadCloser struct {
+	io.ReadCloser
+	req *http.Request
+}
+
+func (r *blockingReadCloser) Read(p []byte) (int, error) {
+	if isPeerBlocked(blockedPeerURLs(), r.req) {
+		return 0, errPeerBlocked
+	}
+	return r.ReadCloser.Read(p)
+}
+
+func blockedPeerURLs() string {
+	// gofail: var raftBlockPeerURLs string
+	// return raftBlockPeerURLs
+	return ""
+}
+
+// isPeerBlocked reports whether request is sent to any of comma separated
+// peer urls. X-PeerURLs header holds urls of the local member, so the peer
+// is matched by request url.
+func isPeerBlocked(urls string, req *http.Request) bool {
+	if urls == "" {
+		return false
+	}
+	target := req.URL.Scheme + "://" + req.URL.Host
+	for _, u := range strings.Split(urls, ",") {
+		if strings.TrimSuffix(strings.TrimSpace(u), "/") == target {
+			return true
+		}
+	}
+	return false
+}
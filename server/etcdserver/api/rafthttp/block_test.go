@@ -0,0 +1,44 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIsPeerBlocked(t *testing.T) {
+	tests := []struct {
+		urls string
+		url  string
+		want bool
+	}{
+		{urls: "", url: "http://localhost:2380/raft", want: false},
+		{urls: "http://localhost:2380", url: "http://localhost:2380/raft", want: true},
+		{urls: "http://localhost:2380/", url: "http://localhost:2380/raft/stream/message/1", want: true},
+		{urls: "http://localhost:2381, http://localhost:2380", url: "http://localhost:2380/raft", want: true},
+		{urls: "http://localhost:2381", url: "http://localhost:2380/raft", want: false},
+		{urls: "https://localhost:2380", url: "http://localhost:2380/raft", want: false},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := isPeerBlocked(tt.urls, req); got != tt.want {
+			t.Errorf("isPeerBlocked(%q, %q) = %v, want %v", tt.urls, tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"go.uber.org/zap"
+
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// dropAfterCampaign reports whether message sent by the member should be
+// dropped, as the member started campaigning while raftBlackholeOnCampaign
+// failpoint is active. Member stays blackholed from the first vote request
+// it sends, including that request, until the failpoint is deactivated.
+// Message sent while failpoint is inactive resets it, so re-enabled failpoint
+// waits for the next campaign.
+func (t *Transport) dropAfterCampaign(m raftpb.Message, active bool) bool {
+	if !active {
+		t.campaigned.Store(false)
+		return false
+	}
+	if (m.Type == raftpb.MsgVote || m.Type == raftpb.MsgPreVote) && !t.campaigned.Swap(true) && t.Logger != nil {
+		t.Logger.Info(
+			"blackholing member after it started campaigning",
+			zap.String("local-member-id", t.ID.String()),
+			zap.String("message-type", m.Type.String()),
+			zap.Uint64("term", m.Term),
+		)
+	}
+	return t.campaigned.Load()
+}
+
+func blackholeOnCampaign() bool {
+	// gofail: var raftBlackholeOnCampaign struct{}
+	// return true
+	return false
+}
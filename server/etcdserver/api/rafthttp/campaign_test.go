@@ -0,0 +1,47 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"testing"
+
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestDropAfterCampaign(t *testing.T) {
+	tests := []struct {
+		m        raftpb.Message
+		inactive bool
+		wantDrop bool
+	}{
+		{m: raftpb.Message{Type: raftpb.MsgHeartbeat}},
+		{m: raftpb.Message{Type: raftpb.MsgAppResp}},
+		{m: raftpb.Message{Type: raftpb.MsgPreVote}, wantDrop: true},
+		{m: raftpb.Message{Type: raftpb.MsgVote}, wantDrop: true},
+		{m: raftpb.Message{Type: raftpb.MsgHeartbeatResp}, wantDrop: true},
+		// deactivated failpoint resets blackhole
+		{m: raftpb.Message{Type: raftpb.MsgVote}, inactive: true},
+		// re-enabled failpoint waits for the next campaign
+		{m: raftpb.Message{Type: raftpb.MsgHeartbeat}},
+		{m: raftpb.Message{Type: raftpb.MsgPreVote}, wantDrop: true},
+		{m: raftpb.Message{Type: raftpb.MsgHeartbeat}, wantDrop: true},
+	}
+	tr := &Transport{}
+	for i, tt := range tests {
+		if drop := tr.dropAfterCampaign(tt.m, !tt.inactive); drop != tt.wantDrop {
+			t.Errorf("#%d: drop = %v, want %v", i, drop, tt.wantDrop)
+		}
+	}
+}
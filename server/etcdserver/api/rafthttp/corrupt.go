@@ -0,0 +1,109 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// newCorruptReader wraps reader of raft messages received by stream or
+// pipeline handler, to verify that corrupted wire data is rejected.
+func newCorruptReader(r io.Reader) *corruptReader {
+	return &corruptReader{r: r}
+}
+
+// corruption describes bytes of data corrupted by corruptReader.
+type corruption struct {
+	// truncate ends data at offset, instead of flipping bytes.
+	truncate bool
+	offset   int
+	length   int
+}
+
+// parseCorruption parses corruption given as "flip:<offset>:<length>",
+// which flips length bytes starting at offset, or "truncate:<offset>",
+// which ends data at offset.
+func parseCorruption(spec string) (corruption, error) {
+	parts := strings.Split(spec, ":")
+	var c corruption
+	var err error
+	switch {
+	case len(parts) == 3 && parts[0] == "flip":
+		if c.offset, err = strconv.Atoi(parts[1]); err != nil {
+			return c, err
+		}
+		if c.length, err = strconv.Atoi(parts[2]); err != nil {
+			return c, err
+		}
+	case len(parts) == 2 && parts[0] == "truncate":
+		c.truncate = true
+		if c.offset, err = strconv.Atoi(parts[1]); err != nil {
+			return c, err
+		}
+	default:
+		return c, fmt.Errorf("invalid corruption %q, expected flip:<offset>:<length> or truncate:<offset>", spec)
+	}
+	if c.offset < 0 || c.length < 0 {
+		return c, fmt.Errorf("invalid corruption %q, offset and length must not be negative", spec)
+	}
+	return c, nil
+}
+
+// corruptReader corrupts data read from the underlying reader while
+// raftCorruptMessage failpoint is active. Offsets are counted from the
+// first read after the failpoint was set to the given corruption.
+type corruptReader struct {
+	r io.Reader
+	// read is the number of bytes read so far.
+	read int
+
+	spec  string
+	c     corruption
+	start int
+}
+
+func (cr *corruptReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	// gofail: var raftCorruptMessage string
+	// n, err = cr.corrupt(raftCorruptMessage, p, n, err)
+	cr.read += n
+	return n, err
+}
+
+// corrupt applies corruption given by spec to data just read into p.
+// Invalid spec leaves data intact.
+func (cr *corruptReader) corrupt(spec string, p []byte, n int, err error) (int, error) {
+	if spec != cr.spec {
+		c, perr := parseCorruption(spec)
+		if perr != nil {
+			return n, err
+		}
+		cr.spec, cr.c, cr.start = spec, c, cr.read
+	}
+	pos := cr.read - cr.start
+	if cr.c.truncate {
+		if pos+n > cr.c.offset {
+			return max(cr.c.offset-pos, 0), io.ErrUnexpectedEOF
+		}
+		return n, err
+	}
+	for i := max(cr.c.offset-pos, 0); i < n && pos+i < cr.c.offset+cr.c.length; i++ {
+		p[i] ^= 0xff
+	}
+	return n, err
+}
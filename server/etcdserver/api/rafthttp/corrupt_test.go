@@ -0,0 +1,118 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestParseCorruption(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    corruption
+		wantErr bool
+	}{
+		{spec: "flip:2:3", want: corruption{offset: 2, length: 3}},
+		{spec: "truncate:5", want: corruption{truncate: true, offset: 5}},
+		{spec: "flip:2", wantErr: true},
+		{spec: "truncate:a", wantErr: true},
+		{spec: "flip:-1:2", wantErr: true},
+		{spec: "drop", wantErr: true},
+	}
+	for _, tt := range tests {
+		c, err := parseCorruption(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCorruption(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if err == nil && c != tt.want {
+			t.Errorf("parseCorruption(%q) = %+v, want %+v", tt.spec, c, tt.want)
+		}
+	}
+}
+
+func TestCorruptReader(t *testing.T) {
+	data := []byte("abcdefgh")
+	tests := []struct {
+		spec    string
+		want    []byte
+		wantErr error
+	}{
+		{spec: "flip:2:3", want: []byte{'a', 'b', 'c' ^ 0xff, 'd' ^ 0xff, 'e' ^ 0xff, 'f', 'g', 'h'}},
+		{spec: "flip:6:10", want: []byte{'a', 'b', 'c', 'd', 'e', 'f', 'g' ^ 0xff, 'h' ^ 0xff}},
+		{spec: "flip:10:1", want: data},
+		{spec: "truncate:3", want: []byte("abc"), wantErr: io.ErrUnexpectedEOF},
+		{spec: "invalid", want: data},
+	}
+	for _, tt := range tests {
+		// Offsets are counted across reads.
+		for _, r := range []io.Reader{bytes.NewReader(data), iotest.OneByteReader(bytes.NewReader(data))} {
+			got, err := io.ReadAll(&failpointReader{cr: newCorruptReader(r), spec: tt.spec})
+			if err != tt.wantErr {
+				t.Errorf("%q: error = %v, want %v", tt.spec, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("%q: data = %q, want %q", tt.spec, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestCorruptReaderOffsetFromActivation(t *testing.T) {
+	cr := newCorruptReader(iotest.OneByteReader(bytes.NewReader([]byte("abcd"))))
+	b := make([]byte, 2)
+	if _, err := io.ReadFull(cr, b); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(&failpointReader{cr: cr, spec: "flip:0:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{'c' ^ 0xff, 'd'}; !bytes.Equal(got, want) {
+		t.Errorf("data = %q, want %q", got, want)
+	}
+}
+
+func TestCorruptedMessageRejected(t *testing.T) {
+	m := raftpb.Message{Type: raftpb.MsgApp, From: 1, To: 2, Entries: []raftpb.Entry{{Term: 1, Index: 4}}}
+	for _, spec := range []string{"flip:0:1", "truncate:10"} {
+		b := &bytes.Buffer{}
+		if err := (&messageEncoder{w: b}).encode(&m); err != nil {
+			t.Fatal(err)
+		}
+		dec := &messageDecoder{r: &failpointReader{cr: newCorruptReader(b), spec: spec}}
+		if _, err := dec.decode(); err == nil {
+			t.Errorf("%q: expected decode error of corrupted message", spec)
+		}
+	}
+}
+
+// failpointReader reads corruptReader as if raftCorruptMessage failpoint was set to spec.
+type failpointReader struct {
+	cr   *corruptReader
+	spec string
+}
+
+func (fr *failpointReader) Read(p []byte) (int, error) {
+	n, err := fr.cr.r.Read(p)
+	n, err = fr.cr.corrupt(fr.spec, p, n, err)
+	fr.cr.read += n
+	return n, err
+}
@@ -110,7 +110,7 @@ func (h *pipelineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Limit the data size that could be read from the request body, which ensures that read from
 	// connection will not time out accidentally due to possible blocking in underlying implementation.
-	limitedr := pioutil.NewLimitedBufferReader(r.Body, connReadLimitByte)
+	limitedr := pioutil.NewLimitedBufferReader(newCorruptReader(r.Body), connReadLimitByte)
 	b, err := io.ReadAll(limitedr)
 	if err != nil {
 		h.lg.Warn(
@@ -137,6 +137,12 @@ func (h *pipelineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	receivedBytes.WithLabelValues(types.ID(m.From).String()).Add(float64(len(b)))
 
+	if shouldDropMsgApp(&m) {
+		// Acknowledge the message, sender cannot tell it was dropped.
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	if err := h.r.Process(context.TODO(), m); err != nil {
 		switch v := err.(type) {
 		case writerToResponse:
@@ -442,7 +448,7 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	conn := &outgoingConn{
 		t:       t,
 		Writer:  w,
-		Flusher: w.(http.Flusher),
+		Flusher: delayedFlusher{w.(http.Flusher)},
 		Closer:  c,
 		localID: h.tr.ID,
 		peerID:  from,
@@ -451,6 +457,17 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	<-c.closeNotify()
 }
 
+// delayedFlusher allows to delay flushing messages written to stream,
+// simulating slow consumer to test stream writer under backpressure.
+type delayedFlusher struct {
+	http.Flusher
+}
+
+func (f delayedFlusher) Flush() {
+	// gofail: var raftBeforeStreamFlush struct{}
+	f.Flusher.Flush()
+}
+
 // checkClusterCompatibilityFromHeader checks the cluster compatibility of
 // the local member from the given header.
 // It checks whether the version of local member is compatible with
@@ -0,0 +1,48 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"sync"
+
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// staleHeartbeat captures a heartbeat received from the leader, to replay it
+// after leadership changed while raftReplayStaleHeartbeats failpoint is active.
+// It's shared by all stream readers of a transport, as heartbeats of the new
+// leader arrive on a different stream than ones captured from the old leader.
+type staleHeartbeat struct {
+	mu sync.Mutex
+	m  *raftpb.Message
+}
+
+// replay captures the first heartbeat it observes, and returns it back for
+// every later heartbeat from a newer term.
+func (s *staleHeartbeat) replay(m raftpb.Message) (raftpb.Message, bool) {
+	if m.Type != raftpb.MsgHeartbeat {
+		return raftpb.Message{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = &m
+		return raftpb.Message{}, false
+	}
+	if m.Term <= s.m.Term {
+		return raftpb.Message{}, false
+	}
+	return *s.m, true
+}
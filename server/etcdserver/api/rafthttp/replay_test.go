@@ -0,0 +1,51 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"reflect"
+	"testing"
+
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestStaleHeartbeatReplay(t *testing.T) {
+	old := raftpb.Message{Type: raftpb.MsgHeartbeat, From: 1, To: 3, Term: 2, Commit: 5}
+	tests := []struct {
+		m          raftpb.Message
+		wantReplay bool
+	}{
+		// append messages are not captured
+		{m: raftpb.Message{Type: raftpb.MsgApp, From: 1, To: 3, Term: 2}},
+		// first heartbeat is captured
+		{m: old},
+		// heartbeats from the same term are not replayed
+		{m: raftpb.Message{Type: raftpb.MsgHeartbeat, From: 1, To: 3, Term: 2, Commit: 6}},
+		// only heartbeats of newer term trigger replay
+		{m: raftpb.Message{Type: raftpb.MsgApp, From: 2, To: 3, Term: 3}},
+		{m: raftpb.Message{Type: raftpb.MsgHeartbeat, From: 2, To: 3, Term: 3}, wantReplay: true},
+		{m: raftpb.Message{Type: raftpb.MsgHeartbeat, From: 2, To: 3, Term: 3}, wantReplay: true},
+	}
+	var s staleHeartbeat
+	for i, tt := range tests {
+		replayed, ok := s.replay(tt.m)
+		if ok != tt.wantReplay {
+			t.Fatalf("#%d: replay = %v, want %v", i, ok, tt.wantReplay)
+		}
+		if ok && !reflect.DeepEqual(replayed, old) {
+			t.Errorf("#%d: replayed = %+v, want %+v", i, replayed, old)
+		}
+	}
+}
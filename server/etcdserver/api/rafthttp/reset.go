@@ -0,0 +1,119 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errStreamReset = errors.New("stream reset by failpoint")
+
+// newResetReader wraps connection of stream reader, to verify that stream
+// torn down in the middle of transfer is re-established.
+func newResetReader(rc io.ReadCloser) *resetReader {
+	return &resetReader{rc: rc}
+}
+
+// reset describes when resetReader tears down the stream.
+type reset struct {
+	// bytes resets the stream after reading given number of bytes.
+	bytes int
+	// after resets the stream after given duration, if bytes is not set.
+	after time.Duration
+}
+
+// parseReset parses reset given as "bytes:<n>", which resets the stream
+// after reading n bytes, or "after:<duration>", which resets the stream
+// once duration has passed.
+func parseReset(spec string) (reset, error) {
+	parts := strings.Split(spec, ":")
+	var r reset
+	var err error
+	switch {
+	case len(parts) == 2 && parts[0] == "bytes":
+		if r.bytes, err = strconv.Atoi(parts[1]); err != nil {
+			return r, err
+		}
+		if r.bytes <= 0 {
+			return r, fmt.Errorf("invalid reset %q, bytes must be positive", spec)
+		}
+	case len(parts) == 2 && parts[0] == "after":
+		if r.after, err = time.ParseDuration(parts[1]); err != nil {
+			return r, err
+		}
+		if r.after <= 0 {
+			return r, fmt.Errorf("invalid reset %q, duration must be positive", spec)
+		}
+	default:
+		return r, fmt.Errorf("invalid reset %q, expected bytes:<n> or after:<duration>", spec)
+	}
+	return r, nil
+}
+
+// resetReader closes the underlying connection while raftResetStream
+// failpoint is active, simulating TCP reset in the middle of transfer.
+// Bytes and duration are counted from the first read after the failpoint
+// was set to the given reset.
+type resetReader struct {
+	rc io.ReadCloser
+	// read is the number of bytes read so far.
+	read   int
+	closed bool
+
+	spec      string
+	r         reset
+	start     int
+	startTime time.Time
+}
+
+func (rr *resetReader) Read(p []byte) (int, error) {
+	if rr.closed {
+		return 0, errStreamReset
+	}
+	n, err := rr.rc.Read(p)
+	// gofail: var raftResetStream string
+	// n, err = rr.reset(raftResetStream, n, err)
+	rr.read += n
+	return n, err
+}
+
+// reset closes the connection if reset given by spec is due, keeping only
+// data read before it. Invalid spec leaves the connection intact.
+func (rr *resetReader) reset(spec string, n int, err error) (int, error) {
+	if spec != rr.spec {
+		r, perr := parseReset(spec)
+		if perr != nil {
+			return n, err
+		}
+		rr.spec, rr.r, rr.start, rr.startTime = spec, r, rr.read, time.Now()
+	}
+	pos := rr.read - rr.start
+	switch {
+	case rr.r.bytes > 0 && pos+n >= rr.r.bytes:
+		n = rr.r.bytes - pos
+	case rr.r.bytes == 0 && time.Since(rr.startTime) >= rr.r.after:
+		n = 0
+	default:
+		return n, err
+	}
+	rr.closed = true
+	rr.rc.Close()
+	return n, errStreamReset
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestParseReset(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    reset
+		wantErr bool
+	}{
+		{spec: "bytes:10", want: reset{bytes: 10}},
+		{spec: "after:1s", want: reset{after: time.Second}},
+		{spec: "bytes:0", wantErr: true},
+		{spec: "bytes:a", wantErr: true},
+		{spec: "after:1", wantErr: true},
+		{spec: "after:-1s", wantErr: true},
+		{spec: "close", wantErr: true},
+	}
+	for _, tt := range tests {
+		r, err := parseReset(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseReset(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if err == nil && r != tt.want {
+			t.Errorf("parseReset(%q) = %+v, want %+v", tt.spec, r, tt.want)
+		}
+	}
+}
+
+func TestResetReader(t *testing.T) {
+	data := []byte("abcdefgh")
+	tests := []struct {
+		spec    string
+		want    []byte
+		wantErr error
+	}{
+		{spec: "bytes:3", want: []byte("abc"), wantErr: errStreamReset},
+		{spec: "bytes:8", want: data, wantErr: errStreamReset},
+		{spec: "bytes:10", want: data},
+		{spec: "after:1ns", want: []byte{}, wantErr: errStreamReset},
+		{spec: "after:1h", want: data},
+		{spec: "invalid", want: data},
+	}
+	for _, tt := range tests {
+		// Bytes are counted across reads.
+		for _, r := range []io.Reader{bytes.NewReader(data), iotest.OneByteReader(bytes.NewReader(data))} {
+			rc := &closeRecorder{Reader: r}
+			got, err := io.ReadAll(&resetFailpointReader{rr: newResetReader(rc), spec: tt.spec})
+			if err != tt.wantErr {
+				t.Errorf("%q: error = %v, want %v", tt.spec, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("%q: data = %q, want %q", tt.spec, got, tt.want)
+			}
+			if rc.closed != (tt.wantErr != nil) {
+				t.Errorf("%q: closed = %v, want %v", tt.spec, rc.closed, tt.wantErr != nil)
+			}
+		}
+	}
+}
+
+func TestResetReaderBytesFromActivation(t *testing.T) {
+	rr := newResetReader(&closeRecorder{Reader: iotest.OneByteReader(bytes.NewReader([]byte("abcd")))})
+	b := make([]byte, 2)
+	if _, err := io.ReadFull(rr, b); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(&resetFailpointReader{rr: rr, spec: "bytes:1"})
+	if err != errStreamReset {
+		t.Fatalf("error = %v, want %v", err, errStreamReset)
+	}
+	if want := []byte("c"); !bytes.Equal(got, want) {
+		t.Errorf("data = %q, want %q", got, want)
+	}
+}
+
+func TestResetMessageStream(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := &messageEncoder{w: buf}
+	for i := 0; i < 2; i++ {
+		if err := enc.encode(&linkHeartbeatMessage); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Reset in the middle of the second message.
+	spec := "bytes:" + strconv.Itoa(buf.Len()-1)
+	dec := &messageDecoder{r: &resetFailpointReader{rr: newResetReader(&closeRecorder{Reader: buf}), spec: spec}}
+	if _, err := dec.decode(); err != nil {
+		t.Fatalf("unexpected decode error of first message: %v", err)
+	}
+	if _, err := dec.decode(); err == nil {
+		t.Errorf("expected decode error of partially received message")
+	}
+}
+
+// resetFailpointReader reads resetReader as if raftResetStream failpoint was set to spec.
+type resetFailpointReader struct {
+	rr   *resetReader
+	spec string
+}
+
+func (fr *resetFailpointReader) Read(p []byte) (int, error) {
+	if fr.rr.closed {
+		return 0, errStreamReset
+	}
+	n, err := fr.rr.rc.Read(p)
+	n, err = fr.rr.reset(fr.spec, n, err)
+	fr.rr.read += n
+	return n, err
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
@@ -100,6 +100,15 @@ func isLinkHeartbeatMessage(m *raftpb.Message) bool {
 	return m.Type == raftpb.MsgHeartbeat && m.From == 0 && m.To == 0
 }
 
+// shouldDropMsgApp reports whether received append message should be dropped
+// to stall log replication, while heartbeats and votes are still delivered.
+// It's shared by stream and pipeline handlers, so a single failpoint covers both.
+func shouldDropMsgApp(m *raftpb.Message) bool {
+	// gofail: var raftDropMsgApp struct{}
+	// return m.Type == raftpb.MsgApp
+	return false
+}
+
 type outgoingConn struct {
 	t streamType
 	io.Writer
@@ -465,12 +474,13 @@ func (cr *streamReader) run() {
 
 func (cr *streamReader) decodeLoop(rc io.ReadCloser, t streamType) error {
 	var dec decoder
+	r := newCorruptReader(newResetReader(rc))
 	cr.mu.Lock()
 	switch t {
 	case streamTypeMsgAppV2:
-		dec = newMsgAppV2Decoder(rc, cr.tr.ID, cr.peerID)
+		dec = newMsgAppV2Decoder(r, cr.tr.ID, cr.peerID)
 	case streamTypeMessage:
-		dec = &messageDecoder{r: rc}
+		dec = &messageDecoder{r: r}
 	default:
 		if cr.lg != nil {
 			cr.lg.Panic("unknown stream type", zap.String("type", t.String()))
@@ -488,7 +498,7 @@ func (cr *streamReader) decodeLoop(rc io.ReadCloser, t streamType) error {
 	}
 	cr.mu.Unlock()
 
-	// gofail: labelRaftDropHeartbeat:
+	// gofail-go: labelRaftDropHeartbeat:
 	for {
 		m, err := dec.decode()
 		if err != nil {
@@ -500,6 +510,9 @@ func (cr *streamReader) decodeLoop(rc io.ReadCloser, t streamType) error {
 
 		// gofail-go: var raftDropHeartbeat struct{}
 		// continue labelRaftDropHeartbeat
+		if shouldDropMsgApp(&m) {
+			continue
+		}
 		receivedBytes.WithLabelValues(types.ID(m.From).String()).Add(float64(m.Size()))
 
 		cr.mu.Lock()
@@ -522,6 +535,9 @@ func (cr *streamReader) decodeLoop(rc io.ReadCloser, t streamType) error {
 			recvc = cr.propc
 		}
 
+		// gofail: var raftReplayStaleHeartbeats struct{}
+		// cr.replayStaleHeartbeat(recvc, m)
+
 		select {
 		case recvc <- m:
 		default:
@@ -672,6 +688,26 @@ func (cr *streamReader) dial(t streamType) (io.ReadCloser, error) {
 	}
 }
 
+// replayStaleHeartbeat delivers heartbeat captured from an old leader along
+// with heartbeats of a newer term, so followers need to reject it.
+func (cr *streamReader) replayStaleHeartbeat(recvc chan<- raftpb.Message, m raftpb.Message) {
+	stale, ok := cr.tr.staleHeartbeat.replay(m)
+	if !ok {
+		return
+	}
+	cr.lg.Info(
+		"replaying stale heartbeat",
+		zap.String("local-member-id", cr.tr.ID.String()),
+		zap.String("from", types.ID(stale.From).String()),
+		zap.Uint64("stale-term", stale.Term),
+		zap.Uint64("term", m.Term),
+	)
+	select {
+	case recvc <- stale:
+	default:
+	}
+}
+
 func (cr *streamReader) close() {
 	if cr.closer != nil {
 		if err := cr.closer.Close(); err != nil {
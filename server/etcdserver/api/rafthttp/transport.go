@@ -18,6 +18,7 @@ import (
 	"context"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/xiang90/probing"
@@ -128,18 +129,25 @@ type Transport struct {
 
 	pipelineProber probing.Prober
 	streamProber   probing.Prober
+
+	// staleHeartbeat is replayed by stream readers while raftReplayStaleHeartbeats failpoint is active.
+	staleHeartbeat staleHeartbeat
+	// campaigned is set once member sent a vote request while raftBlackholeOnCampaign failpoint is active,
+	// and reset by the first message sent after it's deactivated.
+	campaigned atomic.Bool
 }
 
 func (t *Transport) Start() error {
-	var err error
-	t.streamRt, err = newStreamRoundTripper(t.TLSInfo, t.DialTimeout)
+	streamRt, err := newStreamRoundTripper(t.TLSInfo, t.DialTimeout)
 	if err != nil {
 		return err
 	}
-	t.pipelineRt, err = NewRoundTripper(t.TLSInfo, t.DialTimeout)
+	t.streamRt = newBlockingRoundTripper(streamRt)
+	pipelineRt, err := NewRoundTripper(t.TLSInfo, t.DialTimeout)
 	if err != nil {
 		return err
 	}
+	t.pipelineRt = newBlockingRoundTripper(pipelineRt)
 	t.remotes = make(map[types.ID]*remote)
 	t.peers = make(map[types.ID]Peer)
 	t.pipelineProber = probing.NewProber(t.pipelineRt)
@@ -178,6 +186,9 @@ func (t *Transport) Send(msgs []raftpb.Message) {
 			// ignore intentionally dropped message
 			continue
 		}
+		if t.dropAfterCampaign(m, blackholeOnCampaign()) {
+			continue
+		}
 		to := types.ID(m.To)
 
 		t.mu.RLock()
@@ -219,10 +230,10 @@ func (t *Transport) Stop() {
 	}
 	t.pipelineProber.RemoveAll()
 	t.streamProber.RemoveAll()
-	if tr, ok := t.streamRt.(*http.Transport); ok {
+	if tr, ok := t.streamRt.(interface{ CloseIdleConnections() }); ok {
 		tr.CloseIdleConnections()
 	}
-	if tr, ok := t.pipelineRt.(*http.Transport); ok {
+	if tr, ok := t.pipelineRt.(interface{ CloseIdleConnections() }); ok {
 		tr.CloseIdleConnections()
 	}
 	t.peers = nil
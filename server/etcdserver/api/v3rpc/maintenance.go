@@ -224,6 +224,8 @@ func (ms *maintenanceServer) HashKV(ctx context.Context, r *pb.HashKVRequest) (*
 	if err != nil {
 		return nil, togRPCError(err)
 	}
+	// gofail: var corruptHashKV struct{}
+	// h.Hash++
 
 	resp := &pb.HashKVResponse{
 		Header:          &pb.ResponseHeader{Revision: rev},
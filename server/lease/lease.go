@@ -82,7 +82,7 @@ func (l *Lease) getRemainingTTL() int64 {
 
 // refresh refreshes the expiry of the lease.
 func (l *Lease) refresh(extend time.Duration) {
-	newExpiry := time.Now().Add(extend + time.Duration(l.getRemainingTTL())*time.Second)
+	newExpiry := timeNow().Add(extend + time.Duration(l.getRemainingTTL())*time.Second)
 	l.expiryMu.Lock()
 	defer l.expiryMu.Unlock()
 	l.expiry = newExpiry
@@ -120,7 +120,7 @@ func (l *Lease) Remaining() time.Duration {
 	if l.expiry.IsZero() {
 		return time.Duration(math.MaxInt64)
 	}
-	return time.Until(l.expiry)
+	return l.expiry.Sub(timeNow())
 }
 
 type LeaseItem struct {
@@ -133,3 +133,10 @@ type leasesByExpiry []*Lease
 func (le leasesByExpiry) Len() int           { return len(le) }
 func (le leasesByExpiry) Less(i, j int) bool { return le[i].Remaining() < le[j].Remaining() }
 func (le leasesByExpiry) Swap(i, j int)      { le[i], le[j] = le[j], le[i] }
+
+// timeNow returns current time used to track lease expiry.
+func timeNow() time.Time {
+	// gofail: var leaseClockOffset int
+	// return time.Now().Add(time.Duration(leaseClockOffset) * time.Millisecond)
+	return time.Now()
+}
@@ -692,7 +692,7 @@ func (le *lessor) expireExists() (l *Lease, next bool) {
 		le.leaseExpiredNotifier.Unregister() // O(log N)
 		return nil, true
 	}
-	now := time.Now()
+	now := timeNow()
 	if now.Before(item.time) /* item.time: expiration time */ {
 		// Candidate expirations are caught up, reinsert this item
 		// and no need to revoke (nothing is expiry)
@@ -746,7 +746,7 @@ func (le *lessor) scheduleCheckpointIfNeeded(lease *Lease) {
 		}
 		heap.Push(&le.leaseCheckpointHeap, &LeaseWithTime{
 			id:   lease.ID,
-			time: time.Now().Add(le.checkpointInterval),
+			time: timeNow().Add(le.checkpointInterval),
 		})
 	}
 }
@@ -756,7 +756,7 @@ func (le *lessor) findDueScheduledCheckpoints(checkpointLimit int) []*pb.LeaseCh
 		return nil
 	}
 
-	now := time.Now()
+	now := timeNow()
 	var cps []*pb.LeaseCheckpoint
 	for le.leaseCheckpointHeap.Len() > 0 && len(cps) < checkpointLimit {
 		lt := le.leaseCheckpointHeap[0]
@@ -0,0 +1,206 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestBlackholeByMockingPartitionLeader(t *testing.T) {
+	blackholeTestByMockingPartition(t, blackholeTestCase{
+		partitionLeader:    true,
+		blackholeTx:        true,
+		blackholeRx:        true,
+		expectLeaderChange: true,
+	})
+}
+
+func TestBlackholeByMockingPartitionFollower(t *testing.T) {
+	blackholeTestByMockingPartition(t, blackholeTestCase{
+		blackholeTx: true,
+		blackholeRx: true,
+		expectApply: true,
+	})
+}
+
+func TestBlackholeByMockingPartitionLeaderRx(t *testing.T) {
+	blackholeTestByMockingPartition(t, blackholeTestCase{
+		partitionLeader:    true,
+		blackholeRx:        true,
+		expectLeaderChange: true,
+	})
+}
+
+func TestBlackholeByMockingPartitionLeaderTx(t *testing.T) {
+	blackholeTestByMockingPartition(t, blackholeTestCase{
+		partitionLeader: true,
+		blackholeTx:     true,
+		expectApply:     true,
+	})
+}
+
+func TestBlackholeByMockingPartitionFollowerRx(t *testing.T) {
+	blackholeTestByMockingPartition(t, blackholeTestCase{
+		blackholeRx: true,
+		expectApply: true,
+	})
+}
+
+func TestBlackholeByMockingPartitionFollowerTx(t *testing.T) {
+	blackholeTestByMockingPartition(t, blackholeTestCase{
+		blackholeTx: true,
+		expectApply: true,
+	})
+}
+
+// blackholeTestCase describes partition mocked by blackholing peer proxy of
+// a member. The proxy only carries connections dialed to the member by other
+// peers. Raft messages sent by the member are streamed back over those
+// connections as "incoming" (rx) traffic of the proxy, while the "outgoing"
+// (tx) traffic only carries stream requests and pipeline messages, like
+// snapshots. Messages received by the member are streamed over connections it
+// dialed to proxies of other peers, so they are never blocked, and the member
+// keeps following the current leader.
+type blackholeTestCase struct {
+	partitionLeader bool
+	blackholeTx     bool
+	blackholeRx     bool
+	// expectLeaderChange is set if blocked messages are needed to keep leadership.
+	expectLeaderChange bool
+	// expectApply is set if the member applies writes while partitioned, which
+	// requires the leader to replicate entries without receiving its responses.
+	expectApply bool
+}
+
+func blackholeTestByMockingPartition(t *testing.T, tc blackholeTestCase) {
+	e2e.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithIsPeerTLS(true), e2e.WithPeerProxy(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	partitionedIdx := leaderIdx
+	if !tc.partitionLeader {
+		partitionedIdx = (leaderIdx + 1) % len(clus.Procs)
+	}
+	partitioned := clus.Procs[partitionedIdx]
+	other := clus.Procs[(partitionedIdx+1)%len(clus.Procs)]
+	partitionedClient := newStatusClient(t, partitioned)
+	otherClient := newStatusClient(t, other)
+	before, err := otherClient.Status(ctx, otherClient.Endpoints()[0])
+	require.NoError(t, err)
+
+	proxy := partitioned.PeerProxy()
+	t.Logf("Blackholing traffic of member %q, tx: %v, rx: %v", partitioned.Config().Name, tc.blackholeTx, tc.blackholeRx)
+	if tc.blackholeTx {
+		proxy.BlackholeTx()
+	}
+	if tc.blackholeRx {
+		proxy.BlackholeRx()
+	}
+	// Wait multiple election timeouts for the cluster to react.
+	time.Sleep(5 * time.Second)
+
+	after := waitForLeader(ctx, t, otherClient)
+	if tc.expectLeaderChange {
+		require.NotEqual(t, before.Leader, after.Leader, "expected leader to change")
+		require.Greater(t, after.RaftTerm, before.RaftTerm)
+	} else {
+		require.Equal(t, before.Leader, after.Leader, "expected leader to stay")
+		require.Equal(t, before.RaftTerm, after.RaftTerm)
+	}
+	for i := 0; i < 10; i++ {
+		_, err = otherClient.Put(ctx, fmt.Sprintf("key%d", i), "value")
+		require.NoError(t, err)
+	}
+	resp, err := otherClient.Get(ctx, "key9")
+	require.NoError(t, err)
+	if tc.expectApply {
+		waitForMemberRevision(ctx, t, partitionedClient, after.Leader, resp.Header.Revision)
+	} else {
+		e2e.AssertNoProcessLogs(t, partitioned, "applying snapshot", time.Second)
+		status, err := partitionedClient.Status(ctx, partitionedClient.Endpoints()[0])
+		require.NoError(t, err)
+		require.Equal(t, after.Leader, status.Leader, "expected partitioned member to follow the current leader")
+		require.Less(t, status.Header.Revision, resp.Header.Revision, "expected partitioned member to not apply writes")
+	}
+
+	t.Logf("Unblackholing traffic of member %q", partitioned.Config().Name)
+	proxy.UnblackholeTx()
+	proxy.UnblackholeRx()
+	_, err = partitioned.WaitRevision(ctx, resp.Header.Revision)
+	require.NoError(t, err)
+	status, err := partitioned.RaftStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, after.Leader, status.Leader, "expected healed member to follow the current leader")
+	require.Equal(t, after.RaftTerm, status.Term)
+	revision, err := clus.WaitRevisionConverged(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, revision, resp.Header.Revision)
+}
+
+func newStatusClient(t *testing.T, member e2e.EtcdProcess) *clientv3.Client {
+	c, err := clientv3.New(clientv3.Config{
+		Endpoints:   member.EndpointsGRPC(),
+		Logger:      zap.NewNop(),
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// waitForLeader waits until the member reports a leader and returns its status.
+func waitForLeader(ctx context.Context, t *testing.T, c *clientv3.Client) *clientv3.StatusResponse {
+	for {
+		status, err := c.Status(ctx, c.Endpoints()[0])
+		if err == nil && status.Leader != 0 {
+			return status
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Member didn't report a leader, err: %s", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// waitForMemberRevision waits until the member follows given leader and reaches given revision.
+func waitForMemberRevision(ctx context.Context, t *testing.T, c *clientv3.Client, leader uint64, revision int64) {
+	for {
+		status, err := c.Status(ctx, c.Endpoints()[0])
+		if err == nil && status.Leader == leader && status.Header.Revision >= revision {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Member didn't catch up to revision %d of leader %x, last status: %+v, err: %v", revision, leader, status, err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
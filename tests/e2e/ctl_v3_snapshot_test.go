@@ -479,8 +479,7 @@ func TestBreakConsistentIndexNewerThanSnapshot(t *testing.T) {
 	t.Log("Ensure snapshot there is a newer snapshot")
 	err = member.Start(ctx)
 	assert.NoError(t, err)
-	generateSnapshot(t, snapshotCount, member.Etcdctl())
-	_, err = member.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: "saved snapshot"})
+	_, err = member.TriggerSnapshot(ctx)
 	assert.NoError(t, err)
 	err = member.Stop()
 	assert.NoError(t, err)
@@ -337,6 +337,34 @@ func TestHTTPLivezReadyzHandler(t *testing.T) {
 	}
 }
 
+func TestWaitAllHealthy(t *testing.T) {
+	e2e.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithIsPeerTLS(true), e2e.WithPeerProxy(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	healths, err := clus.WaitAllHealthy(ctx)
+	require.NoError(t, err)
+	require.Len(t, healths, 3)
+
+	blackhole(ctx, t, clus, 0)
+	require.Eventually(t, func() bool {
+		healths = clus.Health(ctx)
+		return !healths[0].Healthy && healths[1].Healthy && healths[2].Healthy
+	}, 10*time.Second, 100*time.Millisecond)
+
+	proxy := clus.Procs[0].PeerProxy()
+	proxy.UnblackholeTx()
+	proxy.UnblackholeRx()
+	health, err := clus.Procs[0].WaitHealthy(ctx)
+	require.NoError(t, err)
+	require.Equal(t, clus.Procs[0].Config().Name, health.Name)
+	_, err = clus.WaitAllHealthy(ctx)
+	require.NoError(t, err)
+}
+
 func doHealthCheckAndVerify(t *testing.T, client *http.Client, url string, expectTimeoutError bool, expectStatusCode int, expectRespSubStrings []string) {
 	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -40,6 +40,10 @@ import (
 
 const EtcdProcessBasePort = 20000
 
+// portsPerProcess is the number of ports reserved for each process of the cluster, starting
+// from BasePort. Slot after members is reserved for grpc-proxy, see grpcProxyPort.
+const portsPerProcess = 5
+
 type ClientConnType int
 
 const (
@@ -130,6 +134,8 @@ type EtcdProcessCluster struct {
 	Cfg     *EtcdProcessClusterConfig
 	Procs   []EtcdProcess
 	nextSeq int // sequence number of the next etcd process (if it will be required)
+
+	grpcProxy *GRPCProxyProcess
 }
 
 type EtcdProcessClusterConfig struct {
@@ -143,6 +149,14 @@ type EtcdProcessClusterConfig struct {
 	GoFailClientTimeout time.Duration
 	LazyFSEnabled       bool
 	PeerProxy           bool
+	// PeerProxySeed seeds packet drop decisions of peer proxies, each member
+	// offset by its index. Zero uses random seeds.
+	PeerProxySeed int64
+	// ClientProxy starts a proxy in front of client URL of each member,
+	// using the port of separate client HTTP URL to serve proxied traffic.
+	ClientProxy bool
+	// GRPCProxy starts a grpc-proxy in front of all members, using the first port after the members.
+	GRPCProxy bool
 
 	// Process config
 
@@ -378,6 +392,18 @@ func WithPeerProxy(enabled bool) EPClusterOption {
 	return func(c *EtcdProcessClusterConfig) { c.PeerProxy = enabled }
 }
 
+func WithPeerProxySeed(seed int64) EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.PeerProxySeed = seed }
+}
+
+func WithClientProxy(enabled bool) EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.ClientProxy = enabled }
+}
+
+func WithGRPCProxy(enabled bool) EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.GRPCProxy = enabled }
+}
+
 // NewEtcdProcessCluster launches a new cluster from etcd processes, returning
 // a new EtcdProcessCluster once all nodes are ready to accept client requests.
 func NewEtcdProcessCluster(ctx context.Context, t testing.TB, opts ...EPClusterOption) (*EtcdProcessCluster, error) {
@@ -390,6 +416,36 @@ func NewEtcdProcessCluster(ctx context.Context, t testing.TB, opts ...EPClusterO
 	return StartEtcdProcessCluster(ctx, t, epc, cfg)
 }
 
+// NewEtcdProcessClusterPair launches two independent etcd process clusters configured with the same options,
+// allowing to test cross-cluster scenarios like mirroring. The second cluster uses ports following the ones reserved by the first one, including its grpc-proxy.
+// Returned close function tears down both clusters.
+func NewEtcdProcessClusterPair(ctx context.Context, t testing.TB, opts ...EPClusterOption) (*EtcdProcessCluster, *EtcdProcessCluster, func() error, error) {
+	cfg := NewConfig(opts...)
+	if cfg.BaseDataDirPath != "" {
+		return nil, nil, nil, fmt.Errorf("cluster pair doesn't support shared BaseDataDirPath")
+	}
+	if cfg.BasePort == 0 {
+		cfg.BasePort = EtcdProcessBasePort
+	}
+	first, err := NewEtcdProcessCluster(ctx, t, append(opts, WithBasePort(cfg.BasePort))...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	second, err := NewEtcdProcessCluster(ctx, t, append(opts, WithBasePort(cfg.BasePort+cfg.portsUsed()))...)
+	if err != nil {
+		first.Close()
+		return nil, nil, nil, err
+	}
+	closeBoth := func() error {
+		err := first.Close()
+		if serr := second.Close(); serr != nil {
+			err = serr
+		}
+		return err
+	}
+	return first, second, closeBoth, nil
+}
+
 // InitEtcdProcessCluster initializes a new cluster based on the given config.
 // It doesn't start the cluster.
 func InitEtcdProcessCluster(t testing.TB, cfg *EtcdProcessClusterConfig) (*EtcdProcessCluster, error) {
@@ -459,6 +515,17 @@ func StartEtcdProcessCluster(ctx context.Context, t testing.TB, epc *EtcdProcess
 			return nil, fmt.Errorf("failed to move leader: %v", err)
 		}
 	}
+	if cfg.GRPCProxy {
+		if cfg.Client.ConnectionType != ClientNonTLS {
+			epc.Close()
+			return nil, fmt.Errorf("grpc-proxy requires non TLS client connection")
+		}
+		epc.grpcProxy = newGRPCProxyProcess(cfg.Logger, BinPath.Etcd, cfg.grpcProxyPort(), epc.EndpointsGRPC())
+		if err := epc.grpcProxy.Start(ctx); err != nil {
+			epc.Close()
+			return nil, fmt.Errorf("cannot start grpc-proxy: %v", err)
+		}
+	}
 	return epc, nil
 }
 
@@ -499,10 +566,25 @@ func (cfg *EtcdProcessClusterConfig) SetInitialOrDiscovery(serverCfg *EtcdServer
 	}
 }
 
+// NextBasePort returns the first port after ports reserved by the cluster, to start another cluster next to it.
+func (epc *EtcdProcessCluster) NextBasePort() int {
+	return epc.Cfg.BasePort + epc.Cfg.portsUsed()
+}
+
+// grpcProxyPort returns port of grpc-proxy, in the slot following the members.
+func (cfg *EtcdProcessClusterConfig) grpcProxyPort() int {
+	return cfg.BasePort + portsPerProcess*cfg.ClusterSize
+}
+
+// portsUsed returns number of ports reserved by the cluster starting from BasePort, including grpc-proxy slot.
+func (cfg *EtcdProcessClusterConfig) portsUsed() int {
+	return portsPerProcess * (cfg.ClusterSize + 1)
+}
+
 func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i int) *EtcdServerProcessConfig {
 	var curls []string
 	var curl string
-	port := cfg.BasePort + 5*i
+	port := cfg.BasePort + portsPerProcess*i
 	clientPort := port
 	peerPort := port + 1
 	metricsPort := port + 2
@@ -516,6 +598,27 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 		curl = clientURL(cfg.ClientScheme(), clientPort, cfg.Client.ConnectionType)
 		curls = []string{curl}
 	}
+	listenCurls := curls
+	var clientProxyCfg *proxy.ServerConfig
+	if cfg.ClientProxy {
+		if cfg.ClientHTTPSeparate {
+			panic("Can't use client proxy with separate client HTTP port as proxied traffic is served on that port")
+		}
+		listenCurls = nil
+		for _, u := range curls {
+			listenURL, err := url.Parse(u)
+			if err != nil {
+				panic(err)
+			}
+			listenURL.Host = fmt.Sprintf("localhost:%d", clientHTTPPort)
+			listenCurls = append(listenCurls, listenURL.String())
+		}
+		clientProxyCfg = &proxy.ServerConfig{
+			Logger: zap.NewNop(),
+			To:     url.URL{Scheme: "tcp", Host: fmt.Sprintf("localhost:%d", clientHTTPPort)},
+			From:   url.URL{Scheme: "tcp", Host: fmt.Sprintf("localhost:%d", clientPort)},
+		}
+	}
 
 	peerListenURL := url.URL{Scheme: cfg.PeerScheme(), Host: fmt.Sprintf("localhost:%d", peerPort)}
 	peerAdvertiseURL := url.URL{Scheme: cfg.PeerScheme(), Host: fmt.Sprintf("localhost:%d", peerPort)}
@@ -530,6 +633,9 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 			To:     peerListenURL,
 			From:   peerAdvertiseURL,
 		}
+		if cfg.PeerProxySeed != 0 {
+			proxyCfg.DropSeed = cfg.PeerProxySeed + int64(i)
+		}
 	}
 
 	name := fmt.Sprintf("%s-test-%d", testNameCleanRegex.ReplaceAllString(tb.Name(), ""), i)
@@ -548,7 +654,7 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 
 	args := []string{
 		"--name=" + name,
-		"--listen-client-urls=" + strings.Join(curls, ","),
+		"--listen-client-urls=" + strings.Join(listenCurls, ","),
 		"--advertise-client-urls=" + strings.Join(curls, ","),
 		"--listen-peer-urls=" + peerListenURL.String(),
 		"--initial-advertise-peer-urls=" + peerAdvertiseURL.String(),
@@ -652,6 +758,7 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 		GoFailPort:          gofailPort,
 		GoFailClientTimeout: cfg.GoFailClientTimeout,
 		Proxy:               proxyCfg,
+		ClientProxy:         clientProxyCfg,
 		LazyFSEnabled:       cfg.LazyFSEnabled,
 	}
 }
@@ -728,6 +835,14 @@ func (epc *EtcdProcessCluster) EndpointsGRPC() []string {
 	return epc.Endpoints(func(ep EtcdProcess) []string { return ep.EndpointsGRPC() })
 }
 
+// GRPCProxyEndpoints returns endpoints of the grpc-proxy fronting the cluster, requires GRPCProxy option.
+func (epc *EtcdProcessCluster) GRPCProxyEndpoints() []string {
+	if epc.grpcProxy == nil {
+		return nil
+	}
+	return epc.grpcProxy.EndpointsGRPC()
+}
+
 func (epc *EtcdProcessCluster) EndpointsHTTP() []string {
 	return epc.Endpoints(func(ep EtcdProcess) []string { return ep.EndpointsHTTP() })
 }
@@ -980,7 +1095,13 @@ func (epc *EtcdProcessCluster) Etcdctl(opts ...config.ClientOption) *EtcdctlV3 {
 
 func (epc *EtcdProcessCluster) Close() error {
 	epc.lg.Info("closing test cluster...")
-	err := epc.Stop()
+	var err error
+	if epc.grpcProxy != nil {
+		err = epc.grpcProxy.Close()
+	}
+	if serr := epc.Stop(); serr != nil {
+		err = serr
+	}
 	for _, p := range epc.Procs {
 		// p is nil when NewEtcdProcess fails in the middle
 		// Close still gets called to clean up test data
@@ -1072,6 +1193,42 @@ func (epc *EtcdProcessCluster) WaitMembersForLeader(ctx context.Context, t testi
 	return -1
 }
 
+// WaitRevisionConverged polls status of all members until they report the same revision or ctx is done.
+// Returns the converged revision, or an error listing the last revision reported by each member.
+func (epc *EtcdProcessCluster) WaitRevisionConverged(ctx context.Context, opts ...WaitOption) (int64, error) {
+	o := waitOptions{pollInterval: defaultWaitPollInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	revisions := make([]string, len(epc.Procs))
+	for {
+		converged := true
+		var revision int64
+		for i, proc := range epc.Procs {
+			status, err := proc.RaftStatus(ctx)
+			if err != nil {
+				revisions[i] = fmt.Sprintf("%s: err: %v", proc.Config().Name, err)
+				converged = false
+				continue
+			}
+			revisions[i] = fmt.Sprintf("%s: %d", proc.Config().Name, status.Revision)
+			if i == 0 {
+				revision = status.Revision
+			} else if status.Revision != revision {
+				converged = false
+			}
+		}
+		if converged {
+			return revision, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("member revisions didn't converge, revisions: [%s], err: %w", strings.Join(revisions, ", "), ctx.Err())
+		case <-time.After(o.pollInterval):
+		}
+	}
+}
+
 // MoveLeader moves the leader to the ith process.
 func (epc *EtcdProcessCluster) MoveLeader(ctx context.Context, t testing.TB, i int) error {
 	if i < 0 || i >= len(epc.Procs) {
@@ -72,6 +72,14 @@ func TestEtcdServerProcessConfig(t *testing.T) {
 				"--strict-reconfig-check=false",
 			},
 		},
+		{
+			name:   "ClientProxy",
+			config: NewConfig(WithClientProxy(true)),
+			expectArgsContain: []string{
+				"--listen-client-urls=http://localhost:4",
+				"--advertise-client-urls=http://localhost:0",
+			},
+		},
 		{
 			name:   "CatchUpEntries",
 			config: NewConfig(WithSnapshotCatchUpEntries(100)),
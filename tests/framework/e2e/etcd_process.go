@@ -50,12 +50,18 @@ type EtcdProcess interface {
 
 	IsRunning() bool
 	Wait(ctx context.Context) error
+	Health(ctx context.Context) MemberHealth
+	WaitHealthy(ctx context.Context) (MemberHealth, error)
+	WaitRevision(ctx context.Context, want int64, opts ...WaitOption) (int64, error)
+	TriggerSnapshot(ctx context.Context) (uint64, error)
+	RaftStatus(ctx context.Context) (RaftStatus, error)
 	Start(ctx context.Context) error
 	Restart(ctx context.Context) error
 	Stop() error
 	Close() error
 	Config() *EtcdServerProcessConfig
 	PeerProxy() proxy.Server
+	ClientProxy() proxy.Server
 	Failpoints() *BinaryFailpoints
 	LazyFS() *LazyFS
 	Logs() LogsExpect
@@ -69,12 +75,13 @@ type LogsExpect interface {
 }
 
 type EtcdServerProcess struct {
-	cfg        *EtcdServerProcessConfig
-	proc       *expect.ExpectProcess
-	proxy      proxy.Server
-	lazyfs     *LazyFS
-	failpoints *BinaryFailpoints
-	donec      chan struct{} // closed when Interact() terminates
+	cfg         *EtcdServerProcessConfig
+	proc        *expect.ExpectProcess
+	proxy       proxy.Server
+	clientProxy proxy.Server
+	lazyfs      *LazyFS
+	failpoints  *BinaryFailpoints
+	donec       chan struct{} // closed when Interact() terminates
 }
 
 type EtcdServerProcessConfig struct {
@@ -102,6 +109,7 @@ type EtcdServerProcessConfig struct {
 
 	LazyFSEnabled bool
 	Proxy         *proxy.ServerConfig
+	ClientProxy   *proxy.ServerConfig
 }
 
 func NewEtcdServerProcess(t testing.TB, cfg *EtcdServerProcessConfig) (*EtcdServerProcess, error) {
@@ -160,6 +168,15 @@ func (ep *EtcdServerProcess) Start(ctx context.Context) error {
 			return err
 		}
 	}
+	if ep.cfg.ClientProxy != nil && ep.clientProxy == nil {
+		ep.cfg.lg.Info("starting client proxy...", zap.String("name", ep.cfg.Name), zap.String("from", ep.cfg.ClientProxy.From.String()), zap.String("to", ep.cfg.ClientProxy.To.String()))
+		ep.clientProxy = proxy.NewServer(*ep.cfg.ClientProxy)
+		select {
+		case <-ep.clientProxy.Ready():
+		case err := <-ep.clientProxy.Error():
+			return err
+		}
+	}
 	if ep.lazyfs != nil {
 		ep.cfg.lg.Info("starting lazyfs...", zap.String("name", ep.cfg.Name))
 		err := ep.lazyfs.Start(ctx)
@@ -229,6 +246,14 @@ func (ep *EtcdServerProcess) Stop() (err error) {
 			return err
 		}
 	}
+	if ep.clientProxy != nil {
+		ep.cfg.lg.Info("stopping client proxy...", zap.String("name", ep.cfg.Name))
+		err = ep.clientProxy.Close()
+		ep.clientProxy = nil
+		if err != nil {
+			return err
+		}
+	}
 	if ep.lazyfs != nil {
 		ep.cfg.lg.Info("stopping lazyfs...", zap.String("name", ep.cfg.Name))
 		err = ep.lazyfs.Stop()
@@ -301,6 +326,79 @@ func (ep *EtcdServerProcess) Wait(ctx context.Context) error {
 	}
 }
 
+// RaftStatus is the raft state of a member as reported by its maintenance status endpoint.
+type RaftStatus struct {
+	MemberID uint64
+	Leader   uint64
+	Term     uint64
+	// CommittedIndex is the index of the last raft entry known to be committed.
+	CommittedIndex uint64
+	// AppliedIndex is the index of the last raft entry applied by the member.
+	AppliedIndex uint64
+	Revision     int64
+}
+
+// IsLeader returns true if the member considers itself the leader.
+func (s RaftStatus) IsLeader() bool {
+	return s.Leader != 0 && s.Leader == s.MemberID
+}
+
+// RaftStatus queries the status of the member.
+func (ep *EtcdServerProcess) RaftStatus(ctx context.Context) (RaftStatus, error) {
+	resp, err := ep.Etcdctl().Status(ctx)
+	if err != nil {
+		return RaftStatus{}, err
+	}
+	if len(resp) != 1 || resp[0] == nil || resp[0].Header == nil {
+		return RaftStatus{}, fmt.Errorf("unexpected status response of member %q: %v", ep.cfg.Name, resp)
+	}
+	return RaftStatus{
+		MemberID:       resp[0].Header.MemberId,
+		Leader:         resp[0].Leader,
+		Term:           resp[0].RaftTerm,
+		CommittedIndex: resp[0].RaftIndex,
+		AppliedIndex:   resp[0].RaftAppliedIndex,
+		Revision:       resp[0].Header.Revision,
+	}, nil
+}
+
+const defaultWaitPollInterval = 100 * time.Millisecond
+
+type waitOptions struct {
+	pollInterval time.Duration
+}
+
+type WaitOption func(*waitOptions)
+
+// WithPollInterval sets how often the member is queried while waiting.
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(o *waitOptions) { o.pollInterval = interval }
+}
+
+// WaitRevision polls member status until it reaches the wanted revision or ctx is done.
+// Returns the last revision reported by the member.
+func (ep *EtcdServerProcess) WaitRevision(ctx context.Context, want int64, opts ...WaitOption) (int64, error) {
+	o := waitOptions{pollInterval: defaultWaitPollInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var revision int64
+	for {
+		status, err := ep.RaftStatus(ctx)
+		if err == nil {
+			revision = status.Revision
+			if revision >= want {
+				return revision, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return revision, fmt.Errorf("member %q didn't reach revision %d, last revision: %d, err: %w", ep.cfg.Name, want, revision, ctx.Err())
+		case <-time.After(o.pollInterval):
+		}
+	}
+}
+
 func (ep *EtcdServerProcess) IsRunning() bool {
 	if ep.proc == nil {
 		return false
@@ -321,12 +419,39 @@ func (ep *EtcdServerProcess) IsRunning() bool {
 
 func AssertProcessLogs(t *testing.T, ep EtcdProcess, expectLog string) {
 	t.Helper()
-	var err error
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	_, err = ep.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: expectLog})
+	AssertProcessLogsWithin(ctx, t, ep, expectLog)
+}
+
+// AssertProcessLogsWithin fails the test if the member doesn't log expectLog before ctx is done.
+func AssertProcessLogsWithin(ctx context.Context, t *testing.T, ep EtcdProcess, expectLog string) {
+	t.Helper()
+	_, err := ep.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: expectLog})
 	if err != nil {
-		t.Fatal(err)
+		t.Fatalf("member %q didn't log %q, err: %s", ep.Config().Name, expectLog, err)
+	}
+}
+
+// AssertNoProcessLogs fails the test if the member logs unexpectedLog within the window starting now.
+// Lines logged before the call are ignored.
+func AssertNoProcessLogs(t *testing.T, ep EtcdProcess, unexpectedLog string, window time.Duration) {
+	t.Helper()
+	start := ep.Logs().LineCount()
+	deadline := time.After(window)
+	for {
+		lines := ep.Logs().Lines()
+		for _, line := range lines[start:] {
+			if strings.Contains(line, unexpectedLog) {
+				t.Fatalf("member %q unexpectedly logged %q, line: %s", ep.Config().Name, unexpectedLog, line)
+			}
+		}
+		start = len(lines)
+		select {
+		case <-deadline:
+			return
+		case <-time.After(defaultWaitPollInterval):
+		}
 	}
 }
 
@@ -334,6 +459,11 @@ func (ep *EtcdServerProcess) PeerProxy() proxy.Server {
 	return ep.proxy
 }
 
+// ClientProxy returns proxy between clients and the member, nil if not enabled with WithClientProxy.
+func (ep *EtcdServerProcess) ClientProxy() proxy.Server {
+	return ep.clientProxy
+}
+
 func (ep *EtcdServerProcess) LazyFS() *LazyFS {
 	return ep.lazyfs
 }
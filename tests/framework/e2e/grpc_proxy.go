@@ -0,0 +1,82 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+)
+
+// GRPCProxyProcess is a grpc-proxy process fronting all members of the cluster.
+type GRPCProxyProcess struct {
+	lg       *zap.Logger
+	execPath string
+	args     []string
+	endpoint string
+
+	proc *expect.ExpectProcess
+}
+
+func newGRPCProxyProcess(lg *zap.Logger, execPath string, port int, endpoints []string) *GRPCProxyProcess {
+	listenAddr := fmt.Sprintf("localhost:%d", port)
+	return &GRPCProxyProcess{
+		lg:       lg,
+		execPath: execPath,
+		args: []string{
+			"grpc-proxy",
+			"start",
+			"--listen-addr", listenAddr,
+			"--endpoints", strings.Join(endpoints, ","),
+			// pass-through member RPCs
+			"--advertise-client-url", "",
+		},
+		endpoint: "http://" + listenAddr,
+	}
+}
+
+func (p *GRPCProxyProcess) EndpointsGRPC() []string { return []string{p.endpoint} }
+
+func (p *GRPCProxyProcess) Start(ctx context.Context) error {
+	if p.proc != nil {
+		panic("already started")
+	}
+	proc, err := SpawnCmdWithLogger(p.lg, append([]string{p.execPath}, p.args...), nil, "grpc-proxy")
+	if err != nil {
+		return err
+	}
+	p.proc = proc
+	return WaitReadyExpectProc(ctx, p.proc, []string{"started gRPC proxy"})
+}
+
+func (p *GRPCProxyProcess) Close() error {
+	if p.proc == nil {
+		return nil
+	}
+	if err := p.proc.Stop(); err != nil {
+		return err
+	}
+	err := p.proc.Close()
+	// proxy received SIGTERM signal
+	if err != nil && !strings.Contains(err.Error(), "unexpected exit code") {
+		return err
+	}
+	p.proc = nil
+	return nil
+}
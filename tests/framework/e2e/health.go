@@ -0,0 +1,138 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/etcdhttp"
+)
+
+// MemberHealth is the health of a member as reported by its /health endpoint.
+type MemberHealth struct {
+	Name    string
+	Healthy bool
+	// Reason is the reason reported by the member, or the error of the request if it didn't respond.
+	Reason string
+}
+
+// Health queries /health endpoint of the member once.
+func (ep *EtcdServerProcess) Health(ctx context.Context) MemberHealth {
+	health := MemberHealth{Name: ep.cfg.Name}
+	h, err := ep.health(ctx)
+	if err != nil {
+		health.Reason = err.Error()
+		return health
+	}
+	health.Healthy = h.Health == "true"
+	health.Reason = h.Reason
+	return health
+}
+
+// WaitHealthy polls /health endpoint of the member until it reports healthy or ctx is done.
+func (ep *EtcdServerProcess) WaitHealthy(ctx context.Context) (MemberHealth, error) {
+	for {
+		health := ep.Health(ctx)
+		if health.Healthy {
+			return health, nil
+		}
+		select {
+		case <-ctx.Done():
+			return health, fmt.Errorf("member %q not healthy: %s, err: %w", ep.cfg.Name, health.Reason, ctx.Err())
+		case <-time.After(defaultWaitPollInterval):
+		}
+	}
+}
+
+func (ep *EtcdServerProcess) health(ctx context.Context) (*etcdhttp.Health, error) {
+	endpoint := ep.EndpointsHTTP()[0]
+	httpClient := http.Client{Timeout: time.Second}
+	if strings.HasPrefix(endpoint, "https://") {
+		tlsInfo := transport.TLSInfo{
+			CertFile:           CertPath,
+			KeyFile:            PrivateKeyPath,
+			TrustedCAFile:      CaPath,
+			InsecureSkipVerify: ep.cfg.Client.AutoTLS,
+		}
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var h etcdhttp.Health
+	if err := json.Unmarshal(body, &h); err != nil {
+		return nil, fmt.Errorf("bad status code: %d, err: %w", resp.StatusCode, err)
+	}
+	return &h, nil
+}
+
+// WaitAllHealthy polls /health endpoint of all members until all of them report healthy or ctx is done.
+// Returns the last health of each member, in the order of epc.Procs.
+func (epc *EtcdProcessCluster) WaitAllHealthy(ctx context.Context) ([]MemberHealth, error) {
+	for {
+		healths := epc.Health(ctx)
+		var unhealthy []string
+		for _, h := range healths {
+			if !h.Healthy {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", h.Name, h.Reason))
+			}
+		}
+		if len(unhealthy) == 0 {
+			return healths, nil
+		}
+		select {
+		case <-ctx.Done():
+			return healths, fmt.Errorf("members not healthy: %s, err: %w", strings.Join(unhealthy, "; "), ctx.Err())
+		case <-time.After(defaultWaitPollInterval):
+		}
+	}
+}
+
+// Health queries /health endpoint of all members once, returning health of each member in the order of epc.Procs.
+func (epc *EtcdProcessCluster) Health(ctx context.Context) []MemberHealth {
+	healths := make([]MemberHealth, len(epc.Procs))
+	var wg sync.WaitGroup
+	for i, proc := range epc.Procs {
+		wg.Add(1)
+		go func(i int, proc EtcdProcess) {
+			defer wg.Done()
+			healths[i] = proc.Health(ctx)
+		}(i, proc)
+	}
+	wg.Wait()
+	return healths
+}
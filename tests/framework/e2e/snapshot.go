@@ -0,0 +1,96 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/tests/v3/framework/config"
+)
+
+// TriggerSnapshotKeyPrefix is the prefix of keys written by TriggerSnapshot.
+const TriggerSnapshotKeyPrefix = "/e2e/trigger-snapshot/"
+
+// maxTriggerSnapshotCount is the largest snapshot count TriggerSnapshot agrees to write through.
+const maxTriggerSnapshotCount = 1000
+
+// TriggerSnapshot makes the member save a new raft snapshot and returns its index once a snapshot
+// file with a newer index exists. Etcd doesn't allow triggering a raft snapshot directly, so it puts
+// snapshot count plus one times to a key under TriggerSnapshotKeyPrefix. The key is left in the
+// keyspace and the writes advance the revision, so callers validating history or asserting revisions
+// need to account for them. Fails on members with snapshot count above maxTriggerSnapshotCount.
+func (ep *EtcdServerProcess) TriggerSnapshot(ctx context.Context) (uint64, error) {
+	snapshotCount, err := ep.snapshotCount()
+	if err != nil {
+		return 0, err
+	}
+	if snapshotCount > maxTriggerSnapshotCount {
+		return 0, fmt.Errorf("member %q snapshot count %d is above %d, configure lower snapshot count to trigger snapshot", ep.cfg.Name, snapshotCount, maxTriggerSnapshotCount)
+	}
+	before, err := ep.snapshotIndex()
+	if err != nil {
+		return 0, err
+	}
+	cc := ep.Etcdctl()
+	key := TriggerSnapshotKeyPrefix + ep.cfg.Name
+	for i := uint64(0); i <= snapshotCount; i++ {
+		if err := cc.Put(ctx, key, fmt.Sprint(i), config.PutOptions{}); err != nil {
+			return 0, err
+		}
+	}
+	for {
+		index, err := ep.snapshotIndex()
+		if err != nil {
+			return 0, err
+		}
+		if index > before {
+			return index, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("member %q didn't save snapshot newer than index %d, err: %w", ep.cfg.Name, before, ctx.Err())
+		case <-time.After(defaultWaitPollInterval):
+		}
+	}
+}
+
+// snapshotIndex returns index of the newest raft snapshot saved by the member, or 0 if there is none.
+func (ep *EtcdServerProcess) snapshotIndex() (uint64, error) {
+	snapshot, err := snap.New(ep.cfg.lg, datadir.ToSnapDir(ep.cfg.DataDirPath)).Load()
+	if errors.Is(err, snap.ErrNoSnapshot) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return snapshot.Metadata.Index, nil
+}
+
+func (ep *EtcdServerProcess) snapshotCount() (uint64, error) {
+	for _, arg := range ep.cfg.Args {
+		if value, found := strings.CutPrefix(arg, "--snapshot-count="); found {
+			return strconv.ParseUint(value, 10, 64)
+		}
+	}
+	return etcdserver.DefaultSnapshotCount, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+	"go.etcd.io/etcd/tests/v3/robustness/validate"
+)
+
+func TestApplyLagBoundedAfterApplyDelay(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(1), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	member := clus.Procs[0]
+	if !member.Failpoints().Available("beforeApplyOneEntryNormal") {
+		t.Skip("beforeApplyOneEntryNormal failpoint is not available")
+	}
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	c, err := client.NewRecordingClient(member.EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Writers keep committing entries, while apply is delayed.
+	writeCtx, stopWrites := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wc, err := client.NewRecordingClient(member.EndpointsGRPC(), ids, baseTime)
+		require.NoError(t, err)
+		defer wc.Close()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; writeCtx.Err() == nil; j++ {
+				wc.Put(writeCtx, fmt.Sprintf("key%d", i), fmt.Sprintf("%d", j))
+			}
+		}(i)
+	}
+	defer stopWrites()
+
+	monitorCtx, stopMonitor := context.WithCancel(ctx)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for monitorCtx.Err() == nil {
+			c.Status(monitorCtx, member.EndpointsGRPC()[0])
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	maxLag := uint64(5)
+	require.NoError(t, member.Failpoints().SetupHTTP(ctx, "beforeApplyOneEntryNormal", `sleep("100ms")`))
+	time.Sleep(2 * time.Second)
+	require.NoError(t, member.Failpoints().DeactivateHTTP(ctx, "beforeApplyOneEntryNormal"))
+	deactivated := time.Since(baseTime)
+	stopWrites()
+	// Give the member time to apply the backlog.
+	time.Sleep(2 * time.Second)
+	recovered := time.Since(baseTime)
+	time.Sleep(time.Second)
+	stopMonitor()
+	wg.Wait()
+
+	statuses := c.Report().Status
+	require.ErrorContains(t, validate.ValidateApplyLagBounded(statusesBetween(statuses, 0, deactivated), maxLag), "ApplyLagBounded")
+	require.NoError(t, validate.ValidateApplyLagBounded(statusesBetween(statuses, recovered, time.Since(baseTime)), maxLag))
+}
+
+func statusesBetween(statuses []model.StatusObservation, start, end time.Duration) report.ClientReport {
+	r := report.ClientReport{}
+	for _, status := range statuses {
+		if status.Time >= start && status.Time <= end {
+			r.Status = append(r.Status, status)
+		}
+	}
+	return r
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// CompareAndSwapTo increments integer value of key using read followed by a transaction
+// conditioned on key mod revision, until it reaches the target. Missing key is treated as 0.
+// Failed and conflicting attempts are retried, all of them are recorded.
+func CompareAndSwapTo(ctx context.Context, c *RecordingClient, key string, target int) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		kv, _, err := c.Get(ctx, key, 0)
+		if err != nil {
+			continue
+		}
+		var value int
+		var modRevision int64
+		if kv != nil {
+			value, err = strconv.Atoi(string(kv.Value))
+			if err != nil {
+				return fmt.Errorf("unexpected value of key %q: %w", key, err)
+			}
+			modRevision = kv.ModRevision
+		}
+		if value >= target {
+			return nil
+		}
+		_, _ = c.Txn(ctx,
+			[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)},
+			[]clientv3.Op{clientv3.OpPut(key, strconv.Itoa(value+1))},
+			nil,
+		)
+	}
+}
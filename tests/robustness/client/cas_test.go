@@ -0,0 +1,74 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+	"go.etcd.io/etcd/tests/v3/robustness/validate"
+)
+
+func TestCompareAndSwapToConcurrent(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	clients := make([]*client.RecordingClient, 3)
+	for i := range clients {
+		c, err := client.NewRecordingClient([]string{clus.Members[i].GRPCURL}, ids, baseTime)
+		require.NoError(t, err)
+		defer c.Close()
+		clients[i] = c
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(clients))
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *client.RecordingClient) {
+			defer wg.Done()
+			errs[i] = client.CompareAndSwapTo(ctx, c, "counter", 30)
+		}(i, c)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	kv, _, err := clients[0].Get(ctx, "counter", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "30", string(kv.Value))
+	assert.Equal(t, int64(30), kv.Version)
+
+	reports := make([]report.ClientReport, len(clients))
+	for i, c := range clients {
+		reports[i] = c.Report()
+	}
+	require.NoError(t, validate.ValidateCompareAndSwap(zaptest.NewLogger(t), reports, "counter"))
+}
@@ -17,13 +17,17 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdutl/v3/snapshot"
 	"go.etcd.io/etcd/tests/v3/robustness/identity"
 	"go.etcd.io/etcd/tests/v3/robustness/model"
 	"go.etcd.io/etcd/tests/v3/robustness/report"
@@ -33,14 +37,22 @@ import (
 // clientv3.Client) that records all the requests and responses made. Doesn't
 // allow for concurrent requests to confirm to model.AppendableHistory requirements.
 type RecordingClient struct {
-	ID     int
-	client clientv3.Client
+	ID int
+	// Username the client is authenticated as, empty if auth is not used.
+	Username string
+	client   clientv3.Client
 	// using baseTime time-measuring operation to get monotonic clock reading
 	// see https://github.com/golang/go/blob/master/src/time/time.go#L17
 	baseTime time.Time
 
 	watchMux        sync.Mutex
-	watchOperations []model.WatchOperation
+	watchOperations []*model.WatchOperation
+	// progressRequests are times when progress of watches was requested.
+	progressRequests []time.Duration
+	statusMux        sync.Mutex
+	statuses         []model.StatusObservation
+	memberMux        sync.Mutex
+	members          []model.MembershipOperation
 	// mux ensures order of request appending.
 	kvMux        sync.Mutex
 	kvOperations *model.AppendableHistory
@@ -52,17 +64,34 @@ type TimedWatchEvent struct {
 }
 
 func NewRecordingClient(endpoints []string, ids identity.Provider, baseTime time.Time) (*RecordingClient, error) {
-	cc, err := clientv3.New(clientv3.Config{
+	return newRecordingClient(clientConfig(endpoints), ids, baseTime)
+}
+
+// NewAuthedRecordingClient returns a RecordingClient authenticated as given user.
+func NewAuthedRecordingClient(endpoints []string, username, password string, ids identity.Provider, baseTime time.Time) (*RecordingClient, error) {
+	cfg := clientConfig(endpoints)
+	cfg.Username = username
+	cfg.Password = password
+	return newRecordingClient(cfg, ids, baseTime)
+}
+
+func clientConfig(endpoints []string) clientv3.Config {
+	return clientv3.Config{
 		Endpoints:            endpoints,
 		Logger:               zap.NewNop(),
 		DialKeepAliveTime:    10 * time.Second,
 		DialKeepAliveTimeout: 100 * time.Millisecond,
-	})
+	}
+}
+
+func newRecordingClient(cfg clientv3.Config, ids identity.Provider, baseTime time.Time) (*RecordingClient, error) {
+	cc, err := clientv3.New(cfg)
 	if err != nil {
 		return nil, err
 	}
 	return &RecordingClient{
 		ID:           ids.NewClientID(),
+		Username:     cfg.Username,
 		client:       *cc,
 		kvOperations: model.NewAppendableHistory(ids),
 		baseTime:     baseTime,
@@ -75,10 +104,38 @@ func (c *RecordingClient) Close() error {
 
 func (c *RecordingClient) Report() report.ClientReport {
 	return report.ClientReport{
-		ClientID: c.ID,
-		KeyValue: c.kvOperations.History.Operations(),
-		Watch:    c.watchOperations,
+		ClientID:         c.ID,
+		Username:         c.Username,
+		KeyValue:         c.kvOperations.History.Operations(),
+		Watch:            c.watchOperationsCopy(),
+		Status:           c.statusObservations(),
+		Membership:       c.membershipOperations(),
+		ProgressRequests: c.progressRequestTimes(),
+	}
+}
+
+func (c *RecordingClient) membershipOperations() []model.MembershipOperation {
+	c.memberMux.Lock()
+	defer c.memberMux.Unlock()
+	return append([]model.MembershipOperation(nil), c.members...)
+}
+
+func (c *RecordingClient) appendMembership(op model.MembershipOperation, members []*etcdserverpb.Member, err error) {
+	if err != nil {
+		op.Error = err.Error()
+	}
+	for _, member := range members {
+		op.MemberIDs = append(op.MemberIDs, member.ID)
 	}
+	c.memberMux.Lock()
+	c.members = append(c.members, op)
+	c.memberMux.Unlock()
+}
+
+func (c *RecordingClient) statusObservations() []model.StatusObservation {
+	c.statusMux.Lock()
+	defer c.statusMux.Unlock()
+	return append([]model.StatusObservation(nil), c.statuses...)
 }
 
 func (c *RecordingClient) Get(ctx context.Context, key string, revision int64) (kv *mvccpb.KeyValue, rev int64, err error) {
@@ -92,6 +149,24 @@ func (c *RecordingClient) Get(ctx context.Context, key string, revision int64) (
 	return kv, resp.Header.Revision, nil
 }
 
+// GetSerializable reads key from local state of the contacted member, without
+// going through consensus, so it might observe stale data.
+func (c *RecordingClient) GetSerializable(ctx context.Context, key string) (kv *mvccpb.KeyValue, rev int64, err error) {
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.Get(ctx, key, clientv3.WithSerializable())
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendSerializableGet(key, callTime, returnTime, resp, err)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(resp.Kvs) == 1 {
+		kv = resp.Kvs[0]
+	}
+	return kv, resp.Header.Revision, nil
+}
+
 func (c *RecordingClient) Range(ctx context.Context, start, end string, revision, limit int64) (*clientv3.GetResponse, error) {
 	ops := []clientv3.OpOption{}
 	if end != "" {
@@ -112,6 +187,43 @@ func (c *RecordingClient) Range(ctx context.Context, start, end string, revision
 	return resp, err
 }
 
+// RangePaginated reads range in pages of given size, continuing from the last returned key.
+// First page is a linearizable read, following pages are read at its revision.
+// Each page is recorded separately.
+func (c *RecordingClient) RangePaginated(ctx context.Context, start, end string, pageSize int64) ([]*clientv3.GetResponse, error) {
+	var pages []*clientv3.GetResponse
+	var revision int64
+	for {
+		resp, err := c.Range(ctx, start, end, revision, pageSize)
+		if err != nil {
+			return pages, err
+		}
+		pages = append(pages, resp)
+		if !resp.More || len(resp.Kvs) == 0 {
+			return pages, nil
+		}
+		revision = resp.Header.Revision
+		start = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+func (c *RecordingClient) Count(ctx context.Context, start, end string) (int64, error) {
+	ops := []clientv3.OpOption{clientv3.WithCountOnly()}
+	if end != "" {
+		ops = append(ops, clientv3.WithRange(end))
+	}
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.Get(ctx, start, ops...)
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendCount(start, end, callTime, returnTime, resp, err)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
 func (c *RecordingClient) Put(ctx context.Context, key, value string) (*clientv3.PutResponse, error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
@@ -122,6 +234,17 @@ func (c *RecordingClient) Put(ctx context.Context, key, value string) (*clientv3
 	return resp, err
 }
 
+// PutWithPrevKV puts key and records key-value it replaced, if any.
+func (c *RecordingClient) PutWithPrevKV(ctx context.Context, key, value string) (*clientv3.PutResponse, error) {
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.Put(ctx, key, value, clientv3.WithPrevKV())
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendPutWithPrevKV(key, value, callTime, returnTime, resp, err)
+	return resp, err
+}
+
 func (c *RecordingClient) Delete(ctx context.Context, key string) (*clientv3.DeleteResponse, error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
@@ -132,6 +255,33 @@ func (c *RecordingClient) Delete(ctx context.Context, key string) (*clientv3.Del
 	return resp, err
 }
 
+// DeleteRange deletes keys in range [start, end) as a single recorded operation.
+func (c *RecordingClient) DeleteRange(ctx context.Context, start, end string) (*clientv3.DeleteResponse, error) {
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.Delete(ctx, start, clientv3.WithRange(end))
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendDeleteRange(start, end, callTime, returnTime, resp, err)
+	return resp, err
+}
+
+// DeletePrefix deletes all keys with prefix as a single recorded operation.
+func (c *RecordingClient) DeletePrefix(ctx context.Context, prefix string) (*clientv3.DeleteResponse, error) {
+	return c.DeleteRange(ctx, prefix, clientv3.GetPrefixRangeEnd(prefix))
+}
+
+// DeleteWithPrevKV deletes key and records deleted key-value, if any.
+func (c *RecordingClient) DeleteWithPrevKV(ctx context.Context, key string) (*clientv3.DeleteResponse, error) {
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.Delete(ctx, key, clientv3.WithPrevKV())
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendDeleteWithPrevKV(key, callTime, returnTime, resp, err)
+	return resp, err
+}
+
 func (c *RecordingClient) Txn(ctx context.Context, conditions []clientv3.Cmp, onSuccess []clientv3.Op, onFailure []clientv3.Op) (*clientv3.TxnResponse, error) {
 	txn := c.client.Txn(ctx).If(
 		conditions...,
@@ -149,6 +299,21 @@ func (c *RecordingClient) Txn(ctx context.Context, conditions []clientv3.Cmp, on
 	return resp, err
 }
 
+// CompareAndSwap puts newValue to key if its current value equals expectedValue, or if key
+// doesn't exist when expectedValue is empty. Swap is done and recorded as a single
+// transaction, returning whether it succeeded.
+func (c *RecordingClient) CompareAndSwap(ctx context.Context, key, expectedValue, newValue string) (bool, error) {
+	cond := clientv3.Compare(clientv3.Value(key), "=", expectedValue)
+	if expectedValue == "" {
+		cond = clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	}
+	resp, err := c.Txn(ctx, []clientv3.Cmp{cond}, []clientv3.Op{clientv3.OpPut(key, newValue)}, nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
 func (c *RecordingClient) LeaseGrant(ctx context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
@@ -169,6 +334,16 @@ func (c *RecordingClient) LeaseRevoke(ctx context.Context, leaseID int64) (*clie
 	return resp, err
 }
 
+func (c *RecordingClient) LeaseKeepAliveOnce(ctx context.Context, leaseID int64) (*clientv3.LeaseKeepAliveResponse, error) {
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.Lease.KeepAliveOnce(ctx, clientv3.LeaseID(leaseID))
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendLeaseKeepAliveOnce(leaseID, callTime, returnTime, resp, err)
+	return resp, err
+}
+
 func (c *RecordingClient) PutWithLease(ctx context.Context, key string, value string, leaseID int64) (*clientv3.PutResponse, error) {
 	opts := clientv3.WithLease(clientv3.LeaseID(leaseID))
 	c.kvMux.Lock()
@@ -180,6 +355,23 @@ func (c *RecordingClient) PutWithLease(ctx context.Context, key string, value st
 	return resp, err
 }
 
+// TxnPutWithLease puts key attached to the lease in a transaction if conditions are met.
+// Unlike Txn, it records the lease of the put.
+func (c *RecordingClient) TxnPutWithLease(ctx context.Context, conditions []clientv3.Cmp, key, value string, leaseID int64) (*clientv3.TxnResponse, error) {
+	txn := c.client.Txn(ctx).If(
+		conditions...,
+	).Then(
+		clientv3.OpPut(key, value, clientv3.WithLease(clientv3.LeaseID(leaseID))),
+	)
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := txn.Commit()
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendTxnPutWithLease(conditions, key, value, leaseID, callTime, returnTime, resp, err)
+	return resp, err
+}
+
 func (c *RecordingClient) Defragment(ctx context.Context) (*clientv3.DefragmentResponse, error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
@@ -190,34 +382,111 @@ func (c *RecordingClient) Defragment(ctx context.Context) (*clientv3.DefragmentR
 	return resp, err
 }
 
-func (c *RecordingClient) Compact(ctx context.Context, rev int64) (*clientv3.CompactResponse, error) {
+// Compact compacts keyspace at given revision. Physical compaction returns
+// only after compaction was applied to the backend.
+func (c *RecordingClient) Compact(ctx context.Context, rev int64, physical bool) (*clientv3.CompactResponse, error) {
+	var opts []clientv3.CompactOption
+	if physical {
+		opts = append(opts, clientv3.WithCompactPhysical())
+	}
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
 	callTime := time.Since(c.baseTime)
-	resp, err := c.client.Compact(ctx, rev)
+	resp, err := c.client.Compact(ctx, rev, opts...)
 	returnTime := time.Since(c.baseTime)
-	c.kvOperations.AppendCompact(rev, callTime, returnTime, resp, err)
+	c.kvOperations.AppendCompact(rev, physical, callTime, returnTime, resp, err)
 	return resp, err
 }
 
-func (c *RecordingClient) MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
+// Snapshot streams a backup of the backend and returns its size and the revision it was taken at.
+func (c *RecordingClient) Snapshot(ctx context.Context) (size, revision int64, err error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
-	resp, err := c.client.MemberList(ctx, opts...)
+	callTime := time.Since(c.baseTime)
+	size, revision, err = c.snapshot(ctx)
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendSnapshot(callTime, returnTime, size, revision, err)
+	return size, revision, err
+}
+
+func (c *RecordingClient) snapshot(ctx context.Context) (size, revision int64, err error) {
+	resp, err := c.client.SnapshotWithVersion(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Snapshot.Close()
+	// Snapshot stream doesn't return revision, so it's read from the saved snapshot.
+	f, err := os.CreateTemp("", "robustness-snapshot-*.db")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.Remove(f.Name())
+	size, err = io.Copy(f, resp.Snapshot)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return size, 0, err
+	}
+	status, err := snapshot.NewV3(zap.NewNop()).Status(f.Name())
+	if err != nil {
+		return size, 0, err
+	}
+	return size, status.Revision, nil
+}
+
+// MoveLeader requests leadership transfer to the target member. Needs to be sent to the current leader.
+func (c *RecordingClient) MoveLeader(ctx context.Context, targetID uint64) (*clientv3.MoveLeaderResponse, error) {
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.MoveLeader(ctx, targetID)
+	returnTime := time.Since(c.baseTime)
+	c.kvOperations.AppendMoveLeader(targetID, callTime, returnTime, err)
 	return resp, err
 }
 
-func (c *RecordingClient) MemberAdd(ctx context.Context, peerAddrs []string) (*clientv3.MemberAddResponse, error) {
+func (c *RecordingClient) MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
-	resp, err := c.client.MemberAdd(ctx, peerAddrs)
+	callTime := time.Since(c.baseTime)
+	resp, err := c.client.MemberList(ctx, opts...)
+	returnTime := time.Since(c.baseTime)
+	var members []*etcdserverpb.Member
+	if resp != nil {
+		members = resp.Members
+	}
+	c.appendMembership(model.MembershipOperation{Type: model.MemberList, Call: callTime, Return: returnTime}, members, err)
 	return resp, err
 }
 
+func (c *RecordingClient) MemberAdd(ctx context.Context, peerAddrs []string) (*clientv3.MemberAddResponse, error) {
+	return c.memberAdd(ctx, peerAddrs, false)
+}
+
 func (c *RecordingClient) MemberAddAsLearner(ctx context.Context, peerAddrs []string) (*clientv3.MemberAddResponse, error) {
+	return c.memberAdd(ctx, peerAddrs, true)
+}
+
+func (c *RecordingClient) memberAdd(ctx context.Context, peerAddrs []string, isLearner bool) (resp *clientv3.MemberAddResponse, err error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
-	resp, err := c.client.MemberAddAsLearner(ctx, peerAddrs)
+	callTime := time.Since(c.baseTime)
+	if isLearner {
+		resp, err = c.client.MemberAddAsLearner(ctx, peerAddrs)
+	} else {
+		resp, err = c.client.MemberAdd(ctx, peerAddrs)
+	}
+	returnTime := time.Since(c.baseTime)
+	op := model.MembershipOperation{Type: model.MemberAdd, Call: callTime, Return: returnTime, PeerURLs: peerAddrs, IsLearner: isLearner}
+	var members []*etcdserverpb.Member
+	if resp != nil {
+		members = resp.Members
+		if resp.Member != nil {
+			op.MemberID = resp.Member.ID
+		}
+	}
+	c.appendMembership(op, members, err)
 	return resp, err
 }
 
@@ -246,6 +515,18 @@ func (c *RecordingClient) Status(ctx context.Context, endpoint string) (*clientv
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
 	resp, err := c.client.Status(ctx, endpoint)
+	if err == nil {
+		c.statusMux.Lock()
+		c.statuses = append(c.statuses, model.StatusObservation{
+			Endpoint:     endpoint,
+			Time:         time.Since(c.baseTime),
+			Revision:     resp.Header.Revision,
+			Term:         resp.RaftTerm,
+			CommitIndex:  resp.RaftIndex,
+			AppliedIndex: resp.RaftAppliedIndex,
+		})
+		c.statusMux.Unlock()
+	}
 	return resp, err
 }
 
@@ -265,6 +546,36 @@ func (c *RecordingClient) Watch(ctx context.Context, key string, rev int64, with
 
 }
 
+// WatchPrefix watches all keys with the prefix, starting from revision rev.
+func (c *RecordingClient) WatchPrefix(ctx context.Context, prefix string, rev int64) clientv3.WatchChan {
+	return c.Watch(ctx, prefix, rev, true, false, false)
+}
+
+// WatchWithFilter watches key with put or delete events filtered out by the server.
+func (c *RecordingClient) WatchWithFilter(ctx context.Context, key string, rev int64, withPrefix, withPrevKV, filterPut, filterDelete bool) clientv3.WatchChan {
+	request := model.WatchRequest{
+		Key:          key,
+		Revision:     rev,
+		WithPrefix:   withPrefix,
+		WithPrevKV:   withPrevKV,
+		FilterPut:    filterPut,
+		FilterDelete: filterDelete,
+	}
+	return c.watch(ctx, request)
+}
+
+// WatchWithFragment watches key with server side fragmentation of large responses enabled.
+// Fragments are reassembled by the client, so each recorded response is a complete one.
+func (c *RecordingClient) WatchWithFragment(ctx context.Context, key string, rev int64, withPrefix bool) clientv3.WatchChan {
+	request := model.WatchRequest{
+		Key:          key,
+		Revision:     rev,
+		WithPrefix:   withPrefix,
+		WithFragment: true,
+	}
+	return c.watch(ctx, request)
+}
+
 func (c *RecordingClient) watch(ctx context.Context, request model.WatchRequest) clientv3.WatchChan {
 	ops := []clientv3.OpOption{}
 	if request.WithPrefix {
@@ -279,20 +590,36 @@ func (c *RecordingClient) watch(ctx context.Context, request model.WatchRequest)
 	if request.WithPrevKV {
 		ops = append(ops, clientv3.WithPrevKV())
 	}
+	if request.WithFragment {
+		ops = append(ops, clientv3.WithFragment())
+	}
+	if request.FilterPut {
+		ops = append(ops, clientv3.WithFilterPut())
+	}
+	if request.FilterDelete {
+		ops = append(ops, clientv3.WithFilterDelete())
+	}
 	respCh := make(chan clientv3.WatchResponse)
 
-	c.watchMux.Lock()
-	c.watchOperations = append(c.watchOperations, model.WatchOperation{
+	op := &model.WatchOperation{
 		Request:   request,
 		Responses: []model.WatchResponse{},
-	})
-	index := len(c.watchOperations) - 1
+	}
+	c.watchMux.Lock()
+	c.watchOperations = append(c.watchOperations, op)
 	c.watchMux.Unlock()
 
 	go func() {
 		defer close(respCh)
+		defer func() {
+			c.watchMux.Lock()
+			defer c.watchMux.Unlock()
+			recordWatchEnd(op, ctx.Err(), time.Since(c.baseTime))
+		}()
 		for r := range c.client.Watch(ctx, request.Key, ops...) {
-			c.watchOperations[index].Responses = append(c.watchOperations[index].Responses, ToWatchResponse(r, c.baseTime))
+			c.watchMux.Lock()
+			op.Responses = append(op.Responses, ToWatchResponse(r, c.baseTime))
+			c.watchMux.Unlock()
 			select {
 			case respCh <- r:
 			case <-ctx.Done():
@@ -303,8 +630,55 @@ func (c *RecordingClient) watch(ctx context.Context, request model.WatchRequest)
 	return respCh
 }
 
+// recordWatchEnd records canceled response for watch ended by the client context,
+// as clientv3 closes the watch channel without one. Cancel reported by the server is kept.
+func recordWatchEnd(op *model.WatchOperation, ctxErr error, t time.Duration) {
+	if ctxErr == nil {
+		return
+	}
+	if _, canceled := op.CancelResponse(); canceled {
+		return
+	}
+	resp := model.WatchResponse{Time: t, Canceled: true, CancelReason: ctxErr.Error()}
+	if len(op.Responses) > 0 {
+		resp.Revision = op.Responses[len(op.Responses)-1].Revision
+	}
+	op.Responses = append(op.Responses, resp)
+}
+
 func (c *RecordingClient) RequestProgress(ctx context.Context) error {
-	return c.client.RequestProgress(ctx)
+	requestTime := time.Since(c.baseTime)
+	err := c.client.RequestProgress(ctx)
+	if err != nil {
+		return err
+	}
+	c.watchMux.Lock()
+	defer c.watchMux.Unlock()
+	c.progressRequests = append(c.progressRequests, requestTime)
+	return nil
+}
+
+// watchOperationsCopy returns operations of watches, copied so they can be read while watches are still running.
+func (c *RecordingClient) watchOperationsCopy() []model.WatchOperation {
+	c.watchMux.Lock()
+	defer c.watchMux.Unlock()
+	return copyWatchOperations(c.watchOperations)
+}
+
+func copyWatchOperations(ops []*model.WatchOperation) []model.WatchOperation {
+	watches := make([]model.WatchOperation, 0, len(ops))
+	for _, op := range ops {
+		watch := *op
+		watch.Responses = append([]model.WatchResponse{}, op.Responses...)
+		watches = append(watches, watch)
+	}
+	return watches
+}
+
+func (c *RecordingClient) progressRequestTimes() []time.Duration {
+	c.watchMux.Lock()
+	defer c.watchMux.Unlock()
+	return append([]time.Duration(nil), c.progressRequests...)
 }
 
 func ToWatchResponse(r clientv3.WatchResponse, baseTime time.Time) model.WatchResponse {
@@ -320,6 +694,13 @@ func ToWatchResponse(r clientv3.WatchResponse, baseTime time.Time) model.WatchRe
 	if err != nil {
 		resp.Error = r.Err().Error()
 	}
+	resp.CompactRevision = r.CompactRevision
+	if r.Canceled {
+		resp.Canceled = true
+		if err != nil {
+			resp.CancelReason = err.Error()
+		}
+	}
 	return resp
 }
 
@@ -333,8 +714,10 @@ func toWatchEvent(event clientv3.Event) (watch model.WatchEvent) {
 			Value:       model.ToValueOrHash(string(event.PrevKv.Value)),
 			ModRevision: event.PrevKv.ModRevision,
 		}
+		watch.PrevVersion = event.PrevKv.Version
 	}
 	watch.IsCreate = event.IsCreate()
+	watch.IsModify = event.IsModify()
 
 	switch event.Type {
 	case mvccpb.PUT:
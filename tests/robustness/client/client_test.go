@@ -0,0 +1,1077 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestRecordingClientSnapshot(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx := context.Background()
+	putResp, err := c.Put(ctx, "key", "value")
+	require.NoError(t, err)
+	size, revision, err := c.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, size)
+	assert.Equal(t, putResp.Header.Revision, revision)
+
+	operations := c.Report().KeyValue
+	require.Len(t, operations, 2)
+	request := operations[1].Input.(model.EtcdRequest)
+	response := operations[1].Output.(model.MaybeEtcdResponse)
+	assert.Equal(t, model.Snapshot, request.Type)
+	assert.Empty(t, response.Error)
+	assert.Equal(t, size, response.Snapshot.Size)
+	assert.Equal(t, revision, response.Snapshot.Revision)
+	assert.Less(t, operations[1].Call, operations[1].Return)
+}
+
+func newTestRecordingClient(t *testing.T, clus *integration.Cluster) *RecordingClient {
+	c, err := NewRecordingClient(clus.Endpoints(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestRecordingClientFailedPut(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err := c.Put(ctx, "key", "value")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = c.PutWithLease(context.Background(), "key", "value", 1)
+	require.ErrorIs(t, err, rpctypes.ErrLeaseNotFound)
+
+	operations := c.Report().KeyValue
+	require.Len(t, operations, 2)
+	timeout := operations[0].Output.(model.MaybeEtcdResponse)
+	assert.NotEmpty(t, timeout.Error)
+	assert.True(t, timeout.Indeterminate, "timed out put should be indeterminate")
+	assert.Equal(t, model.FailureDeadlineExceeded, timeout.Failure)
+	rejected := operations[1].Output.(model.MaybeEtcdResponse)
+	assert.NotEmpty(t, rejected.Error)
+	assert.False(t, rejected.Indeterminate, "put with not existing lease should be rejected")
+	assert.Equal(t, model.FailureServerError, rejected.Failure)
+
+	_, err = c.Put(context.Background(), "key", "value")
+	require.NoError(t, err)
+	assert.Equal(t, model.FailureNone, c.Report().KeyValue[2].Output.(model.MaybeEtcdResponse).Failure)
+}
+
+func TestRecordingClientCallDurations(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	_, err := c.Put(context.Background(), "key", "value")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err = c.Put(ctx, "key", "value")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	r := c.Report()
+	require.Len(t, r.KeyValue, 2)
+	succeeded := r.KeyValue[0].Output.(model.MaybeEtcdResponse)
+	assert.Equal(t, time.Duration(r.KeyValue[0].Return-r.KeyValue[0].Call), succeeded.CallDuration)
+	// Return time of failed put is unknown, but its duration is recorded.
+	failed := r.KeyValue[1].Output.(model.MaybeEtcdResponse)
+	assert.Positive(t, failed.CallDuration)
+	assert.Less(t, failed.CallDuration, time.Duration(r.KeyValue[1].Return-r.KeyValue[1].Call))
+}
+
+func TestRecordingClientResponseHeaders(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	putResp, err := c.Put(context.Background(), "key", "value")
+	require.NoError(t, err)
+	getResp, err := c.Range(context.Background(), "key", "", 0, 0)
+	require.NoError(t, err)
+
+	r := c.Report()
+	require.Len(t, r.KeyValue, 2)
+	putHeader := r.KeyValue[0].Output.(model.MaybeEtcdResponse).Header
+	getHeader := r.KeyValue[1].Output.(model.MaybeEtcdResponse).Header
+	assert.Equal(t, model.ResponseHeader{Revision: putResp.Header.Revision, RaftTerm: putResp.Header.RaftTerm, MemberID: putResp.Header.MemberId}, putHeader)
+	assert.Equal(t, model.ResponseHeader{Revision: getResp.Header.Revision, RaftTerm: getResp.Header.RaftTerm, MemberID: getResp.Header.MemberId}, getHeader)
+	assert.Positive(t, putHeader.RaftTerm)
+}
+
+func TestRecordingClientCount(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c", "d"} {
+		_, err := c.Put(ctx, key, "value")
+		require.NoError(t, err)
+	}
+	count, err := c.Count(ctx, "b", "d")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	operations := c.Report().KeyValue
+	request := operations[len(operations)-1].Input.(model.EtcdRequest)
+	response := operations[len(operations)-1].Output.(model.MaybeEtcdResponse)
+	assert.True(t, request.Range.CountOnly)
+	assert.Equal(t, int64(2), response.Range.Count)
+	assert.Empty(t, response.Range.KVs)
+	assert.Equal(t, int64(5), response.Revision)
+}
+
+func TestRecordingClientWatchCompactionCancel(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		_, err := c.Put(ctx, "key", "value")
+		require.NoError(t, err)
+	}
+	_, err := c.Compact(ctx, 3, false)
+	require.NoError(t, err)
+
+	for resp := range c.Watch(ctx, "key", 1, false, false, false) {
+		if resp.Canceled {
+			break
+		}
+	}
+	watches := c.Report().Watch
+	require.Len(t, watches, 1)
+	responses := watches[0].Responses
+	require.NotEmpty(t, responses)
+	last := responses[len(responses)-1]
+	assert.True(t, last.Canceled)
+	assert.Equal(t, int64(3), last.CompactRevision)
+	assert.Equal(t, rpctypes.ErrCompacted.Error(), last.CancelReason)
+}
+
+func TestRecordingClientWatchEnd(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		_, err := c.Put(ctx, "key", "value")
+		require.NoError(t, err)
+	}
+	_, err := c.Compact(ctx, 3, false)
+	require.NoError(t, err)
+
+	// Canceled by the server due to compaction.
+	for range c.Watch(ctx, "key", 1, false, false, false) {
+	}
+	// Canceled by the client after receiving an event.
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	defer watchCancel()
+	for resp := range c.Watch(watchCtx, "key", 4, false, false, false) {
+		if len(resp.Events) > 0 {
+			watchCancel()
+		}
+	}
+
+	watches := c.Report().Watch
+	require.Len(t, watches, 2)
+	serverCancel, canceled := watches[0].CancelResponse()
+	require.True(t, canceled)
+	assert.Equal(t, rpctypes.ErrCompacted.Error(), serverCancel.CancelReason)
+	assert.Equal(t, int64(3), serverCancel.CompactRevision)
+	clientCancel, canceled := watches[1].CancelResponse()
+	require.True(t, canceled)
+	assert.Equal(t, context.Canceled.Error(), clientCancel.CancelReason)
+	assert.Equal(t, int64(4), clientCancel.Revision)
+}
+
+func TestRecordingClientWatchEventTypes(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		_, err := c.Put(ctx, "key", fmt.Sprintf("value%d", i))
+		require.NoError(t, err)
+	}
+	_, err := c.Delete(ctx, "key")
+	require.NoError(t, err)
+
+	var events []*clientv3.Event
+	for resp := range c.Watch(ctx, "key", 2, false, false, true) {
+		events = append(events, resp.Events...)
+		if len(events) >= 3 {
+			cancel()
+		}
+	}
+	require.Len(t, events, 3)
+	watches := c.Report().Watch
+	require.Len(t, watches, 1)
+	require.NotEmpty(t, watches[0].Responses)
+	var recorded []model.WatchEvent
+	for _, resp := range watches[0].Responses {
+		recorded = append(recorded, resp.Events...)
+	}
+	require.Len(t, recorded, 3)
+	assert.True(t, recorded[0].IsCreate)
+	assert.False(t, recorded[0].IsModify)
+	assert.False(t, recorded[1].IsCreate)
+	assert.True(t, recorded[1].IsModify)
+	assert.Equal(t, model.DeleteOperation, recorded[2].Type)
+	assert.False(t, recorded[2].IsModify)
+	assert.Equal(t, &model.ValueRevision{Value: model.ToValueOrHash("value1"), ModRevision: 3}, recorded[2].PrevValue)
+	assert.Equal(t, int64(2), recorded[2].PrevVersion)
+}
+
+func TestAuthedRecordingClientPermissionDenied(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx := context.Background()
+	root := clus.Client(0)
+	_, err := root.UserAdd(ctx, "root", "rootPassword")
+	require.NoError(t, err)
+	_, err = root.UserGrantRole(ctx, "root", "root")
+	require.NoError(t, err)
+	_, err = root.RoleAdd(ctx, "limited")
+	require.NoError(t, err)
+	_, err = root.RoleGrantPermission(ctx, "limited", "a", "b", clientv3.PermissionType(clientv3.PermReadWrite))
+	require.NoError(t, err)
+	_, err = root.UserAdd(ctx, "user", "userPassword")
+	require.NoError(t, err)
+	_, err = root.UserGrantRole(ctx, "user", "limited")
+	require.NoError(t, err)
+	_, err = root.AuthEnable(ctx)
+	require.NoError(t, err)
+
+	c, err := NewAuthedRecordingClient(clus.Endpoints(), "user", "userPassword", identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+	_, err = c.Put(ctx, "a", "1")
+	require.NoError(t, err)
+	_, err = c.Put(ctx, "c", "1")
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	r := c.Report()
+	assert.Equal(t, "user", r.Username)
+	require.Len(t, r.KeyValue, 2)
+	response := r.KeyValue[1].Output.(model.MaybeEtcdResponse)
+	assert.Equal(t, rpctypes.ErrPermissionDenied.Error(), response.Error)
+	assert.False(t, response.Indeterminate)
+}
+
+func TestRecordingClientDroppedResponse(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseTCP: true})
+	defer clus.Terminate(t)
+
+	p, err := NewClientProxy(zaptest.NewLogger(t), clus.Members[0].GRPCURL)
+	require.NoError(t, err)
+	defer p.Close()
+	c, err := NewRecordingClient([]string{p.Endpoint()}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+	_, err = c.Put(context.Background(), "a", "1")
+	require.NoError(t, err)
+
+	p.DropResponses()
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_, err = c.Put(ctx, "b", "2")
+	require.Error(t, err)
+	p.UndropResponses()
+
+	operations := c.Report().KeyValue
+	require.Len(t, operations, 2)
+	response := operations[1].Output.(model.MaybeEtcdResponse)
+	assert.True(t, response.Indeterminate)
+
+	resp, err := clus.Client(0).Get(context.Background(), "b")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "2", string(resp.Kvs[0].Value))
+}
+
+func TestRecordingClientRangePaginated(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx := context.Background()
+	for i := 0; i < 300; i++ {
+		_, err := c.Put(ctx, fmt.Sprintf("key%03d", i), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	pages, err := c.RangePaginated(ctx, "key", "kez", 7)
+	require.NoError(t, err)
+	require.Len(t, pages, 43)
+	full, err := c.Range(ctx, "key", "kez", pages[0].Header.Revision, 0)
+	require.NoError(t, err)
+	var keys []string
+	for _, page := range pages {
+		for _, kv := range page.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+	}
+	var fullKeys []string
+	for _, kv := range full.Kvs {
+		fullKeys = append(fullKeys, string(kv.Key))
+	}
+	assert.Equal(t, fullKeys, keys)
+
+	operations := c.Report().KeyValue
+	pageOperations := operations[len(operations)-len(pages)-1 : len(operations)-1]
+	for i, op := range pageOperations {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		assert.Equal(t, int64(7), request.Range.Limit)
+		assert.Equal(t, i != len(pages)-1, response.Range.More)
+	}
+}
+
+func TestMeasureThroughput(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	i := 0
+	throughput, err := MeasureThroughput(context.Background(), c, time.Second, func(ctx context.Context, c *RecordingClient) error {
+		i++
+		_, err := c.Put(ctx, "key", fmt.Sprintf("%d", i))
+		return err
+	})
+	require.NoError(t, err)
+	t.Logf("Measured throughput: %.1f ops/s", throughput)
+	assert.Greater(t, throughput, 50.0)
+}
+
+func TestRecordingClientStatusCommitIndex(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		_, err := c.Put(ctx, "key", fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+		_, err = c.Status(ctx, c.Endpoints()[0])
+		require.NoError(t, err)
+	}
+
+	statuses := c.Report().Status
+	require.Len(t, statuses, 5)
+	for i := 1; i < len(statuses); i++ {
+		assert.GreaterOrEqual(t, statuses[i].CommitIndex, statuses[i-1].CommitIndex)
+		assert.Greater(t, statuses[i].Revision, statuses[i-1].Revision)
+	}
+	assert.NotZero(t, statuses[0].CommitIndex)
+}
+
+func TestRecordingClientDeadlineExceeded(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err := c.Put(ctx, "key", "value")
+	require.Error(t, err)
+	_, _, err = c.Get(ctx, "key", 0)
+	require.Error(t, err)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 2)
+	put := ops[0].Output.(model.MaybeEtcdResponse)
+	assert.Equal(t, model.FailureDeadlineExceeded, put.Failure)
+	assert.True(t, put.Indeterminate)
+	get := ops[1].Output.(model.MaybeEtcdResponse)
+	assert.Equal(t, model.FailureDeadlineExceeded, get.Failure)
+	// Write might have been persisted any time later, while read has a known return time.
+	assert.Greater(t, ops[0].Return, ops[1].Return)
+	assert.NotEqual(t, ops[0].ClientId, ops[1].ClientId)
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = c.Get(cancelCtx, "key", 0)
+	require.Error(t, err)
+	ops = c.Report().KeyValue
+	require.Len(t, ops, 3)
+	assert.Equal(t, model.FailureCanceled, ops[2].Output.(model.MaybeEtcdResponse).Failure)
+}
+
+func TestRecordingClientMoveLeader(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	leaderIdx := clus.WaitLeader(t)
+	targetIdx := (leaderIdx + 1) % len(clus.Members)
+	target := uint64(clus.Members[targetIdx].Server.MemberID())
+	c, err := NewRecordingClient([]string{clus.Members[leaderIdx].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	before, err := c.Status(ctx, c.Endpoints()[0])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(clus.Members[leaderIdx].Server.MemberID()), before.Leader)
+	_, err = c.MoveLeader(ctx, target)
+	require.NoError(t, err)
+	after, err := c.Status(ctx, c.Endpoints()[0])
+	require.NoError(t, err)
+	assert.Equal(t, target, after.Leader)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 1)
+	request := ops[0].Input.(model.EtcdRequest)
+	assert.Equal(t, model.MoveLeader, request.Type)
+	assert.Equal(t, target, request.MoveLeader.TargetID)
+	assert.Empty(t, ops[0].Output.(model.MaybeEtcdResponse).Error)
+}
+
+func TestRecordingClientRangeLease(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	lease, err := c.LeaseGrant(ctx, 60)
+	require.NoError(t, err)
+	_, err = c.PutWithLease(ctx, "leased", "1", int64(lease.ID))
+	require.NoError(t, err)
+	_, err = c.Put(ctx, "not-leased", "2")
+	require.NoError(t, err)
+	_, err = c.Range(ctx, "leased", "", 0, 0)
+	require.NoError(t, err)
+	_, err = c.Range(ctx, "leased", "not-leasee", 0, 0)
+	require.NoError(t, err)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 5)
+	get := ops[3].Output.(model.MaybeEtcdResponse).Range
+	require.Len(t, get.KVs, 1)
+	assert.Equal(t, int64(lease.ID), get.KVs[0].Lease)
+	all := ops[4].Output.(model.MaybeEtcdResponse).Range
+	require.Len(t, all.KVs, 2)
+	assert.Equal(t, "leased", all.KVs[0].Key)
+	assert.Equal(t, int64(lease.ID), all.KVs[0].Lease)
+	assert.Equal(t, "not-leased", all.KVs[1].Key)
+	assert.Zero(t, all.KVs[1].Lease)
+}
+
+func TestRecordingClientLeaseOperations(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	lease, err := c.LeaseGrant(ctx, 60)
+	require.NoError(t, err)
+	_, err = c.LeaseKeepAliveOnce(ctx, int64(lease.ID))
+	require.NoError(t, err)
+	_, err = c.LeaseRevoke(ctx, int64(lease.ID))
+	require.NoError(t, err)
+	_, err = c.LeaseKeepAliveOnce(ctx, int64(lease.ID))
+	require.ErrorIs(t, err, rpctypes.ErrLeaseNotFound)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 4)
+	expectTypes := []model.RequestType{model.LeaseGrant, model.LeaseKeepAlive, model.LeaseRevoke, model.LeaseKeepAlive}
+	for i, op := range ops {
+		assert.Equal(t, expectTypes[i], op.Input.(model.EtcdRequest).Type)
+		assert.Less(t, op.Call, op.Return)
+		if i > 0 {
+			assert.Less(t, ops[i-1].Return, op.Call)
+		}
+	}
+	assert.Equal(t, int64(lease.ID), ops[1].Input.(model.EtcdRequest).LeaseKeepAlive.LeaseID)
+	assert.NotNil(t, ops[1].Output.(model.MaybeEtcdResponse).LeaseKeepAlive)
+	assert.Equal(t, rpctypes.ErrLeaseNotFound.Error(), ops[3].Output.(model.MaybeEtcdResponse).ClientError)
+}
+
+func TestRecordingClientTxnPutWithLease(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	lease, err := c.LeaseGrant(ctx, 60)
+	require.NoError(t, err)
+	_, err = c.TxnPutWithLease(ctx, nil, "key", "value", int64(lease.ID))
+	require.NoError(t, err)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 2)
+	put := ops[1].Input.(model.EtcdRequest).Txn.OperationsOnSuccess[0].Put
+	assert.Equal(t, "key", put.Key)
+	assert.Equal(t, int64(lease.ID), put.LeaseID)
+}
+
+func TestRecordingClientNestedTxn(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := c.Put(ctx, "key", "1")
+	require.NoError(t, err)
+	nested := clientv3.OpTxn([]clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision("a"), "=", 0)}, []clientv3.Op{clientv3.OpPut("key", "2")}, []clientv3.Op{clientv3.OpGet("key")})
+	resp, err := c.Txn(ctx, nil, []clientv3.Op{clientv3.OpPut("a", "1"), nested, clientv3.OpGet("key")}, nil)
+	require.NoError(t, err)
+	require.True(t, resp.Responses[1].GetResponseTxn().Succeeded)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 2)
+	txn := ops[1].Input.(model.EtcdRequest).Txn
+	require.Equal(t, model.TxnOperation, txn.OperationsOnSuccess[1].Type)
+	assert.Equal(t, "key", txn.OperationsOnSuccess[1].Txn.OperationsOnSuccess[0].Put.Key)
+	nestedResp := ops[1].Output.(model.MaybeEtcdResponse).Txn.Results[1].Txn
+	require.NotNil(t, nestedResp)
+	assert.False(t, nestedResp.Failure)
+
+	state := model.NonDeterministicModel.Init()
+	for _, op := range ops {
+		var ok bool
+		ok, state = model.NonDeterministicModel.Step(state, op.Input, op.Output)
+		require.Truef(t, ok, "model rejected %s", model.NonDeterministicModel.DescribeOperation(op.Input, op.Output))
+	}
+}
+
+func TestRecordingClientTxnSucceeded(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmps := []clientv3.Cmp{clientv3.Compare(clientv3.ModRevision("key"), "=", 0)}
+	for _, wantSucceeded := range []bool{true, false} {
+		resp, err := c.Txn(ctx, cmps, []clientv3.Op{clientv3.OpPut("key", "value")}, []clientv3.Op{clientv3.OpGet("key")})
+		require.NoError(t, err)
+		require.Equal(t, wantSucceeded, resp.Succeeded)
+	}
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 2)
+	assert.False(t, ops[0].Output.(model.MaybeEtcdResponse).Txn.Failure)
+	failed := ops[1].Output.(model.MaybeEtcdResponse).Txn
+	assert.True(t, failed.Failure)
+	require.Len(t, failed.Results, 1)
+	assert.Equal(t, "key", failed.Results[0].KVs[0].Key)
+}
+
+func TestRecordingClientCompareAndSwap(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, tc := range []struct {
+		expectedValue, newValue string
+		wantSucceeded           bool
+	}{
+		{expectedValue: "", newValue: "1", wantSucceeded: true},
+		{expectedValue: "", newValue: "2", wantSucceeded: false},
+		{expectedValue: "2", newValue: "3", wantSucceeded: false},
+		{expectedValue: "1", newValue: "2", wantSucceeded: true},
+	} {
+		succeeded, err := c.CompareAndSwap(ctx, "key", tc.expectedValue, tc.newValue)
+		require.NoError(t, err)
+		assert.Equalf(t, tc.wantSucceeded, succeeded, "CompareAndSwap(%q, %q)", tc.expectedValue, tc.newValue)
+	}
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 4)
+	cond := ops[3].Input.(model.EtcdRequest).Txn.Conditions[0]
+	assert.Equal(t, model.EtcdCondition{Key: "key", Target: model.CompareValue, ExpectedValue: model.ToValueOrHash("1")}, cond)
+	assert.True(t, ops[2].Output.(model.MaybeEtcdResponse).Txn.Failure)
+	assert.False(t, ops[3].Output.(model.MaybeEtcdResponse).Txn.Failure)
+
+	state := model.NonDeterministicModel.Init()
+	for _, op := range ops {
+		var ok bool
+		ok, state = model.NonDeterministicModel.Step(state, op.Input, op.Output)
+		require.Truef(t, ok, "model rejected %s", model.NonDeterministicModel.DescribeOperation(op.Input, op.Output))
+	}
+}
+
+func TestRecordingClientPrevKV(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := c.PutWithPrevKV(ctx, "key", "1")
+	require.NoError(t, err)
+	_, err = c.PutWithPrevKV(ctx, "key", "2")
+	require.NoError(t, err)
+	_, err = c.DeleteWithPrevKV(ctx, "key")
+	require.NoError(t, err)
+	_, err = c.DeleteWithPrevKV(ctx, "key")
+	require.NoError(t, err)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 4)
+	prevKVs := make([]*model.KeyValue, len(ops))
+	for i, op := range ops {
+		prevKVs[i] = op.Output.(model.MaybeEtcdResponse).Txn.Results[0].PrevKV
+	}
+	assert.Nil(t, prevKVs[0])
+	require.NotNil(t, prevKVs[1])
+	assert.Equal(t, model.ToValueOrHash("1"), prevKVs[1].Value)
+	require.NotNil(t, prevKVs[2])
+	assert.Equal(t, model.ToValueOrHash("2"), prevKVs[2].Value)
+	assert.Nil(t, prevKVs[3])
+
+	state := model.NonDeterministicModel.Init()
+	for _, op := range ops {
+		var ok bool
+		ok, state = model.NonDeterministicModel.Step(state, op.Input, op.Output)
+		require.Truef(t, ok, "model rejected %s", model.NonDeterministicModel.DescribeOperation(op.Input, op.Output))
+	}
+}
+
+func TestRecordingClientDeleteRange(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, key := range []string{"a/1", "a/2", "b/1", "c"} {
+		_, err := c.Put(ctx, key, "value")
+		require.NoError(t, err)
+	}
+	resp, err := c.DeletePrefix(ctx, "a/")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Deleted)
+	resp, err = c.DeleteRange(ctx, "b", "c")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Deleted)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 6)
+	assert.Equal(t, model.DeleteOptions{Key: "a/", End: "a0"}, ops[4].Input.(model.EtcdRequest).Txn.OperationsOnSuccess[0].Delete)
+	assert.Equal(t, int64(2), ops[4].Output.(model.MaybeEtcdResponse).Txn.Results[0].Deleted)
+
+	state := model.NonDeterministicModel.Init()
+	for _, op := range ops {
+		var ok bool
+		ok, state = model.NonDeterministicModel.Step(state, op.Input, op.Output)
+		require.Truef(t, ok, "model rejected %s", model.NonDeterministicModel.DescribeOperation(op.Input, op.Output))
+	}
+}
+
+func TestRecordingClientGetSerializable(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := c.Put(ctx, "key", "value")
+	require.NoError(t, err)
+	kv, rev, err := c.GetSerializable(ctx, "key")
+	require.NoError(t, err)
+	require.NotNil(t, kv)
+	assert.Equal(t, "value", string(kv.Value))
+	assert.Equal(t, resp.Header.Revision, rev)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 2)
+	request := ops[1].Input.(model.EtcdRequest)
+	assert.True(t, request.Range.Serializable)
+	assert.False(t, request.Range.IsLinearizable())
+	assert.Equal(t, rev, ops[1].Output.(model.MaybeEtcdResponse).Revision)
+}
+
+func TestRecordingClientCompactPhysical(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		_, err := c.Put(ctx, "key", fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	_, err := c.Compact(ctx, 2, false)
+	require.NoError(t, err)
+	_, err = c.Compact(ctx, 3, true)
+	require.NoError(t, err)
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 5)
+	assert.Equal(t, model.CompactRequest{Revision: 2}, *ops[3].Input.(model.EtcdRequest).Compact)
+	assert.Equal(t, model.CompactRequest{Revision: 3, Physical: true}, *ops[4].Input.(model.EtcdRequest).Compact)
+	assert.NotNil(t, ops[4].Output.(model.MaybeEtcdResponse).Compact)
+}
+
+func TestRecordingClientGetCompactedRevision(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		_, err := c.Put(ctx, "key", fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	kv, _, err := c.Get(ctx, "key", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(kv.Value))
+	_, err = c.Compact(ctx, 3, true)
+	require.NoError(t, err)
+	_, _, err = c.Get(ctx, "key", 2)
+	require.ErrorContains(t, err, mvcc.ErrCompacted.Error())
+
+	ops := c.Report().KeyValue
+	require.Len(t, ops, 6)
+	assert.Equal(t, int64(3), ops[3].Input.(model.EtcdRequest).Range.Revision)
+	assert.Equal(t, int64(2), ops[5].Input.(model.EtcdRequest).Range.Revision)
+	response := ops[5].Output.(model.MaybeEtcdResponse)
+	assert.Empty(t, response.Error)
+	assert.Equal(t, model.EtcdResponse{ClientError: mvcc.ErrCompacted.Error()}, response.EtcdResponse)
+}
+
+func TestRecordingClientMembership(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := newTestRecordingClient(t, clus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	list, err := c.MemberList(ctx)
+	require.NoError(t, err)
+	require.Len(t, list.Members, 1)
+	peerURLs := []string{"http://127.0.0.1:1234"}
+	add, err := c.MemberAddAsLearner(ctx, peerURLs)
+	require.NoError(t, err)
+
+	ops := c.Report().Membership
+	require.Len(t, ops, 2)
+	assert.Equal(t, model.MemberList, ops[0].Type)
+	assert.Equal(t, []uint64{list.Members[0].ID}, ops[0].MemberIDs)
+	assert.Equal(t, model.MemberAdd, ops[1].Type)
+	assert.Equal(t, peerURLs, ops[1].PeerURLs)
+	assert.True(t, ops[1].IsLearner)
+	assert.Equal(t, add.Member.ID, ops[1].MemberID)
+	assert.ElementsMatch(t, []uint64{list.Members[0].ID, add.Member.ID}, ops[1].MemberIDs)
+	assert.Less(t, ops[0].Return, ops[1].Call)
+	assert.Empty(t, c.Report().KeyValue)
+}
+
+func TestAssertFailedTxnNoEffect(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := c.Put(ctx, "a", "1")
+	require.NoError(t, err)
+	_, err = c.Put(ctx, "b", "2")
+	require.NoError(t, err)
+
+	AssertFailedTxnNoEffect(ctx, t, c, func(ctx context.Context, c *RecordingClient) (resp *clientv3.TxnResponse, err error) {
+		// Retry to make sure all attempts are covered.
+		for i := 0; i < 2; i++ {
+			resp, err = c.Txn(ctx,
+				[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision("a"), "=", 1)},
+				[]clientv3.Op{clientv3.OpPut("a", "3"), clientv3.OpDelete("b")},
+				nil,
+			)
+		}
+		return resp, err
+	})
+
+	txns := 0
+	for _, op := range c.Report().KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		if request.Type != model.Txn || len(request.Txn.Conditions) == 0 {
+			continue
+		}
+		assert.True(t, op.Output.(model.MaybeEtcdResponse).Txn.Failure)
+		txns++
+	}
+	assert.Equal(t, 2, txns)
+}
+
+func TestRunConcurrentWatchers(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	watches, ops, err := RunConcurrentWatchers(ctx, c, "key", 100)
+	require.NoError(t, err)
+	require.Len(t, watches, 100)
+
+	resp, err := c.Put(ctx, "key", "value")
+	require.NoError(t, err)
+	for _, watch := range watches {
+		var r clientv3.WatchResponse
+		for r = range watch {
+			if !r.IsProgressNotify() {
+				break
+			}
+		}
+		require.Len(t, r.Events, 1)
+		assert.Equal(t, resp.Header.Revision, r.Events[0].Kv.ModRevision)
+	}
+	recorded := ops()
+	require.Len(t, recorded, 100)
+	for _, op := range recorded {
+		assert.Equal(t, "key", op.Request.Key)
+	}
+}
+
+func TestRecordingClientWatchExactlyOnceAcrossReconnect(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := c.Put(ctx, "key0", "0")
+	require.NoError(t, err)
+	startRevision := resp.Header.Revision + 1
+
+	watch := c.Watch(ctx, "key", startRevision, true, false, false)
+	for i := 1; i <= 3; i++ {
+		_, err = c.Put(ctx, fmt.Sprintf("key%d", i), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	// Restarting member breaks the watch stream, forcing client to resume it.
+	clus.Members[0].Stop(t)
+	require.NoError(t, clus.Members[0].Restart(t))
+	clus.WaitLeader(t)
+	for i := 4; i <= 6; i++ {
+		_, err = c.Put(ctx, fmt.Sprintf("key%d", i), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+
+	events := 0
+	for resp := range watch {
+		events += len(resp.Events)
+		if events >= 6 {
+			break
+		}
+	}
+	watches := c.Report().Watch
+	require.Len(t, watches, 1)
+	assert.Empty(t, watches[0].Duplicates())
+	assert.Equal(t, 6, events)
+}
+
+func TestRecordingClientWatchPrefix(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := newTestRecordingClient(t, clus)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := c.Put(ctx, "other", "0")
+	require.NoError(t, err)
+	startRevision := resp.Header.Revision + 1
+
+	watch := c.WatchPrefix(ctx, "key", startRevision)
+	for i := 1; i <= 3; i++ {
+		_, err = c.Put(ctx, fmt.Sprintf("key%d", i), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+		_, err = c.Put(ctx, "other", fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+
+	events := 0
+	for resp := range watch {
+		events += len(resp.Events)
+		if events >= 3 {
+			break
+		}
+	}
+	watches := c.Report().Watch
+	require.Len(t, watches, 1)
+	assert.Equal(t, model.WatchRequest{Key: "key", Revision: startRevision, WithPrefix: true}, watches[0].Request)
+	for _, resp := range watches[0].Responses {
+		for _, event := range resp.Events {
+			assert.True(t, event.Match(watches[0].Request), "event of key %q outside of watched prefix", event.Key)
+		}
+	}
+	assert.Empty(t, watches[0].Duplicates())
+	assert.Equal(t, 3, events)
+}
+
+func TestClientSetWithSeed(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cs := NewSetWithSeed(42, time.Now())
+	assert.Equal(t, int64(42), cs.Seed())
+	c, err := cs.NewClient(clus.Endpoints())
+	require.NoError(t, err)
+	_, err = c.Put(context.Background(), "key", "value")
+	require.NoError(t, err)
+
+	reports := cs.Reports()
+	require.Len(t, reports, 1)
+	assert.Equal(t, c.ID, reports[0].ClientID)
+	assert.Len(t, reports[0].KeyValue, 1)
+	_, err = cs.NewClient(clus.Endpoints())
+	require.ErrorIs(t, err, ErrClientSetClosed)
+}
+
+func TestClientSetMaxClients(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cs := NewSet(identity.NewIDProvider(), time.Now(), WithMaxClients(2))
+	defer cs.Close()
+	for i := 0; i < 2; i++ {
+		_, err := cs.NewClient(clus.Endpoints())
+		require.NoError(t, err)
+	}
+	_, err := cs.NewClient(clus.Endpoints())
+	require.ErrorIs(t, err, ErrClientSetFull)
+	assert.Len(t, cs.Reports(), 2)
+}
+
+func TestCollectReports(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	sets := []*ClientSet{NewSet(ids, baseTime), NewSet(ids, baseTime)}
+	var clients []*RecordingClient
+	for i := 0; i < 4; i++ {
+		c, err := sets[i%2].NewClient(clus.Endpoints())
+		require.NoError(t, err)
+		clients = append(clients, c)
+	}
+	for i := len(clients) - 1; i >= 0; i-- {
+		_, err := clients[i].Put(context.Background(), "key", fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+
+	reports := CollectReports(sets...)
+	require.Len(t, reports, 4)
+	for i, r := range reports {
+		assert.Equal(t, clients[i].ID, r.ClientID)
+	}
+	merged := report.MergeReports(reports)
+	require.Len(t, merged.KeyValue, 4)
+	for i := 1; i < len(merged.KeyValue); i++ {
+		assert.Less(t, merged.KeyValue[i-1].Call, merged.KeyValue[i].Call)
+	}
+	assert.Panics(t, func() { CollectReports(NewSet(ids, baseTime), NewSet(ids, time.Now())) })
+}
+
+func TestClientSetNewClientWithConfig(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cs := NewSet(identity.NewIDProvider(), time.Now())
+	_, err := cs.NewClientWithConfig(clientv3.Config{DialTimeout: time.Second})
+	require.ErrorContains(t, err, "no endpoints")
+
+	c, err := cs.NewClientWithConfig(clientv3.Config{
+		Endpoints:            clus.Endpoints(),
+		DialKeepAliveTime:    time.Second,
+		DialKeepAliveTimeout: time.Second,
+	})
+	require.NoError(t, err)
+	_, err = c.Put(context.Background(), "key", "value")
+	require.NoError(t, err)
+
+	reports := cs.Reports()
+	require.Len(t, reports, 1)
+	assert.Len(t, reports[0].KeyValue, 1)
+}
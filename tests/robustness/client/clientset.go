@@ -0,0 +1,145 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var (
+	ErrClientSetClosed = errors.New("the clientset is already closed")
+	ErrClientSetFull   = errors.New("the clientset reached its client limit")
+)
+
+// ClientSet creates recording clients sharing identity provider and base time, and collects their reports.
+type ClientSet struct {
+	mux        sync.Mutex
+	closed     bool
+	idProvider identity.Provider
+	baseTime   time.Time
+	// maxClients limits number of clients created by the set, zero means no limit.
+	maxClients int
+	clients    []*RecordingClient
+}
+
+type SetOption func(*ClientSet)
+
+// WithMaxClients limits number of clients the set can create.
+func WithMaxClients(limit int) SetOption {
+	return func(cs *ClientSet) { cs.maxClients = limit }
+}
+
+func NewSet(ids identity.Provider, baseTime time.Time, opts ...SetOption) *ClientSet {
+	cs := &ClientSet{
+		idProvider: ids,
+		baseTime:   baseTime,
+	}
+	for _, opt := range opts {
+		opt(cs)
+	}
+	return cs
+}
+
+// NewSetWithSeed creates client set with identity provider seeded with seed, so identity
+// assignment of clients and their requests can be reproduced.
+func NewSetWithSeed(seed int64, baseTime time.Time, opts ...SetOption) *ClientSet {
+	return NewSet(identity.NewIDProviderWithSeed(seed), baseTime, opts...)
+}
+
+// Seed returns seed of identity provider used by the set.
+func (cs *ClientSet) Seed() int64 {
+	return cs.idProvider.Seed()
+}
+
+func (cs *ClientSet) NewClient(endpoints []string) (*RecordingClient, error) {
+	return cs.NewClientWithConfig(clientConfig(endpoints))
+}
+
+// NewClientWithConfig creates recording client with custom config, for example with different
+// keepalive, TLS or auth. Nop logger is used if config doesn't set one.
+func (cs *ClientSet) NewClientWithConfig(cfg clientv3.Config) (*RecordingClient, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("client config has no endpoints")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	cs.mux.Lock()
+	defer cs.mux.Unlock()
+	if cs.closed {
+		return nil, ErrClientSetClosed
+	}
+	if cs.maxClients != 0 && len(cs.clients) >= cs.maxClients {
+		return nil, fmt.Errorf("%w, limit: %d", ErrClientSetFull, cs.maxClients)
+	}
+	c, err := newRecordingClient(cfg, cs.idProvider, cs.baseTime)
+	if err != nil {
+		return nil, err
+	}
+	cs.clients = append(cs.clients, c)
+	return c, nil
+}
+
+// Close closes all clients of the set. No new clients can be created afterwards.
+func (cs *ClientSet) Close() {
+	cs.mux.Lock()
+	defer cs.mux.Unlock()
+	if cs.closed {
+		return
+	}
+	for _, c := range cs.clients {
+		c.Close()
+	}
+	cs.closed = true
+}
+
+// Reports closes the set and returns reports of all its clients.
+func (cs *ClientSet) Reports() []report.ClientReport {
+	cs.Close()
+	cs.mux.Lock()
+	defer cs.mux.Unlock()
+	reports := make([]report.ClientReport, 0, len(cs.clients))
+	for _, c := range cs.clients {
+		reports = append(reports, c.Report())
+	}
+	return reports
+}
+
+// CollectReports returns reports of clients from all sets, ordered by client ID. Sets need to share
+// base time, as otherwise times of their operations cannot be compared. Merging the result with
+// report.MergeReports orders operations by call time, with ties broken by client ID.
+func CollectReports(sets ...*ClientSet) []report.ClientReport {
+	reports := []report.ClientReport{}
+	for _, cs := range sets {
+		if !cs.baseTime.Equal(sets[0].baseTime) {
+			panic(fmt.Sprintf("client sets have different base time, %s != %s", cs.baseTime, sets[0].baseTime))
+		}
+		reports = append(reports, cs.Reports()...)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].ClientID < reports[j].ClientID
+	})
+	return reports
+}
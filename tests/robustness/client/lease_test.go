@@ -0,0 +1,78 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/validate"
+)
+
+func TestLeaseRevokeDeletesKeys(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := client.NewRecordingClient([]string{clus.Members[0].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	resp, err := c.Put(ctx, "start", "0")
+	require.NoError(t, err)
+	watch := c.Watch(ctx, "key", resp.Header.Revision+1, true, false, false)
+
+	lease, err := c.LeaseGrant(ctx, 60)
+	require.NoError(t, err)
+	otherLease, err := c.LeaseGrant(ctx, 60)
+	require.NoError(t, err)
+	for _, key := range []string{"key0", "key1", "key2"} {
+		_, err = c.PutWithLease(ctx, key, "1", int64(lease.ID))
+		require.NoError(t, err)
+	}
+	// Reattached key survives the revoke.
+	_, err = c.PutWithLease(ctx, "key2", "2", int64(otherLease.ID))
+	require.NoError(t, err)
+	revoke, err := c.LeaseRevoke(ctx, int64(lease.ID))
+	require.NoError(t, err)
+	get, err := c.Range(ctx, "key", "kez", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, get.Kvs, 1)
+	assert.Equal(t, "key2", string(get.Kvs[0].Key))
+
+	deleted := map[string]int64{}
+	for resp := range watch {
+		for _, event := range resp.Events {
+			if event.Type == mvccpb.DELETE {
+				deleted[string(event.Kv.Key)] = event.Kv.ModRevision
+			}
+		}
+		if len(deleted) >= 2 {
+			break
+		}
+	}
+	assert.Equal(t, map[string]int64{"key0": revoke.Header.Revision, "key1": revoke.Header.Revision}, deleted)
+	require.NoError(t, validate.ValidateLeaseRevokeDeletesKeys(c.Report()))
+}
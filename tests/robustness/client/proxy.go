@@ -0,0 +1,76 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/pkg/v3/proxy"
+)
+
+// ClientProxy forwards client traffic to a single etcd endpoint, allowing to
+// inject faults on the link between client and server.
+type ClientProxy struct {
+	proxy.Server
+	endpoint string
+}
+
+// NewClientProxy starts proxy on a free local port forwarding to given non-TLS endpoint.
+func NewClientProxy(lg *zap.Logger, endpoint string) (*ClientProxy, error) {
+	to, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	// Reserve a free port, it's released just before proxy starts listening on it.
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, err
+	}
+	from := url.URL{Scheme: "tcp", Host: ln.Addr().String()}
+	ln.Close()
+
+	p := proxy.NewServer(proxy.ServerConfig{
+		Logger: lg,
+		From:   from,
+		To:     *to,
+	})
+	select {
+	case <-p.Ready():
+	case err = <-p.Error():
+		p.Close()
+		return nil, fmt.Errorf("failed to start client proxy: %w", err)
+	}
+	return &ClientProxy{Server: p, endpoint: "http://" + from.Host}, nil
+}
+
+// Endpoint returns address clients should connect to.
+func (p *ClientProxy) Endpoint() string {
+	return p.endpoint
+}
+
+// DropResponses keeps forwarding client requests to the server, but drops
+// the server responses, so client cannot know whether its request was applied.
+func (p *ClientProxy) DropResponses() {
+	p.BlackholeRx()
+}
+
+// UndropResponses removes dropping of server responses.
+func (p *ClientProxy) UndropResponses() {
+	p.UnblackholeRx()
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+// streamRecord is a single line of report stream, holding one operation of a client.
+type streamRecord struct {
+	ClientID int
+	KeyValue *porcupine.Operation  `json:",omitempty"`
+	Watch    *model.WatchOperation `json:",omitempty"`
+}
+
+// WriteReportStream closes the sets and writes operations of their clients as
+// newline delimited JSON, one operation per line, so reports can be persisted
+// without merging them in memory. Like in CollectReports, sets need to share base time.
+// Only KeyValue and Watch operations are written, same as in report.PersistClientReports.
+func WriteReportStream(w io.Writer, sets ...*ClientSet) error {
+	encoder := json.NewEncoder(w)
+	for _, cs := range sets {
+		if !cs.baseTime.Equal(sets[0].baseTime) {
+			return fmt.Errorf("client sets have different base time, %s != %s", cs.baseTime, sets[0].baseTime)
+		}
+		cs.Close()
+		cs.mux.Lock()
+		clients := cs.clients
+		cs.mux.Unlock()
+		for _, c := range clients {
+			if err := encodeReport(encoder, c.Report()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteReports writes operations of reports in the same format as WriteReportStream,
+// for reports already collected, for example returned by traffic.
+func WriteReports(w io.Writer, reports ...report.ClientReport) error {
+	encoder := json.NewEncoder(w)
+	for _, r := range reports {
+		if err := encodeReport(encoder, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeReport(encoder *json.Encoder, r report.ClientReport) error {
+	for i := range r.KeyValue {
+		if err := encoder.Encode(streamRecord{ClientID: r.ClientID, KeyValue: &r.KeyValue[i]}); err != nil {
+			return fmt.Errorf("failed to encode operation, err: %w", err)
+		}
+	}
+	for i := range r.Watch {
+		if err := encoder.Encode(streamRecord{ClientID: r.ClientID, Watch: &r.Watch[i]}); err != nil {
+			return fmt.Errorf("failed to encode watch operation, err: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadReportStream reads reports written by WriteReportStream, ordered by client ID.
+// Last line without newline is an incomplete write of crashed run and is skipped.
+func ReadReportStream(r io.Reader) ([]report.ClientReport, error) {
+	reports := map[int]*report.ClientReport{}
+	reader := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var record struct {
+			ClientID int
+			KeyValue *struct {
+				ClientID int
+				Input    model.EtcdRequest
+				Call     int64
+				Output   model.MaybeEtcdResponse
+				Return   int64
+			}
+			Watch *model.WatchOperation
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("failed to decode line %d of report stream, err: %w", lineNumber, err)
+		}
+		rep, ok := reports[record.ClientID]
+		if !ok {
+			rep = &report.ClientReport{ClientID: record.ClientID}
+			reports[record.ClientID] = rep
+		}
+		if op := record.KeyValue; op != nil {
+			rep.KeyValue = append(rep.KeyValue, porcupine.Operation{
+				ClientId: op.ClientID,
+				Input:    op.Input,
+				Call:     op.Call,
+				Output:   op.Output,
+				Return:   op.Return,
+			})
+		}
+		if record.Watch != nil {
+			rep.Watch = append(rep.Watch, *record.Watch)
+		}
+	}
+	result := make([]report.ClientReport, 0, len(reports))
+	for _, rep := range reports {
+		result = append(result, *rep)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClientID < result[j].ClientID
+	})
+	return result, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+)
+
+func TestReportStream(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	sets := []*ClientSet{NewSet(ids, baseTime), NewSet(ids, baseTime)}
+	kvClient, err := sets[0].NewClient(clus.Endpoints())
+	require.NoError(t, err)
+	watchClient, err := sets[1].NewClient(clus.Endpoints())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = kvClient.Put(ctx, "key", "value")
+	require.NoError(t, err)
+	_, _, err = kvClient.Get(ctx, "key", 0)
+	require.NoError(t, err)
+	for resp := range watchClient.Watch(ctx, "key", 1, false, false, false) {
+		if len(resp.Events) > 0 {
+			cancel()
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteReportStream(buf, sets...))
+	want := CollectReports(sets...)
+	got, err := ReadReportStream(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(t, want[i].ClientID, got[i].ClientID)
+		assert.ElementsMatch(t, want[i].KeyValue, got[i].KeyValue)
+		assert.ElementsMatch(t, want[i].Watch, got[i].Watch)
+	}
+	reportsBuf := &bytes.Buffer{}
+	require.NoError(t, WriteReports(reportsBuf, want...))
+	assert.Equal(t, buf.String(), reportsBuf.String())
+
+	// Partial write of the last line is skipped.
+	lines := strings.SplitAfter(buf.String(), "\n")
+	require.Len(t, lines, 4)
+	partial := strings.Join(lines[:2], "") + lines[2][:len(lines[2])/2]
+	got, err = ReadReportStream(strings.NewReader(partial))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Len(t, got[0].KeyValue, 2)
+
+	// Corrupted complete line fails.
+	_, err = ReadReportStream(strings.NewReader(lines[0] + "{\n"))
+	require.ErrorContains(t, err, "line 2")
+
+	require.Error(t, WriteReportStream(&bytes.Buffer{}, NewSet(ids, baseTime), NewSet(ids, time.Now())))
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+)
+
+// MeasureThroughput calls opFn for given duration and returns number of successful
+// operations per second. Rate is calculated from call and return times of operations
+// recorded during the window, so it's not affected by time spent outside of requests.
+func MeasureThroughput(ctx context.Context, c *RecordingClient, duration time.Duration, opFn func(ctx context.Context, c *RecordingClient) error) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	startIndex := len(c.Report().KeyValue)
+	for ctx.Err() == nil {
+		// Errors are recorded, failed operations are excluded from the rate.
+		_ = opFn(ctx, c)
+	}
+
+	var succeeded int64
+	var first, last int64
+	for _, op := range c.Report().KeyValue[startIndex:] {
+		if op.Output.(model.MaybeEtcdResponse).Error != "" {
+			continue
+		}
+		if succeeded == 0 || op.Call < first {
+			first = op.Call
+		}
+		last = max(last, op.Return)
+		succeeded++
+	}
+	if succeeded == 0 || last <= first {
+		return 0, errors.New("no successful operations recorded")
+	}
+	return float64(succeeded) / time.Duration(last-first).Seconds(), nil
+}
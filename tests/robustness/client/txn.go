@@ -0,0 +1,107 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+)
+
+// AssertFailedTxnNoEffect calls txnFn that is expected to issue a transaction with failing
+// conditions and empty else branch, and asserts that none of the keys involved in recorded
+// transactions changed. Keys are read at revision from before the transaction and after it.
+// Retried transactions are included, as all of them are recorded.
+func AssertFailedTxnNoEffect(ctx context.Context, t *testing.T, c *RecordingClient, txnFn func(ctx context.Context, c *RecordingClient) (*clientv3.TxnResponse, error)) {
+	status, err := c.Status(ctx, c.Endpoints()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	revision := status.Header.Revision
+	recorded := len(c.Report().KeyValue)
+
+	resp, err := txnFn(ctx, c)
+	if err != nil {
+		t.Fatalf("Failed to execute transaction, err: %s", err)
+	}
+	if resp.Succeeded {
+		t.Fatal("Expected transaction conditions to fail")
+	}
+
+	for _, key := range txnKeys(c.Report().KeyValue[recorded:]) {
+		before, err := c.Range(ctx, key, "", revision, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		after, err := c.Range(ctx, key, "", 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equalKeyValues(before, after) {
+			t.Errorf("Failed transaction changed key %q, before (rev: %d): %v, after (rev: %d): %v", key, revision, before.Kvs, after.Header.Revision, after.Kvs)
+		}
+	}
+}
+
+// txnKeys returns keys used in conditions and operations of recorded transactions.
+func txnKeys(operations []porcupine.Operation) (keys []string) {
+	seen := map[string]struct{}{}
+	add := func(key string) {
+		if _, found := seen[key]; found || key == "" {
+			return
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	var addTxn func(txn *model.TxnRequest)
+	addTxn = func(txn *model.TxnRequest) {
+		for _, cond := range txn.Conditions {
+			add(cond.Key)
+		}
+		for _, etcdOp := range append(txn.OperationsOnSuccess, txn.OperationsOnFailure...) {
+			if etcdOp.Type == model.TxnOperation {
+				addTxn(etcdOp.Txn)
+				continue
+			}
+			add(etcdOp.Range.Start)
+			add(etcdOp.Put.Key)
+			add(etcdOp.Delete.Key)
+		}
+	}
+	for _, op := range operations {
+		request := op.Input.(model.EtcdRequest)
+		if request.Type != model.Txn {
+			continue
+		}
+		addTxn(request.Txn)
+	}
+	return keys
+}
+
+func equalKeyValues(r1, r2 *clientv3.GetResponse) bool {
+	if len(r1.Kvs) != len(r2.Kvs) {
+		return false
+	}
+	for i := range r1.Kvs {
+		if string(r1.Kvs[i].Key) != string(r2.Kvs[i].Key) || string(r1.Kvs[i].Value) != string(r2.Kvs[i].Value) || r1.Kvs[i].ModRevision != r2.Kvs[i].ModRevision {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+	"go.etcd.io/etcd/tests/v3/robustness/validate"
+)
+
+func TestMultiKeyWatchOrder(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := client.NewRecordingClient([]string{clus.Members[0].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	resp, err := c.Put(ctx, "start", "0")
+	require.NoError(t, err)
+	watch := c.Watch(ctx, "key", resp.Header.Revision+1, true, false, false)
+	expectEvents := 0
+	for i := 0; i < 10; i++ {
+		_, err = c.Put(ctx, fmt.Sprintf("key%d", i%3), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+		expectEvents++
+	}
+	// Transaction modifies multiple keys in a single revision.
+	_, err = c.Txn(ctx, nil, []clientv3.Op{
+		clientv3.OpPut("key0", "txn"),
+		clientv3.OpPut("key1", "txn"),
+		clientv3.OpPut("key2", "txn"),
+	}, nil)
+	require.NoError(t, err)
+	expectEvents += 3
+
+	events := 0
+	for resp := range watch {
+		events += len(resp.Events)
+		if events >= expectEvents {
+			break
+		}
+	}
+	watches := c.Report().Watch
+	require.Len(t, watches, 1)
+	require.NoError(t, validate.ValidateMultiKeyWatchOrder(watches[0]))
+}
+
+func TestWatchProgressNotifyLiveness(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, WatchProgressNotifyInterval: 200 * time.Millisecond})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := client.NewRecordingClient([]string{clus.Members[0].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	watch := c.Watch(ctx, "key", 0, true, true, false)
+	progressNotifies := 0
+	for resp := range watch {
+		if resp.IsProgressNotify() {
+			progressNotifies++
+		}
+		if progressNotifies >= 5 {
+			break
+		}
+	}
+	watches := c.Report().Watch
+	require.Len(t, watches, 1)
+	intervals := watches[0].ProgressNotifyIntervals()
+	require.Len(t, intervals, 4)
+	require.NoError(t, validate.AssertWatchLiveness(watches[0], time.Second))
+}
+
+func TestWatchWithFragmentReassembled(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxRequestBytes: 1.5 * 1024 * 1024})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := client.NewRecordingClient([]string{clus.Members[0].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	resp, err := c.Put(ctx, "start", "0")
+	require.NoError(t, err)
+	startRevision := resp.Header.Revision + 1
+	// Transactions modifying multiple keys, combined exceeding request limit, so catching up watch response is fragmented.
+	for i := 0; i < 5; i++ {
+		// Values need to be unique for watch validation.
+		value := fmt.Sprintf("%d-%s", i, strings.Repeat("a", 400*1024))
+		_, err = c.Txn(ctx, nil, []clientv3.Op{
+			clientv3.OpPut("key0", value),
+			clientv3.OpPut("key1", value),
+			clientv3.OpPut("key2", value),
+		}, nil)
+		require.NoError(t, err)
+	}
+
+	watch := c.WatchWithFragment(ctx, "key", startRevision, true)
+	events := 0
+	for resp := range watch {
+		require.NoError(t, resp.Err())
+		events += len(resp.Events)
+		if events >= 15 {
+			break
+		}
+	}
+	require.Equal(t, 15, events)
+	r := c.Report()
+	require.Len(t, r.Watch, 1)
+	require.True(t, r.Watch[0].Request.WithFragment)
+	// Single client sends requests sequentially, so they are persisted in the order they were sent.
+	persistedRequests := []model.EtcdRequest{}
+	for _, op := range r.KeyValue {
+		persistedRequests = append(persistedRequests, op.Input.(model.EtcdRequest))
+	}
+	validate.ValidateAndReturnVisualize(t, zaptest.NewLogger(t), validate.Config{}, []report.ClientReport{r}, persistedRequests, time.Minute)
+}
+
+func TestConcurrentWatchesRecorded(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := client.NewRecordingClient([]string{clus.Members[0].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	putCtx, putCancel := context.WithCancel(ctx)
+	defer putCancel()
+	putDone := make(chan struct{})
+	go func() {
+		defer close(putDone)
+		for i := 0; putCtx.Err() == nil; i++ {
+			c.Put(putCtx, "key", fmt.Sprintf("%d", i))
+		}
+	}()
+	// Watches are opened while others are recording responses, growing the list of watch operations.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			watchCtx, watchCancel := context.WithCancel(ctx)
+			defer watchCancel()
+			events := 0
+			for resp := range c.Watch(watchCtx, "key", 0, false, false, false) {
+				events += len(resp.Events)
+				if events >= 5 {
+					watchCancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	putCancel()
+	<-putDone
+	watches := c.Report().Watch
+	require.Len(t, watches, 20)
+	for _, watch := range watches {
+		require.NotEmpty(t, watch.Responses)
+		cancel, canceled := watch.CancelResponse()
+		require.True(t, canceled)
+		require.NotEmpty(t, cancel.CancelReason)
+	}
+}
+
+func TestWatchRequestProgress(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := client.NewRecordingClient([]string{clus.Members[0].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	resp, err := c.Put(ctx, "key", "0")
+	require.NoError(t, err)
+	watch := c.Watch(ctx, "key", resp.Header.Revision, false, false, false)
+	// Wait for the watch to start before requesting progress.
+	<-watch
+	require.NoError(t, c.RequestProgress(ctx))
+	for resp := range watch {
+		if resp.IsProgressNotify() {
+			break
+		}
+	}
+	r := c.Report()
+	require.Len(t, r.ProgressRequests, 1)
+	require.Len(t, r.Watch, 1)
+	latency, ok := r.Watch[0].ProgressNotifyLatency(r.ProgressRequests[0])
+	require.True(t, ok)
+	require.Positive(t, latency)
+	require.NoError(t, validate.ValidateProgressNotifyLatency(r, time.Second))
+}
+
+func TestWatchWithFilterPut(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := client.NewRecordingClient([]string{clus.Members[0].GRPCURL}, identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	resp, err := c.Put(ctx, "key", "0")
+	require.NoError(t, err)
+	watch := c.WatchWithFilter(ctx, "key", resp.Header.Revision+1, false, true, true, false)
+	for i := 1; i <= 3; i++ {
+		_, err = c.Put(ctx, "key", fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+		_, err = c.Delete(ctx, "key")
+		require.NoError(t, err)
+	}
+
+	events := 0
+	for resp := range watch {
+		events += len(resp.Events)
+		if events >= 3 {
+			break
+		}
+	}
+	watches := c.Report().Watch
+	require.Len(t, watches, 1)
+	require.True(t, watches[0].Request.FilterPut)
+	for i, resp := range watches[0].Responses {
+		for _, event := range resp.Events {
+			require.Equal(t, model.DeleteOperation, event.Type)
+			require.NotNil(t, event.PrevValue, "delete event should have prevKV, response: %d", i)
+			require.Equal(t, event.Revision-1, event.PrevValue.ModRevision)
+		}
+	}
+	require.NoError(t, validate.ValidateExactlyOnce(watches[0]))
+	require.Equal(t, 3, events)
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+)
+
+// watchEstablishInterval is how often progress is requested while waiting for watchers to establish.
+const watchEstablishInterval = 10 * time.Millisecond
+
+// RunConcurrentWatchers opens n watches on key starting from the current revision and
+// waits until all of them are established, so a subsequent write reaches all of them.
+// Watch is established once it receives progress notification, which is repeatedly
+// requested until all watchers received one, so channels can still deliver some
+// progress notifications afterwards. Returns watch channels and function
+// returning operations recorded for the watchers, to be called once they are done.
+func RunConcurrentWatchers(ctx context.Context, c *RecordingClient, key string, n int) ([]clientv3.WatchChan, func() []model.WatchOperation, error) {
+	c.watchMux.Lock()
+	first := len(c.watchOperations)
+	c.watchMux.Unlock()
+
+	watches := make([]clientv3.WatchChan, n)
+	var wg sync.WaitGroup
+	for i := range watches {
+		watches[i] = c.Watch(ctx, key, 0, false, false, false)
+		wg.Add(1)
+		go func(watch clientv3.WatchChan) {
+			defer wg.Done()
+			for resp := range watch {
+				if resp.IsProgressNotify() {
+					return
+				}
+			}
+		}(watches[i])
+	}
+	established := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(established)
+	}()
+
+	ticker := time.NewTicker(watchEstablishInterval)
+	defer ticker.Stop()
+	for {
+		if err := c.RequestProgress(ctx); err != nil {
+			return nil, nil, err
+		}
+		select {
+		case <-established:
+			return watches, func() []model.WatchOperation {
+				c.watchMux.Lock()
+				defer c.watchMux.Unlock()
+				return copyWatchOperations(c.watchOperations[first : first+n])
+			}, nil
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+)
+
+func TestClientProxyBlackholeIsolatesClient(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithClientProxy(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	isolated, err := client.NewRecordingClient(clus.Procs[0].EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer isolated.Close()
+	healthy, err := client.NewRecordingClient(clus.Procs[1].EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer healthy.Close()
+	_, err = isolated.Put(ctx, "key", "0")
+	require.NoError(t, err)
+
+	clientProxy := clus.Procs[0].ClientProxy()
+	clientProxy.BlackholeTx()
+	clientProxy.BlackholeRx()
+	putCtx, putCancel := context.WithTimeout(ctx, time.Second)
+	_, err = isolated.Put(putCtx, "key", "1")
+	putCancel()
+	require.Error(t, err)
+	// Cluster stays healthy, including the member client lost connectivity to.
+	_, err = healthy.Put(ctx, "key", "2")
+	require.NoError(t, err)
+	clientProxy.UnblackholeTx()
+	clientProxy.UnblackholeRx()
+
+	// Blackhole dropped part of the connection stream, so a new connection is needed.
+	reconnected, err := client.NewRecordingClient(clus.Procs[0].EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer reconnected.Close()
+	_, err = reconnected.Put(ctx, "key", "3")
+	require.NoError(t, err)
+	revision := waitForRevisionConvergence(ctx, t, reconnected, clus)
+	require.NoError(t, CheckHashKV(ctx, clus, revision))
+}
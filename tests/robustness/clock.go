@@ -0,0 +1,120 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+const (
+	leaseClockOffsetFailpoint = "leaseClockOffset"
+	clockJumpObservePeriod    = 3 * time.Second
+)
+
+// ClockJumpState is the state of a member observed before and after a clock jump.
+type ClockJumpState struct {
+	Leader   uint64
+	RaftTerm uint64
+	Revision int64
+	// LeaseTTL is the remaining TTL of the lease granted on the member, -1 if it expired.
+	LeaseTTL int64
+}
+
+// AssertToleratesClockJump shifts the clock used by lessor of the member by jump, using the
+// leaseClockOffset failpoint, and asserts that a lease granted before the jump doesn't expire
+// before its TTL and that leadership stays stable while the jump is applied.
+// The lease outlives the observation period by its length, so only jumps that don't exceed it
+// are expected to be tolerated. Returns state of the member before and after the jump.
+func AssertToleratesClockJump(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, memberIdx int, jump time.Duration) (before, after ClockJumpState) {
+	member := clus.Procs[memberIdx]
+	if member.Failpoints() == nil || !member.Failpoints().Available(leaseClockOffsetFailpoint) {
+		t.Fatalf("Member %q doesn't have %s failpoint", member.Config().Name, leaseClockOffsetFailpoint)
+	}
+	c, err := clientv3.New(clientv3.Config{
+		Endpoints:   member.EndpointsGRPC(),
+		Logger:      zap.NewNop(),
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed creating client: %v", err)
+	}
+	defer c.Close()
+
+	lease, err := c.Grant(ctx, int64(2*clockJumpObservePeriod/time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Revoke(ctx, lease.ID)
+	key := fmt.Sprintf("clock-jump-%x", lease.ID)
+	if _, err = c.Put(ctx, key, "value", clientv3.WithLease(lease.ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	before = memberClockJumpState(ctx, t, c, member, lease.ID)
+	t.Logf("Member %q state before clock jump of %s: %+v", member.Config().Name, jump, before)
+	if err = member.Failpoints().SetupHTTP(ctx, leaseClockOffsetFailpoint, fmt.Sprintf("return(%d)", jump.Milliseconds())); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	case <-time.After(clockJumpObservePeriod):
+	}
+	after = memberClockJumpState(ctx, t, c, member, lease.ID)
+	t.Logf("Member %q state after clock jump of %s: %+v", member.Config().Name, jump, after)
+	if err = member.Failpoints().DeactivateHTTP(ctx, leaseClockOffsetFailpoint); err != nil {
+		t.Fatal(err)
+	}
+
+	if after.LeaseTTL <= 0 {
+		t.Errorf("Lease %x expired early after clock jump of %s", lease.ID, jump)
+	}
+	resp, err := c.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 {
+		t.Errorf("Key %q attached to lease %x was deleted after clock jump of %s", key, lease.ID, jump)
+	}
+	if after.Leader != before.Leader || after.RaftTerm != before.RaftTerm {
+		t.Errorf("Leadership changed after clock jump of %s, leader: %x -> %x, term: %d -> %d", jump, before.Leader, after.Leader, before.RaftTerm, after.RaftTerm)
+	}
+	return before, after
+}
+
+func memberClockJumpState(ctx context.Context, t *testing.T, c *clientv3.Client, member e2e.EtcdProcess, leaseID clientv3.LeaseID) ClockJumpState {
+	status, err := c.Status(ctx, member.EndpointsGRPC()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	ttl, err := c.TimeToLive(ctx, leaseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ClockJumpState{
+		Leader:   status.Leader,
+		RaftTerm: status.RaftTerm,
+		Revision: status.Header.Revision,
+		LeaseTTL: ttl.TTL,
+	}
+}
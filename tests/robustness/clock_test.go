@@ -0,0 +1,43 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestToleratesForwardClockJump(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	if !clus.Procs[leaderIdx].Failpoints().Available(leaseClockOffsetFailpoint) {
+		t.Skipf("%s failpoint is not available", leaseClockOffsetFailpoint)
+	}
+	// Leader is the primary lessor responsible for expiring leases.
+	before, after := AssertToleratesClockJump(ctx, t, clus, leaderIdx, time.Second)
+	require.Equal(t, before.Leader, after.Leader)
+	require.Positive(t, after.LeaseTTL)
+}
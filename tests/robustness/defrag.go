@@ -0,0 +1,121 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+const (
+	// dbSizeNoiseRatio is the relative change of DbSize tolerated as measurement noise.
+	dbSizeNoiseRatio = 0.1
+	// dbSizeMinReclaimRatio is the minimal relative drop of DbSize expected after defragmentation.
+	dbSizeMinReclaimRatio = 0.3
+)
+
+// AssertSpaceReclaimedAfterDefrag compacts the cluster at the current revision and defragments
+// all members, asserting that compaction alone doesn't change size of the database file of any member,
+// while defragmentation afterwards does. Expects the keyspace to contain enough overwritten or
+// deleted data for the drop to exceed measurement noise. Returns reports of the recorded operations.
+func AssertSpaceReclaimedAfterDefrag(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster) []report.ClientReport {
+	lg := zaptest.NewLogger(t)
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	clients := make([]*client.RecordingClient, len(clus.Procs))
+	for i, member := range clus.Procs {
+		c, err := client.NewRecordingClient(member.EndpointsGRPC(), ids, baseTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		clients[i] = c
+	}
+
+	before := make([]int64, len(clients))
+	var revision int64
+	for i, c := range clients {
+		status, err := c.Status(ctx, c.Endpoints()[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		before[i] = status.DbSize
+		revision = max(revision, status.Header.Revision)
+	}
+	if _, err := clients[0].Compact(ctx, revision, false); err != nil {
+		t.Fatalf("Failed to compact, err: %s", err)
+	}
+
+	for i, c := range clients {
+		member := clus.Procs[i].Config().Name
+		compacted := waitForCompactionFreedPages(ctx, t, c, before[i])
+		if float64(compacted) < float64(before[i])*(1-dbSizeNoiseRatio) || float64(compacted) > float64(before[i])*(1+dbSizeNoiseRatio) {
+			t.Errorf("Member %q DbSize changed from %d to %d after compaction, before defragmentation", member, before[i], compacted)
+		}
+		if _, err := c.Defragment(ctx); err != nil {
+			t.Fatalf("Failed to defragment member %q, err: %s", member, err)
+		}
+		status, err := c.Status(ctx, c.Endpoints()[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defragmented := status.DbSize
+		lg.Info("Member DbSize after compaction and defragmentation",
+			zap.String("member", member),
+			zap.Int64("compact-revision", revision),
+			zap.Int64("before", before[i]),
+			zap.Int64("compacted", compacted),
+			zap.Int64("defragmented", defragmented),
+		)
+		if float64(defragmented) > float64(compacted)*(1-dbSizeMinReclaimRatio) {
+			t.Errorf("Member %q DbSize didn't drop enough after defragmentation, compacted: %d, defragmented: %d, expected drop: %.0f%%", member, compacted, defragmented, dbSizeMinReclaimRatio*100)
+		}
+	}
+	reports := make([]report.ClientReport, len(clients))
+	for i, c := range clients {
+		reports[i] = c.Report()
+	}
+	return reports
+}
+
+// waitForCompactionFreedPages waits until compaction, which is applied asynchronously, frees
+// database pages of the member and returns its DbSize at that point.
+func waitForCompactionFreedPages(ctx context.Context, t *testing.T, c *client.RecordingClient, dbSize int64) int64 {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	for {
+		status, err := c.Status(ctx, c.Endpoints()[0])
+		if err != nil {
+			t.Fatalf("Failed to get status, err: %s", err)
+		}
+		if float64(status.DbSizeInUse) <= float64(dbSize)*(1-dbSizeMinReclaimRatio) {
+			return status.DbSize
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Compaction didn't free database pages, db size: %d, in use: %d", status.DbSize, status.DbSizeInUse)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestSpaceReclaimedAfterDefrag(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	c, err := clientv3.New(clientv3.Config{Endpoints: clus.EndpointsGRPC(), Logger: zap.NewNop()})
+	require.NoError(t, err)
+	defer c.Close()
+	value := strings.Repeat("a", 10*1024)
+	for i := 0; i < 500; i++ {
+		_, err = c.Put(ctx, fmt.Sprintf("key%d", i), value)
+		require.NoError(t, err)
+	}
+	_, err = c.Delete(ctx, "key", clientv3.WithPrefix())
+	require.NoError(t, err)
+
+	AssertSpaceReclaimedAfterDefrag(ctx, t, clus)
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+)
+
+func TestDegradingLeaderLinkReelects(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithIsPeerTLS(true), e2e.WithPeerProxy(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	leader := clus.Procs[leaderIdx]
+	follower := clus.Procs[(leaderIdx+1)%len(clus.Procs)]
+	c, err := clientv3.New(clientv3.Config{
+		Endpoints: follower.EndpointsGRPC(),
+		Logger:    zap.NewNop(),
+	})
+	require.NoError(t, err)
+	defer c.Close()
+	resp, err := c.Status(ctx, c.Endpoints()[0])
+	require.NoError(t, err)
+	oldLeader := resp.Leader
+
+	// Loss on leader link increases until followers stop receiving heartbeats.
+	cancelRamp := leader.PeerProxy().RampDropRate(0, 1, 5*time.Second)
+	defer cancelRamp()
+	for resp.Leader == oldLeader || resp.Leader == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Cluster didn't re-elect leader as leader link degraded, err: %s", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+		if r, err := c.Status(ctx, c.Endpoints()[0]); err == nil {
+			resp = r
+		}
+	}
+	t.Logf("Leader changed from %x to %x in term %d", oldLeader, resp.Leader, resp.RaftTerm)
+
+	cancelRamp()
+	clus.WaitLeader(t)
+	rc, err := client.NewRecordingClient(clus.EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer rc.Close()
+	_, err = rc.Put(ctx, "key", "value")
+	require.NoError(t, err)
+	revision := waitForRevisionConvergence(ctx, t, rc, clus)
+	require.NoError(t, CheckHashKV(ctx, clus, revision))
+}
@@ -129,6 +129,7 @@ func (f memberReplace) Inject(ctx context.Context, t *testing.T, lg *zap.Logger,
 			break
 		}
 	}
+	AssertMembershipConverged(ctx, t, clus)
 	return nil, nil
 }
 
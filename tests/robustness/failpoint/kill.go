@@ -39,16 +39,9 @@ type killFailpoint struct{}
 func (f killFailpoint) Inject(ctx context.Context, t *testing.T, lg *zap.Logger, clus *e2e.EtcdProcessCluster, baseTime time.Time, ids identity.Provider) ([]report.ClientReport, error) {
 	member := clus.Procs[rand.Int()%len(clus.Procs)]
 
-	for member.IsRunning() {
-		err := member.Kill()
-		if err != nil {
-			lg.Info("Sending kill signal failed", zap.Error(err))
-		}
-		err = member.Wait(ctx)
-		if err != nil && !strings.Contains(err.Error(), "unexpected exit code") {
-			lg.Info("Failed to kill the process", zap.Error(err))
-			return nil, fmt.Errorf("failed to kill the process within %s, err: %w", triggerTimeout, err)
-		}
+	err := KillAndWait(ctx, lg, member)
+	if err != nil {
+		return nil, err
 	}
 	if lazyfs := member.LazyFS(); lazyfs != nil {
 		lg.Info("Removing data that was not fsynced")
@@ -57,13 +50,29 @@ func (f killFailpoint) Inject(ctx context.Context, t *testing.T, lg *zap.Logger,
 			return nil, err
 		}
 	}
-	err := member.Start(ctx)
+	err = member.Start(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return nil, nil
 }
 
+// KillAndWait sends kill signal to the member and waits until its process exits.
+func KillAndWait(ctx context.Context, lg *zap.Logger, member e2e.EtcdProcess) error {
+	for member.IsRunning() {
+		err := member.Kill()
+		if err != nil {
+			lg.Info("Sending kill signal failed", zap.Error(err))
+		}
+		err = member.Wait(ctx)
+		if err != nil && !strings.Contains(err.Error(), "unexpected exit code") {
+			lg.Info("Failed to kill the process", zap.Error(err))
+			return fmt.Errorf("failed to kill the process within %s, err: %w", triggerTimeout, err)
+		}
+	}
+	return nil
+}
+
 func (f killFailpoint) Name() string {
 	return "Kill"
 }
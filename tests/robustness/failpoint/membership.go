@@ -0,0 +1,113 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failpoint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+)
+
+const membershipConvergenceTimeout = 30 * time.Second
+
+// AssertMembershipConverged waits until all running members report the same member list
+// (IDs, peer URLs and learner flags) in their local view. Fails the test listing view
+// of each member if they don't converge in time.
+func AssertMembershipConverged(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster) {
+	ctx, cancel := context.WithTimeout(ctx, membershipConvergenceTimeout)
+	defer cancel()
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	var views map[string]string
+	for {
+		views = map[string]string{}
+		for _, member := range clus.Procs {
+			if !member.IsRunning() {
+				continue
+			}
+			view, err := memberListView(ctx, member, ids, baseTime)
+			if err != nil {
+				view = fmt.Sprintf("error: %s", err)
+			}
+			views[member.Config().Name] = view
+		}
+		if converged(views) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Errorf("Membership didn't converge: %s", describeViews(views))
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// memberListView returns member list as seen locally by the member.
+func memberListView(ctx context.Context, member e2e.EtcdProcess, ids identity.Provider, baseTime time.Time) (string, error) {
+	cc, err := client.NewRecordingClient(member.EndpointsGRPC(), ids, baseTime)
+	if err != nil {
+		return "", err
+	}
+	defer cc.Close()
+	resp, err := cc.MemberList(ctx, clientv3.WithSerializable())
+	if err != nil {
+		return "", err
+	}
+	members := make([]string, len(resp.Members))
+	for i, m := range resp.Members {
+		peerURLs := append([]string{}, m.PeerURLs...)
+		sort.Strings(peerURLs)
+		members[i] = fmt.Sprintf("%x(peers: %s, learner: %t)", m.ID, strings.Join(peerURLs, ","), m.IsLearner)
+	}
+	sort.Strings(members)
+	return strings.Join(members, ", "), nil
+}
+
+func converged(views map[string]string) bool {
+	var first *string
+	for _, view := range views {
+		if strings.HasPrefix(view, "error: ") {
+			return false
+		}
+		if first == nil {
+			first = &view
+		} else if *first != view {
+			return false
+		}
+	}
+	return true
+}
+
+func describeViews(views map[string]string) string {
+	names := make([]string, 0, len(views))
+	for name := range views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	descriptions := make([]string, len(names))
+	for i, name := range names {
+		descriptions[i] = fmt.Sprintf("%s: [%s]", name, views[name])
+	}
+	return strings.Join(descriptions, "; ")
+}
@@ -77,7 +77,7 @@ func (t triggerCompact) Trigger(ctx context.Context, _ *testing.T, member e2e.Et
 		}
 		time.Sleep(50 * time.Millisecond)
 	}
-	_, err = cc.Compact(ctx, rev)
+	_, err = cc.Compact(ctx, rev, false)
 	if err != nil && !connectionError(err) {
 		return nil, fmt.Errorf("failed to compact: %w", err)
 	}
@@ -0,0 +1,101 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+)
+
+func TestGRPCProxyWatchMatchesDirect(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGRPCProxy(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	direct, err := client.NewRecordingClient(clus.EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer direct.Close()
+	proxied, err := client.NewRecordingClient(clus.GRPCProxyEndpoints(), ids, baseTime)
+	require.NoError(t, err)
+	defer proxied.Close()
+
+	resp, err := direct.Put(ctx, "start", "0")
+	require.NoError(t, err)
+	startRevision := resp.Header.Revision + 1
+	// Two watches on the same prefix through proxy are coalesced into one.
+	done := []<-chan struct{}{
+		waitForWatchEvents(ctx, direct, startRevision, 20),
+		waitForWatchEvents(ctx, proxied, startRevision, 20),
+		waitForWatchEvents(ctx, proxied, startRevision, 20),
+	}
+	for i := 0; i < 20; i++ {
+		c := direct
+		if i%2 == 0 {
+			c = proxied
+		}
+		_, err = c.Put(ctx, fmt.Sprintf("key%d", i%5), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	for _, d := range done {
+		<-d
+	}
+
+	directWatches := direct.Report().Watch
+	require.Len(t, directWatches, 1)
+	expect := watchEvents(directWatches[0])
+	require.Len(t, expect, 20)
+	proxiedWatches := proxied.Report().Watch
+	require.Len(t, proxiedWatches, 2)
+	for _, op := range proxiedWatches {
+		require.Equal(t, expect, watchEvents(op))
+	}
+}
+
+func waitForWatchEvents(ctx context.Context, c *client.RecordingClient, revision int64, count int) <-chan struct{} {
+	watch := c.Watch(ctx, "key", revision, true, false, false)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		events := 0
+		for resp := range watch {
+			events += len(resp.Events)
+			if events >= count {
+				return
+			}
+		}
+	}()
+	return done
+}
+
+func watchEvents(op model.WatchOperation) (events []model.WatchEvent) {
+	for _, resp := range op.Responses {
+		events = append(events, resp.Events...)
+	}
+	return events
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+// CheckHashKV compares hash of key-value store at given revision across all members of the cluster.
+// Returns error listing hashes of all members if any of them differs.
+// Requests failing with transient errors are retried with DefaultHashKVRetry.
+func CheckHashKV(ctx context.Context, clus *e2e.EtcdProcessCluster, rev int64) error {
+	return CheckHashKVWithRetry(ctx, clus, rev, DefaultHashKVRetry)
+}
+
+// CheckHashKVWithRetry works like CheckHashKV, retrying failed HashKV requests as configured by retry.
+func CheckHashKVWithRetry(ctx context.Context, clus *e2e.EtcdProcessCluster, rev int64, retry HashKVRetry) error {
+	return checkHashKVRange(ctx, clus, rev, rev, 1, retry)
+}
+
+// CheckHashKVAgainstReference compares hash of key-value store at given revision on every member
+// with expectedHash, for example computed by model.HashKV from persisted requests. Unlike CheckHashKV,
+// detects also corruption affecting all members the same way.
+func CheckHashKVAgainstReference(ctx context.Context, clus *e2e.EtcdProcessCluster, rev int64, expectedHash uint32) error {
+	hashes := make([]memberHashKV, 0, len(clus.Procs))
+	var mismatched []string
+	for _, member := range clus.Procs {
+		hash, err := retryHashKV(ctx, DefaultHashKVRetry, func() (memberHashKV, error) {
+			return memberHashKVAt(ctx, member, rev)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get hashKV from member %q: %w", member.Config().Name, err)
+		}
+		hashes = append(hashes, hash)
+		if hash.Compacted || hash.Hash != expectedHash {
+			mismatched = append(mismatched, fmt.Sprintf("%q", hash.Name))
+		}
+	}
+	if len(mismatched) != 0 {
+		return fmt.Errorf("hashKV at revision %d of members %s differs from reference hash %d: %s", rev, strings.Join(mismatched, ", "), expectedHash, describeHashKVs(hashes))
+	}
+	return nil
+}
+
+// HashKVRetry configures retries of HashKV requests, which can fail for example due to leader change
+// or connection being reestablished after network failure. Hash mismatches are never retried.
+type HashKVRetry struct {
+	// MaxAttempts of HashKV request to a single member, including the first one.
+	MaxAttempts int
+	// Backoff before the first retry, doubled on each next one up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+var DefaultHashKVRetry = HashKVRetry{
+	MaxAttempts: 5,
+	Backoff:     100 * time.Millisecond,
+	MaxBackoff:  2 * time.Second,
+}
+
+// CheckHashKVRange compares hashes of key-value store across all members of the cluster at revisions
+// from fromRev to toRev, checking every step revisions. Last checkpoint is always toRev.
+// Revisions compacted on all members are skipped, however members compacted to different revisions are reported.
+func CheckHashKVRange(ctx context.Context, clus *e2e.EtcdProcessCluster, fromRev, toRev, step int64) error {
+	return checkHashKVRange(ctx, clus, fromRev, toRev, step, DefaultHashKVRetry)
+}
+
+func checkHashKVRange(ctx context.Context, clus *e2e.EtcdProcessCluster, fromRev, toRev, step int64, retry HashKVRetry) error {
+	if step <= 0 {
+		return fmt.Errorf("invalid hashKV step %d", step)
+	}
+	for _, rev := range hashKVCheckpoints(fromRev, toRev, step) {
+		hashes := make([]memberHashKV, 0, len(clus.Procs))
+		for _, member := range clus.Procs {
+			hash, err := retryHashKV(ctx, retry, func() (memberHashKV, error) {
+				return memberHashKVAt(ctx, member, rev)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get hashKV from member %q: %w", member.Config().Name, err)
+			}
+			hashes = append(hashes, hash)
+		}
+		if err := compareHashKVs(rev, hashes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashKVCheckpoints returns revisions from fromRev to toRev every step revisions, followed by toRev
+// if it's not one of them.
+func hashKVCheckpoints(fromRev, toRev, step int64) (revs []int64) {
+	for rev := fromRev; rev <= toRev; rev += step {
+		revs = append(revs, rev)
+	}
+	if len(revs) != 0 && revs[len(revs)-1] != toRev {
+		revs = append(revs, toRev)
+	}
+	return revs
+}
+
+// retryHashKV calls getHashKV until it succeeds, attempts are exhausted or ctx is done.
+func retryHashKV(ctx context.Context, retry HashKVRetry, getHashKV func() (memberHashKV, error)) (hash memberHashKV, err error) {
+	backoff := retry.Backoff
+	for attempt := 1; ; attempt++ {
+		hash, err = getHashKV()
+		if err == nil || attempt >= retry.MaxAttempts {
+			return hash, err
+		}
+		select {
+		case <-ctx.Done():
+			return hash, fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, retry.MaxBackoff)
+	}
+}
+
+func compareHashKVs(rev int64, hashes []memberHashKV) error {
+	ahead := hashes[0]
+	for _, hash := range hashes[1:] {
+		if ahead.Compacted {
+			break
+		}
+		if hash.Compacted || hash.CompactRevision > ahead.CompactRevision {
+			ahead = hash
+		}
+	}
+	for _, hash := range hashes {
+		if hash.Compacted != ahead.Compacted || hash.CompactRevision != ahead.CompactRevision {
+			return fmt.Errorf("member %q compacted ahead of member %q at revision %d: %s", ahead.Name, hash.Name, rev, describeHashKVs(hashes))
+		}
+	}
+	if ahead.Compacted {
+		return nil
+	}
+	if outliers := hashKVOutliers(hashes); len(outliers) != 0 {
+		return fmt.Errorf("hashKV mismatch at revision %d, members %s differ from majority: %s", rev, strings.Join(outliers, ", "), describeHashKVs(hashes))
+	}
+	return nil
+}
+
+// hashKVOutliers groups members by hash and returns names of members outside of the largest group.
+// On tie, group of the member listed first is treated as majority.
+func hashKVOutliers(hashes []memberHashKV) (outliers []string) {
+	groupSize := map[uint32]int{}
+	for _, hash := range hashes {
+		groupSize[hash.Hash]++
+	}
+	majority := hashes[0].Hash
+	for _, hash := range hashes {
+		if groupSize[hash.Hash] > groupSize[majority] {
+			majority = hash.Hash
+		}
+	}
+	for _, hash := range hashes {
+		if hash.Hash != majority {
+			outliers = append(outliers, fmt.Sprintf("%q", hash.Name))
+		}
+	}
+	return outliers
+}
+
+type memberHashKV struct {
+	Name            string
+	Endpoint        string
+	Hash            uint32
+	HashRevision    int64
+	CompactRevision int64
+	// Compacted is set if requested revision was compacted on member, so hash is unknown.
+	Compacted bool
+}
+
+func memberHashKVAt(ctx context.Context, member e2e.EtcdProcess, rev int64) (memberHashKV, error) {
+	c, err := clientv3.New(clientv3.Config{
+		Endpoints:   member.EndpointsGRPC(),
+		Logger:      zap.NewNop(),
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		return memberHashKV{}, err
+	}
+	defer c.Close()
+	resp, err := c.HashKV(ctx, member.EndpointsGRPC()[0], rev)
+	if errors.Is(err, rpctypes.ErrCompacted) {
+		return memberHashKV{Name: member.Config().Name, Endpoint: member.EndpointsGRPC()[0], Compacted: true}, nil
+	}
+	if err != nil {
+		return memberHashKV{}, err
+	}
+	return memberHashKV{
+		Name:            member.Config().Name,
+		Endpoint:        member.EndpointsGRPC()[0],
+		Hash:            resp.Hash,
+		HashRevision:    resp.HashRevision,
+		CompactRevision: resp.CompactRevision,
+	}, nil
+}
+
+func describeHashKVs(hashes []memberHashKV) string {
+	descriptions := make([]string, len(hashes))
+	for i, hash := range hashes {
+		if hash.Compacted {
+			descriptions[i] = fmt.Sprintf("%s(endpoint: %s, compacted)", hash.Name, hash.Endpoint)
+			continue
+		}
+		descriptions[i] = fmt.Sprintf("%s(endpoint: %s, hash: %d, hash-revision: %d, compact-revision: %d)", hash.Name, hash.Endpoint, hash.Hash, hash.HashRevision, hash.CompactRevision)
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/framework/config"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestCheckHashKVDetectsCorruption(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx := context.Background()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	corrupted := clus.Procs[1]
+	if !corrupted.Failpoints().Available("corruptHashKV") {
+		t.Skip("corruptHashKV failpoint is not available")
+	}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, clus.Etcdctl().Put(ctx, fmt.Sprintf("key%d", i), "value", config.PutOptions{}))
+	}
+	resp, err := clus.Etcdctl().Get(ctx, "key0", config.GetOptions{})
+	require.NoError(t, err)
+	rev := resp.Header.Revision
+	require.NoError(t, CheckHashKV(ctx, clus, rev))
+
+	require.NoError(t, corrupted.Failpoints().SetupHTTP(ctx, "corruptHashKV", "return"))
+	err = CheckHashKV(ctx, clus, rev)
+	require.ErrorContains(t, err, "hashKV mismatch")
+	require.ErrorContains(t, err, corrupted.Config().Name)
+
+	require.NoError(t, corrupted.Failpoints().DeactivateHTTP(ctx, "corruptHashKV"))
+	require.NoError(t, CheckHashKV(ctx, clus, rev))
+}
+
+func TestCheckHashKVRange(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx := context.Background()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, clus.Etcdctl().Put(ctx, fmt.Sprintf("key%d", i), "value", config.PutOptions{}))
+	}
+	resp, err := clus.Etcdctl().Get(ctx, "key0", config.GetOptions{})
+	require.NoError(t, err)
+	rev := resp.Header.Revision
+	_, err = clus.Etcdctl().Compact(ctx, rev/2, config.CompactOption{Physical: true})
+	require.NoError(t, err)
+	require.NoError(t, CheckHashKVRange(ctx, clus, 1, rev, 3))
+	require.ErrorContains(t, CheckHashKVRange(ctx, clus, 1, rev, 0), "invalid hashKV step")
+}
+
+func TestCheckHashKVAgainstReference(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx := context.Background()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, clus.Etcdctl().Put(ctx, fmt.Sprintf("key%d", i%3), fmt.Sprintf("%d", i), config.PutOptions{}))
+	}
+	_, err = clus.Etcdctl().Delete(ctx, "key0", config.DeleteOptions{})
+	require.NoError(t, err)
+	resp, err := clus.Etcdctl().Get(ctx, "key1", config.GetOptions{})
+	require.NoError(t, err)
+	rev := resp.Header.Revision
+
+	persistedRequests, err := report.PersistedRequestsCluster(zaptest.NewLogger(t), clus)
+	require.NoError(t, err)
+	expectedHash, err := model.HashKV(persistedRequests, rev)
+	require.NoError(t, err)
+	require.NoError(t, CheckHashKVAgainstReference(ctx, clus, rev, expectedHash))
+	require.ErrorContains(t, CheckHashKVAgainstReference(ctx, clus, rev, expectedHash+1), "differs from reference hash")
+}
+
+func TestHashKVCheckpoints(t *testing.T) {
+	tcs := []struct {
+		name           string
+		fromRev, toRev int64
+		step           int64
+		expect         []int64
+	}{
+		{name: "Range multiple of step", fromRev: 1, toRev: 10, step: 3, expect: []int64{1, 4, 7, 10}},
+		{name: "Range not multiple of step", fromRev: 1, toRev: 9, step: 3, expect: []int64{1, 4, 7, 9}},
+		{name: "Step larger than range", fromRev: 1, toRev: 5, step: 10, expect: []int64{1, 5}},
+		{name: "Single revision", fromRev: 5, toRev: 5, step: 1, expect: []int64{5}},
+		{name: "Empty range", fromRev: 6, toRev: 5, step: 1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, hashKVCheckpoints(tc.fromRev, tc.toRev, tc.step))
+		})
+	}
+}
+
+func TestRetryHashKV(t *testing.T) {
+	retry := HashKVRetry{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	t.Run("Succeeds after transient errors", func(t *testing.T) {
+		attempts := 0
+		hash, err := retryHashKV(context.Background(), retry, func() (memberHashKV, error) {
+			attempts++
+			if attempts < 3 {
+				return memberHashKV{}, errors.New("leader changed")
+			}
+			return memberHashKV{Name: "a", Hash: 1}, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, memberHashKV{Name: "a", Hash: 1}, hash)
+	})
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		attempts := 0
+		_, err := retryHashKV(context.Background(), retry, func() (memberHashKV, error) {
+			attempts++
+			return memberHashKV{}, errors.New("connection refused")
+		})
+		require.ErrorContains(t, err, "connection refused")
+		require.Equal(t, 3, attempts)
+	})
+	t.Run("Stops on context cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		_, err := retryHashKV(ctx, HashKVRetry{MaxAttempts: 10, Backoff: time.Minute, MaxBackoff: time.Minute}, func() (memberHashKV, error) {
+			attempts++
+			cancel()
+			return memberHashKV{}, errors.New("connection refused")
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func TestCompareHashKVs(t *testing.T) {
+	tcs := []struct {
+		name        string
+		hashes      []memberHashKV
+		expectError string
+	}{
+		{
+			name: "Matching hashes",
+			hashes: []memberHashKV{
+				{Name: "a", Hash: 1, CompactRevision: 2},
+				{Name: "b", Hash: 1, CompactRevision: 2},
+			},
+		},
+		{
+			name: "Revision compacted on all members",
+			hashes: []memberHashKV{
+				{Name: "a", Compacted: true},
+				{Name: "b", Compacted: true},
+			},
+		},
+		{
+			name: "Hash mismatch",
+			hashes: []memberHashKV{
+				{Name: "a", Hash: 1, CompactRevision: 2},
+				{Name: "b", Hash: 2, CompactRevision: 2},
+			},
+			expectError: `hashKV mismatch at revision 5, members "b" differ from majority`,
+		},
+		{
+			name: "Hash mismatch of minority",
+			hashes: []memberHashKV{
+				{Name: "a", Hash: 2, CompactRevision: 2},
+				{Name: "b", Hash: 1, CompactRevision: 2},
+				{Name: "c", Hash: 1, CompactRevision: 2},
+			},
+			expectError: `hashKV mismatch at revision 5, members "a" differ from majority`,
+		},
+		{
+			name: "Member compacted to higher revision",
+			hashes: []memberHashKV{
+				{Name: "a", Hash: 1, CompactRevision: 2},
+				{Name: "b", Hash: 2, CompactRevision: 3},
+			},
+			expectError: `member "b" compacted ahead of member "a" at revision 5`,
+		},
+		{
+			name: "Member compacted requested revision",
+			hashes: []memberHashKV{
+				{Name: "a", Hash: 1, CompactRevision: 2},
+				{Name: "b", Compacted: true},
+			},
+			expectError: `member "b" compacted ahead of member "a" at revision 5`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := compareHashKVs(5, tc.hashes)
+			if tc.expectError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectError)
+			}
+		})
+	}
+}
@@ -14,7 +14,14 @@
 
 package identity
 
-import "sync/atomic"
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// maxRequestIDOffset bounds the seeded starting point of request IDs.
+const maxRequestIDOffset = 1 << 30
 
 type Provider interface {
 	// NewStreamID returns an integer starting from zero to make it render nicely by porcupine visualization.
@@ -23,13 +30,25 @@ type Provider interface {
 	NewRequestID() int
 	// NewClientID returns unique identification for client and their reports.
 	NewClientID() int
+	// Seed returns seed that determines the sequence of IDs, allowing to reproduce it with NewIDProviderWithSeed.
+	Seed() int64
 }
 
+// NewIDProvider returns provider seeded from the current time.
 func NewIDProvider() Provider {
-	return &atomicProvider{}
+	return NewIDProviderWithSeed(time.Now().UnixNano())
+}
+
+// NewIDProviderWithSeed returns provider generating deterministic sequence of IDs for the seed.
+// Seed determines the first request ID, while stream and client IDs always start from zero and one.
+func NewIDProviderWithSeed(seed int64) Provider {
+	p := &atomicProvider{seed: seed}
+	p.requestID.Store(rand.New(rand.NewSource(seed)).Int63n(maxRequestIDOffset))
+	return p
 }
 
 type atomicProvider struct {
+	seed      int64
 	streamID  atomic.Int64
 	requestID atomic.Int64
 	clientID  atomic.Int64
@@ -46,3 +65,7 @@ func (id *atomicProvider) NewRequestID() int {
 func (id *atomicProvider) NewClientID() int {
 	return int(id.clientID.Add(1))
 }
+
+func (id *atomicProvider) Seed() int64 {
+	return id.seed
+}
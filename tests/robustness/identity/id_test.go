@@ -0,0 +1,35 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDProviderWithSeed(t *testing.T) {
+	ids := func(p Provider) []int {
+		return []int{p.NewStreamID(), p.NewClientID(), p.NewRequestID(), p.NewRequestID()}
+	}
+	p1, p2 := NewIDProviderWithSeed(42), NewIDProviderWithSeed(42)
+	assert.Equal(t, ids(p1), ids(p2))
+	assert.Equal(t, int64(42), p1.Seed())
+
+	p3 := NewIDProviderWithSeed(p1.Seed() + 1)
+	assert.Equal(t, 0, p3.NewStreamID())
+	assert.Equal(t, 1, p3.NewClientID())
+	assert.NotEqual(t, NewIDProviderWithSeed(42).NewRequestID(), p3.NewRequestID())
+}
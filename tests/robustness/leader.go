@@ -0,0 +1,283 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+// StartLeaderUniquenessMonitor polls status of all members every interval and fails the test
+// if two members claimed to be the leader in the same term. Members disagreeing on term
+// are expected during leader election, so only leaders in the same term are compared.
+// Returned function stops the monitor and waits for it to finish.
+func StartLeaderUniquenessMonitor(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	clients := make([]*clientv3.Client, 0, len(clus.Procs))
+	for _, member := range clus.Procs {
+		c, err := clientv3.New(clientv3.Config{
+			Endpoints:   member.EndpointsGRPC(),
+			Logger:      zap.NewNop(),
+			DialTimeout: interval,
+		})
+		if err != nil {
+			t.Fatalf("Failed creating client: %v", err)
+		}
+		clients = append(clients, c)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			for _, c := range clients {
+				c.Close()
+			}
+		}()
+		leaderByTerm := map[uint64]uint64{}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for _, c := range clients {
+				checkLeaderUniqueness(ctx, t, c, interval, leaderByTerm)
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func checkLeaderUniqueness(ctx context.Context, t *testing.T, c *clientv3.Client, timeout time.Duration, leaderByTerm map[uint64]uint64) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	resp, err := c.Status(ctx, c.Endpoints()[0])
+	// Member can be unavailable due to injected failpoint.
+	if err != nil {
+		return
+	}
+	if resp.Leader == 0 || resp.Leader != resp.Header.MemberId {
+		return
+	}
+	leader, found := leaderByTerm[resp.RaftTerm]
+	if !found {
+		leaderByTerm[resp.RaftTerm] = resp.Leader
+		return
+	}
+	if leader != resp.Leader {
+		t.Errorf("Broken leader uniqueness, members %x and %x both claimed leadership in term %d", leader, resp.Leader, resp.RaftTerm)
+	}
+}
+
+// DelayFirstConnectionToNewLeader polls status of all members every interval and, whenever
+// a member becomes leader in a new term, delays the next connection accepted by its peer proxy.
+// It models connection setup cost on a freshly elected leader. Requires peer proxy.
+// Returned function stops the polling and waits for it to finish.
+func DelayFirstConnectionToNewLeader(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, latency, interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	clients := make([]*clientv3.Client, 0, len(clus.Procs))
+	for _, member := range clus.Procs {
+		if member.PeerProxy() == nil {
+			t.Fatalf("Member %q doesn't have peer proxy", member.Config().Name)
+		}
+		c, err := clientv3.New(clientv3.Config{
+			Endpoints:   member.EndpointsGRPC(),
+			Logger:      zap.NewNop(),
+			DialTimeout: interval,
+		})
+		if err != nil {
+			t.Fatalf("Failed creating client: %v", err)
+		}
+		clients = append(clients, c)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			for _, c := range clients {
+				c.Close()
+			}
+		}()
+		var lastTerm uint64
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for i, c := range clients {
+				reqCtx, reqCancel := context.WithTimeout(ctx, interval)
+				resp, err := c.Status(reqCtx, c.Endpoints()[0])
+				reqCancel()
+				if err != nil || resp.Leader != resp.Header.MemberId || resp.RaftTerm <= lastTerm {
+					continue
+				}
+				lastTerm = resp.RaftTerm
+				clus.Procs[i].PeerProxy().DelayNextAccept(latency)
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// AssertMoveLeaderSafe transfers leadership from the current leader to one of the followers
+// while writes are continuously issued, and asserts that every acknowledged write is
+// readable after the transfer. Returns IDs of leaders before and after the transfer,
+// together with reports of the recorded operations.
+func AssertMoveLeaderSafe(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster) (before, after uint64, reports []report.ClientReport) {
+	ids := identity.NewIDProvider()
+	baseTime := time.Now()
+	leader := clus.Procs[clus.WaitLeader(t)]
+	lc, err := client.NewRecordingClient(leader.EndpointsGRPC(), ids, baseTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lc.Close()
+	wc, err := client.NewRecordingClient(clus.EndpointsGRPC(), ids, baseTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wc.Close()
+
+	var target uint64
+	for _, member := range clus.Procs {
+		status, err := lc.Status(ctx, member.EndpointsGRPC()[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		before = status.Leader
+		if status.Header.MemberId != status.Leader {
+			target = status.Header.MemberId
+		}
+	}
+	if target == 0 {
+		t.Fatal("No follower to transfer leadership to")
+	}
+
+	writeCtx, cancel := context.WithCancel(ctx)
+	acknowledged := map[string]string{}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; writeCtx.Err() == nil; i++ {
+			key, value := fmt.Sprintf("move-leader-%d", i), fmt.Sprintf("%d", i)
+			if _, err := wc.Put(writeCtx, key, value); err == nil {
+				acknowledged[key] = value
+			}
+		}
+	}()
+
+	t.Logf("Moving leadership from %x to %x", before, target)
+	_, err = lc.MoveLeader(ctx, target)
+	cancel()
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("Failed to move leader, err: %s", err)
+	}
+	status, err := lc.Status(ctx, leader.EndpointsGRPC()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	after = status.Leader
+	t.Logf("Leadership moved from %x to %x, acknowledged writes: %d", before, after, len(acknowledged))
+	if after != target {
+		t.Errorf("Expected leader %x after transfer, got %x", target, after)
+	}
+
+	for key, value := range acknowledged {
+		kv, _, err := wc.Get(ctx, key, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kv == nil || string(kv.Value) != value {
+			t.Errorf("Lost acknowledged write %q=%q after leadership transfer", key, value)
+		}
+	}
+	return before, after, []report.ClientReport{lc.Report(), wc.Report()}
+}
+
+// RampLatencyUntilReelection increases latency of the leader peer link by step every interval,
+// until any member reports a different leader. Latency is removed before returning.
+// Returns latency at which re-election was detected. Requires peer proxy.
+func RampLatencyUntilReelection(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, step, interval time.Duration) (threshold time.Duration) {
+	leaderIdx := clus.WaitLeader(t)
+	leader := clus.Procs[leaderIdx]
+	proxy := leader.PeerProxy()
+	if proxy == nil {
+		t.Fatalf("Member %q doesn't have peer proxy", leader.Config().Name)
+	}
+	c, err := clientv3.New(clientv3.Config{
+		Endpoints:   clus.EndpointsGRPC(),
+		Logger:      zap.NewNop(),
+		DialTimeout: interval,
+	})
+	if err != nil {
+		t.Fatalf("Failed creating client: %v", err)
+	}
+	defer c.Close()
+	status, err := c.Status(ctx, leader.EndpointsGRPC()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldLeader := status.Leader
+
+	defer func() {
+		proxy.UndelayTx()
+		proxy.UndelayRx()
+	}()
+	for latency := step; ; latency += step {
+		proxy.DelayTx(latency, 0)
+		proxy.DelayRx(latency, 0)
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Leader wasn't re-elected up to latency %s, err: %s", latency-step, ctx.Err())
+		case <-time.After(interval):
+		}
+		for _, member := range clus.Procs {
+			reqCtx, cancel := context.WithTimeout(ctx, interval)
+			resp, err := c.Status(reqCtx, member.EndpointsGRPC()[0])
+			cancel()
+			if err != nil || resp.Leader == 0 || resp.Leader == oldLeader {
+				continue
+			}
+			t.Logf("Member %q reported leader change from %x to %x at latency %s", member.Config().Name, oldLeader, resp.Leader, latency)
+			return latency
+		}
+	}
+}
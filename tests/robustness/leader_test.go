@@ -0,0 +1,54 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestMoveLeaderUnderLoad(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	before, after, reports := AssertMoveLeaderSafe(ctx, t, clus)
+	require.NotEqual(t, before, after)
+	require.Len(t, reports, 2)
+}
+
+func TestRampLatencyUntilReelection(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithIsPeerTLS(true), e2e.WithPeerProxy(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	threshold := RampLatencyUntilReelection(ctx, t, clus, 100*time.Millisecond, 2*time.Second)
+	electionTimeout := time.Duration(clus.Cfg.ServerConfig.ElectionMs) * time.Millisecond
+	t.Logf("Re-election at latency %s, election timeout %s", threshold, electionTimeout)
+	// Heartbeats arrive late once latency is comparable with election timeout.
+	require.Greater(t, threshold, electionTimeout/4)
+	require.LessOrEqual(t, threshold, 2*electionTimeout)
+}
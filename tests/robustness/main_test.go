@@ -113,6 +113,9 @@ func (s testScenario) run(ctx context.Context, t *testing.T, lg *zap.Logger, clu
 	// see https://github.com/golang/go/blob/master/src/time/time.go#L17
 	baseTime := time.Now()
 	ids := identity.NewIDProvider()
+	lg.Info("Using identity seed", zap.Int64("seed", ids.Seed()))
+	stopLeaderMonitor := StartLeaderUniquenessMonitor(ctx, t, clus, 100*time.Millisecond)
+	defer stopLeaderMonitor()
 	g.Go(func() error {
 		defer close(failpointInjected)
 		// Give some time for traffic to reach qps target before injecting failpoint.
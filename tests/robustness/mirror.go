@@ -0,0 +1,56 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+)
+
+// MirrorPrefix copies keys with given prefix from source to destination and then
+// replicates all following changes until context is cancelled.
+// Both sides are recorded by the provided clients.
+func MirrorPrefix(ctx context.Context, src, dst *client.RecordingClient, prefix string) error {
+	resp, err := src.Range(ctx, prefix, clientv3.GetPrefixRangeEnd(prefix), 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list source keys: %w", err)
+	}
+	for _, kv := range resp.Kvs {
+		if _, err = dst.Put(ctx, string(kv.Key), string(kv.Value)); err != nil {
+			return fmt.Errorf("failed to copy key %q: %w", kv.Key, err)
+		}
+	}
+	for watchResp := range src.Watch(ctx, prefix, resp.Header.Revision+1, true, false, false) {
+		if err = watchResp.Err(); err != nil {
+			return fmt.Errorf("source watch failed: %w", err)
+		}
+		for _, event := range watchResp.Events {
+			switch event.Type {
+			case mvccpb.PUT:
+				_, err = dst.Put(ctx, string(event.Kv.Key), string(event.Kv.Value))
+			case mvccpb.DELETE:
+				_, err = dst.Delete(ctx, string(event.Kv.Key))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to mirror event for key %q: %w", event.Kv.Key, err)
+			}
+		}
+	}
+	return ctx.Err()
+}
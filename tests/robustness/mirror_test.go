@@ -0,0 +1,87 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+)
+
+func TestMirrorBetweenClusters(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source, destination, closeClusters, err := e2e.NewEtcdProcessClusterPair(ctx, t, e2e.WithClusterSize(1))
+	require.NoError(t, err)
+	defer closeClusters()
+
+	baseTime := time.Now()
+	ids := identity.NewIDProvider()
+	src, err := client.NewRecordingClient(source.EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer src.Close()
+	dst, err := client.NewRecordingClient(destination.EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer dst.Close()
+	writer, err := client.NewRecordingClient(source.EndpointsGRPC(), ids, baseTime)
+	require.NoError(t, err)
+	defer writer.Close()
+
+	for i := 0; i < 5; i++ {
+		_, err = writer.Put(ctx, fmt.Sprintf("/mirror/key%d", i), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	mirrorErr := make(chan error, 1)
+	go func() {
+		mirrorErr <- MirrorPrefix(ctx, src, dst, "/mirror/")
+	}()
+	for i := 5; i < 10; i++ {
+		_, err = writer.Put(ctx, fmt.Sprintf("/mirror/key%d", i), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	_, err = writer.Delete(ctx, "/mirror/key0")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return equalPrefix(ctx, t, src, dst, "/mirror/")
+	}, 10*time.Second, 100*time.Millisecond, "clusters didn't converge")
+	cancel()
+	require.ErrorIs(t, <-mirrorErr, context.Canceled)
+}
+
+func equalPrefix(ctx context.Context, t *testing.T, a, b *client.RecordingClient, prefix string) bool {
+	respA, err := a.Range(ctx, prefix, clientv3.GetPrefixRangeEnd(prefix), 0, 0)
+	require.NoError(t, err)
+	respB, err := b.Range(ctx, prefix, clientv3.GetPrefixRangeEnd(prefix), 0, 0)
+	require.NoError(t, err)
+	if len(respA.Kvs) != len(respB.Kvs) {
+		return false
+	}
+	for i := range respA.Kvs {
+		if string(respA.Kvs[i].Key) != string(respB.Kvs[i].Key) || string(respA.Kvs[i].Value) != string(respB.Kvs[i].Value) {
+			return false
+		}
+	}
+	return true
+}
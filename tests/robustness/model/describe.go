@@ -41,8 +41,10 @@ func describeEtcdResponse(request EtcdRequest, response MaybeEtcdResponse) strin
 			return "ok"
 		}
 		return fmt.Sprintf("ok, rev: %d", response.Revision)
-	case Compact:
+	case Compact, MoveLeader, LeaseKeepAlive:
 		return "ok"
+	case Snapshot:
+		return fmt.Sprintf("ok, size: %d", response.Snapshot.Size)
 	default:
 		return fmt.Sprintf("<! unknown request type: %q !>", request.Type)
 	}
@@ -51,36 +53,52 @@ func describeEtcdResponse(request EtcdRequest, response MaybeEtcdResponse) strin
 func describeEtcdRequest(request EtcdRequest) string {
 	switch request.Type {
 	case Range:
+		if request.Range.Serializable {
+			return fmt.Sprintf("serializable(%s)", describeRangeRequest(request.Range.RangeOptions, request.Range.Revision))
+		}
 		return describeRangeRequest(request.Range.RangeOptions, request.Range.Revision)
 	case Txn:
-		guaranteedTxnDescription := describeGuaranteedTxn(request.Txn)
-		if guaranteedTxnDescription != "" {
-			return guaranteedTxnDescription
-		}
-		onSuccess := describeEtcdOperations(request.Txn.OperationsOnSuccess)
-		if len(request.Txn.Conditions) != 0 {
-			if len(request.Txn.OperationsOnFailure) == 0 {
-				return fmt.Sprintf("if(%s).then(%s)", describeEtcdConditions(request.Txn.Conditions), onSuccess)
-			}
-			onFailure := describeEtcdOperations(request.Txn.OperationsOnFailure)
-			return fmt.Sprintf("if(%s).then(%s).else(%s)", describeEtcdConditions(request.Txn.Conditions), onSuccess, onFailure)
-		}
-		return onSuccess
+		return describeTxnRequest(request.Txn)
 	case LeaseGrant:
 		return fmt.Sprintf("leaseGrant(%d)", request.LeaseGrant.LeaseID)
 	case LeaseRevoke:
 		return fmt.Sprintf("leaseRevoke(%d)", request.LeaseRevoke.LeaseID)
+	case LeaseKeepAlive:
+		return fmt.Sprintf("leaseKeepAlive(%d)", request.LeaseKeepAlive.LeaseID)
 	case Defragment:
 		return fmt.Sprintf("defragment()")
 	case Compact:
+		if request.Compact.Physical {
+			return fmt.Sprintf("compact(%d, physical)", request.Compact.Revision)
+		}
 		return fmt.Sprintf("compact(%d)", request.Compact.Revision)
+	case Snapshot:
+		return "snapshot()"
+	case MoveLeader:
+		return fmt.Sprintf("moveLeader(%x)", request.MoveLeader.TargetID)
 	default:
 		return fmt.Sprintf("<! unknown request type: %q !>", request.Type)
 	}
 }
 
+func describeTxnRequest(txn *TxnRequest) string {
+	guaranteedTxnDescription := describeGuaranteedTxn(txn)
+	if guaranteedTxnDescription != "" {
+		return guaranteedTxnDescription
+	}
+	onSuccess := describeEtcdOperations(txn.OperationsOnSuccess)
+	if len(txn.Conditions) != 0 {
+		if len(txn.OperationsOnFailure) == 0 {
+			return fmt.Sprintf("if(%s).then(%s)", describeEtcdConditions(txn.Conditions), onSuccess)
+		}
+		onFailure := describeEtcdOperations(txn.OperationsOnFailure)
+		return fmt.Sprintf("if(%s).then(%s).else(%s)", describeEtcdConditions(txn.Conditions), onSuccess, onFailure)
+	}
+	return onSuccess
+}
+
 func describeGuaranteedTxn(txn *TxnRequest) string {
-	if len(txn.Conditions) != 1 || len(txn.OperationsOnSuccess) != 1 || len(txn.OperationsOnFailure) > 1 {
+	if len(txn.Conditions) != 1 || !txn.Conditions[0].IsModRevisionEqual() || len(txn.OperationsOnSuccess) != 1 || len(txn.OperationsOnFailure) > 1 {
 		return ""
 	}
 	switch txn.OperationsOnSuccess[0].Type {
@@ -93,7 +111,7 @@ func describeGuaranteedTxn(txn *TxnRequest) string {
 		}
 		return fmt.Sprintf("guaranteedUpdate(%q, %s, mod_rev=%d)", txn.Conditions[0].Key, describeValueOrHash(txn.OperationsOnSuccess[0].Put.Value), txn.Conditions[0].ExpectedRevision)
 	case DeleteOperation:
-		if txn.Conditions[0].Key != txn.OperationsOnSuccess[0].Delete.Key || (len(txn.OperationsOnFailure) == 1 && txn.Conditions[0].Key != txn.OperationsOnFailure[0].Range.Start) {
+		if txn.Conditions[0].Key != txn.OperationsOnSuccess[0].Delete.Key || txn.OperationsOnSuccess[0].Delete.End != "" || (len(txn.OperationsOnFailure) == 1 && txn.Conditions[0].Key != txn.OperationsOnFailure[0].Range.Start) {
 			return ""
 		}
 		return fmt.Sprintf("guaranteedDelete(%q, mod_rev=%d)", txn.Conditions[0].Key, txn.Conditions[0].ExpectedRevision)
@@ -104,11 +122,32 @@ func describeGuaranteedTxn(txn *TxnRequest) string {
 func describeEtcdConditions(conds []EtcdCondition) string {
 	opsDescription := make([]string, len(conds))
 	for i := range conds {
-		opsDescription[i] = fmt.Sprintf("mod_rev(%s)==%d", conds[i].Key, conds[i].ExpectedRevision)
+		opsDescription[i] = describeEtcdCondition(conds[i])
 	}
 	return strings.Join(opsDescription, " && ")
 }
 
+func describeEtcdCondition(cond EtcdCondition) string {
+	result := string(cond.Result)
+	if cond.Result == CompareEqual {
+		result = "=="
+	}
+	switch cond.Target {
+	case CompareModRevision:
+		return fmt.Sprintf("mod_rev(%s)%s%d", cond.Key, result, cond.ExpectedRevision)
+	case CompareCreateRevision:
+		return fmt.Sprintf("create_rev(%s)%s%d", cond.Key, result, cond.ExpectedRevision)
+	case CompareVersion:
+		return fmt.Sprintf("version(%s)%s%d", cond.Key, result, cond.ExpectedVersion)
+	case CompareValue:
+		return fmt.Sprintf("value(%s)%s%s", cond.Key, result, describeValueOrHash(cond.ExpectedValue))
+	case CompareLease:
+		return fmt.Sprintf("lease(%s)%s%d", cond.Key, result, cond.ExpectedLease)
+	default:
+		return fmt.Sprintf("<! unknown compare target: %q !>", cond.Target)
+	}
+}
+
 func describeEtcdOperations(ops []EtcdOperation) string {
 	opsDescription := make([]string, len(ops))
 	for i := range ops {
@@ -141,12 +180,27 @@ func describeEtcdOperation(op EtcdOperation) string {
 	case RangeOperation:
 		return describeRangeRequest(op.Range, 0)
 	case PutOperation:
+		prevKV := ""
+		if op.Put.PrevKV {
+			prevKV = ", prev_kv"
+		}
 		if op.Put.LeaseID != 0 {
-			return fmt.Sprintf("put(%q, %s, %d)", op.Put.Key, describeValueOrHash(op.Put.Value), op.Put.LeaseID)
+			return fmt.Sprintf("put(%q, %s, %d%s)", op.Put.Key, describeValueOrHash(op.Put.Value), op.Put.LeaseID, prevKV)
 		}
-		return fmt.Sprintf("put(%q, %s)", op.Put.Key, describeValueOrHash(op.Put.Value))
+		return fmt.Sprintf("put(%q, %s%s)", op.Put.Key, describeValueOrHash(op.Put.Value), prevKV)
 	case DeleteOperation:
+		if op.Delete.End == clientv3.GetPrefixRangeEnd(op.Delete.Key) {
+			return fmt.Sprintf("deletePrefix(%q)", op.Delete.Key)
+		}
+		if op.Delete.End != "" {
+			return fmt.Sprintf("deleteRange(%q..%q)", op.Delete.Key, op.Delete.End)
+		}
+		if op.Delete.PrevKV {
+			return fmt.Sprintf("delete(%q, prev_kv)", op.Delete.Key)
+		}
 		return fmt.Sprintf("delete(%q)", op.Delete.Key)
+	case TxnOperation:
+		return fmt.Sprintf("txn(%s)", describeTxnRequest(op.Txn))
 	default:
 		return fmt.Sprintf("<! unknown op: %q !>", op.Type)
 	}
@@ -160,6 +214,9 @@ func describeRangeRequest(opts RangeOptions, revision int64) string {
 	if opts.Limit != 0 {
 		kwargs = append(kwargs, fmt.Sprintf("limit=%d", opts.Limit))
 	}
+	if opts.CountOnly {
+		kwargs = append(kwargs, "count_only")
+	}
 	kwargsString := strings.Join(kwargs, ", ")
 	if kwargsString != "" {
 		kwargsString = ", " + kwargsString
@@ -179,9 +236,17 @@ func describeEtcdOperationResponse(op EtcdOperation, resp EtcdOperationResult) s
 	case RangeOperation:
 		return describeRangeResponse(op.Range, resp.RangeResponse)
 	case PutOperation:
+		if op.Put.PrevKV {
+			return fmt.Sprintf("ok, prev: %s", describePrevKV(resp.PrevKV))
+		}
 		return fmt.Sprintf("ok")
 	case DeleteOperation:
+		if op.Delete.PrevKV {
+			return fmt.Sprintf("deleted: %d, prev: %s", resp.Deleted, describePrevKV(resp.PrevKV))
+		}
 		return fmt.Sprintf("deleted: %d", resp.Deleted)
+	case TxnOperation:
+		return fmt.Sprintf("txn(%s)", describeTxnResponse(op.Txn, resp.Txn))
 	default:
 		return fmt.Sprintf("<! unknown op: %q !>", op.Type)
 	}
@@ -202,6 +267,13 @@ func describeRangeResponse(request RangeOptions, response RangeResponse) string
 	return describeValueOrHash(response.KVs[0].Value)
 }
 
+func describePrevKV(kv *KeyValue) string {
+	if kv == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%s, mod_rev: %d", describeValueOrHash(kv.Value), kv.ModRevision)
+}
+
 func describeValueOrHash(value ValueOrHash) string {
 	if value.Hash != 0 {
 		return fmt.Sprintf("hash: %d", value.Hash)
@@ -54,6 +54,16 @@ func TestModelDescribe(t *testing.T) {
 			resp:           putResponse(3),
 			expectDescribe: `put("key3b", "3b", 3) -> ok, rev: 3`,
 		},
+		{
+			req:            compactRequest(4, false),
+			resp:           compactResponse(-1),
+			expectDescribe: `compact(4) -> ok`,
+		},
+		{
+			req:            compactRequest(4, true),
+			resp:           compactResponse(-1),
+			expectDescribe: `compact(4, physical) -> ok`,
+		},
 		{
 			req:            putRequest("key3c", "01234567890123456789"),
 			resp:           putResponse(3),
@@ -124,11 +134,56 @@ func TestModelDescribe(t *testing.T) {
 			resp:           txnResponse([]EtcdOperationResult{{}}, true, 11),
 			expectDescribe: `if(mod_rev(key11)==11).then(put("key12", "11")) -> success(ok), rev: 11`,
 		},
+		{
+			req: txnRequest([]EtcdCondition{
+				{Key: "key13", Target: CompareCreateRevision, Result: CompareLess, ExpectedRevision: 13},
+				{Key: "key13", Target: CompareVersion, Result: CompareGreater, ExpectedVersion: 2},
+				{Key: "key13", Target: CompareValue, Result: CompareNotEqual, ExpectedValue: ToValueOrHash("13")},
+				{Key: "key13", Target: CompareLease, ExpectedLease: 5},
+			}, []EtcdOperation{{Type: RangeOperation, Range: RangeOptions{Start: "key13"}}}, nil),
+			resp:           txnResponse([]EtcdOperationResult{{}}, true, 13),
+			expectDescribe: `if(create_rev(key13)<13 && version(key13)>2 && value(key13)!="13" && lease(key13)==5).then(get("key13")) -> success(nil), rev: 13`,
+		},
+		{
+			req:            txnRequest(nil, []EtcdOperation{{Type: PutOperation, Put: PutOptions{Key: "key14", Value: ToValueOrHash("14")}}, {Type: TxnOperation, Txn: &TxnRequest{Conditions: []EtcdCondition{{Key: "key15", Target: CompareVersion}}, OperationsOnSuccess: []EtcdOperation{{Type: DeleteOperation, Delete: DeleteOptions{Key: "key14"}}}}}}, nil),
+			resp:           txnResponse([]EtcdOperationResult{{}, {Txn: &TxnResponse{Failure: true, Results: []EtcdOperationResult{}}}}, true, 14),
+			expectDescribe: `put("key14", "14"), txn(if(version(key15)==0).then(delete("key14"))) -> ok, txn(failure()), rev: 14`,
+		},
+		{
+			req:            putWithPrevKVRequest("key16", "16"),
+			resp:           putWithPrevKVResponse(&KeyValue{Key: "key16", ValueRevision: ValueRevision{Value: ToValueOrHash("15"), ModRevision: 15}}, 16),
+			expectDescribe: `put("key16", "16", prev_kv) -> ok, prev: "15", mod_rev: 15, rev: 16`,
+		},
+		{
+			req:            deleteWithPrevKVRequest("key17"),
+			resp:           deleteWithPrevKVResponse(0, nil, 17),
+			expectDescribe: `delete("key17", prev_kv) -> deleted: 0, prev: nil, rev: 17`,
+		},
+		{
+			req:            deleteRangeRequest("key18", "key20"),
+			resp:           deleteResponse(2, 18),
+			expectDescribe: `deleteRange("key18".."key20") -> deleted: 2, rev: 18`,
+		},
+		{
+			req:            deleteRangeRequest("prefix/", "prefix0"),
+			resp:           deleteResponse(3, 19),
+			expectDescribe: `deletePrefix("prefix/") -> deleted: 3, rev: 19`,
+		},
+		{
+			req:            serializableGetRequest("key20"),
+			resp:           getResponse("key20", "20", 20, 20),
+			expectDescribe: `serializable(get("key20")) -> "20", rev: 20`,
+		},
 		{
 			req:            defragmentRequest(),
 			resp:           defragmentResponse(10),
 			expectDescribe: `defragment() -> ok, rev: 10`,
 		},
+		{
+			req:            snapshotRequest(),
+			resp:           snapshotResponse(4096, 1),
+			expectDescribe: `snapshot() -> ok, size: 4096`,
+		},
 		{
 			req:            listRequest("key11", 0),
 			resp:           rangeResponse(nil, 0, 11),
@@ -149,6 +204,11 @@ func TestModelDescribe(t *testing.T) {
 			resp:           rangeResponse(nil, 0, 14),
 			expectDescribe: `list("key14", limit=14) -> [], count: 0, rev: 14`,
 		},
+		{
+			req:            countRequest("key14", "key16"),
+			resp:           rangeResponse(nil, 3, 14),
+			expectDescribe: `range("key14".."key16", count_only) -> [], count: 3, rev: 14`,
+		},
 		{
 			req:            staleListRequest("key15", 0, 15),
 			resp:           rangeResponse(nil, 0, 15),
@@ -15,6 +15,7 @@
 package model
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,9 +23,12 @@ import (
 	"maps"
 	"reflect"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/anishathalye/porcupine"
 
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
@@ -70,8 +74,11 @@ type EtcdState struct {
 	Revision        int64
 	CompactRevision int64
 	KeyValues       map[string]ValueRevision
-	KeyLeases       map[string]int64
-	Leases          map[int64]EtcdLease
+	// KeyVersions are create revisions and versions of keys in KeyValues,
+	// used only to evaluate txn conditions.
+	KeyVersions map[string]KeyVersion
+	KeyLeases   map[string]int64
+	Leases      map[int64]EtcdLease
 }
 
 func (s EtcdState) apply(request EtcdRequest, response EtcdResponse) (bool, EtcdState) {
@@ -86,6 +93,7 @@ func (s EtcdState) DeepCopy() EtcdState {
 	}
 
 	newState.KeyValues = maps.Clone(s.KeyValues)
+	newState.KeyVersions = maps.Clone(s.KeyVersions)
 	newState.KeyLeases = maps.Clone(s.KeyLeases)
 
 	newLeases := map[int64]EtcdLease{}
@@ -102,6 +110,7 @@ func freshEtcdState() EtcdState {
 		// Start from CompactRevision equal -1 as etcd allows client to compact revision 0 for some reason.
 		CompactRevision: -1,
 		KeyValues:       map[string]ValueRevision{},
+		KeyVersions:     map[string]KeyVersion{},
 		KeyLeases:       map[string]int64{},
 		Leases:          map[int64]EtcdLease{},
 	}
@@ -125,54 +134,11 @@ func (s EtcdState) Step(request EtcdRequest) (EtcdState, MaybeEtcdResponse) {
 		}
 		return newState, MaybeEtcdResponse{PartialResponse: true, EtcdResponse: EtcdResponse{Revision: newState.Revision}}
 	case Txn:
-		failure := false
-		for _, cond := range request.Txn.Conditions {
-			if val := newState.KeyValues[cond.Key]; val.ModRevision != cond.ExpectedRevision {
-				failure = true
-				break
-			}
-		}
-		operations := request.Txn.OperationsOnSuccess
-		if failure {
-			operations = request.Txn.OperationsOnFailure
-		}
-		opResp := make([]EtcdOperationResult, len(operations))
-		increaseRevision := false
-		for i, op := range operations {
-			switch op.Type {
-			case RangeOperation:
-				opResp[i] = EtcdOperationResult{
-					RangeResponse: newState.getRange(op.Range),
-				}
-			case PutOperation:
-				_, leaseExists := newState.Leases[op.Put.LeaseID]
-				if op.Put.LeaseID != 0 && !leaseExists {
-					break
-				}
-				newState.KeyValues[op.Put.Key] = ValueRevision{
-					Value:       op.Put.Value,
-					ModRevision: newState.Revision + 1,
-				}
-				increaseRevision = true
-				newState = detachFromOldLease(newState, op.Put.Key)
-				if leaseExists {
-					newState = attachToNewLease(newState, op.Put.LeaseID, op.Put.Key)
-				}
-			case DeleteOperation:
-				if _, ok := newState.KeyValues[op.Delete.Key]; ok {
-					delete(newState.KeyValues, op.Delete.Key)
-					increaseRevision = true
-					newState = detachFromOldLease(newState, op.Delete.Key)
-					opResp[i].Deleted = 1
-				}
-			default:
-				panic("unsupported operation")
-			}
-		}
+		txnResp, increaseRevision := applyTxn(s, newState, request.Txn)
 		if increaseRevision {
 			newState.Revision++
 		}
-		return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{Txn: &TxnResponse{Failure: failure, Results: opResp}, Revision: newState.Revision}}
+		return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{Txn: &txnResp, Revision: newState.Revision}}
 	case LeaseGrant:
 		lease := EtcdLease{
 			LeaseID: request.LeaseGrant.LeaseID,
@@ -190,6 +156,7 @@ func (s EtcdState) Step(request EtcdRequest) (EtcdState, MaybeEtcdResponse) {
 					keyDeleted = true
 				}
 				delete(newState.KeyValues, key)
+				delete(newState.KeyVersions, key)
 				delete(newState.KeyLeases, key)
 			}
 		}
@@ -199,6 +166,12 @@ func (s EtcdState) Step(request EtcdRequest) (EtcdState, MaybeEtcdResponse) {
 			newState.Revision++
 		}
 		return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{Revision: newState.Revision, LeaseRevoke: &LeaseRevokeResponse{}}}
+	case LeaseKeepAlive:
+		if _, ok := newState.Leases[request.LeaseKeepAlive.LeaseID]; !ok {
+			return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{ClientError: rpctypes.ErrLeaseNotFound.Error()}}
+		}
+		// Set fake revision as keep alive is served by leader lessor without going through raft.
+		return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{LeaseKeepAlive: &LeaseKeepAliveResponse{}, Revision: -1}}
 	case Defragment:
 		return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{Defragment: &DefragmentResponse{}, Revision: newState.Revision}}
 	case Compact:
@@ -209,11 +182,115 @@ func (s EtcdState) Step(request EtcdRequest) (EtcdState, MaybeEtcdResponse) {
 		// Set fake revision as compaction returns non-linearizable revision.
 		// TODO: Model non-linearizable response revision in model.
 		return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{Compact: &CompactResponse{}, Revision: -1}}
+	case MoveLeader:
+		// Set fake revision as leadership transfer doesn't return revision.
+		return newState, MaybeEtcdResponse{EtcdResponse: EtcdResponse{MoveLeader: &MoveLeaderResponse{}, Revision: -1}}
+	case Snapshot:
+		// Model doesn't store backend content, so it cannot tell snapshot size.
+		// Return partial response to only compare the fake revision.
+		return newState, MaybeEtcdResponse{PartialResponse: true, EtcdResponse: EtcdResponse{Revision: -1}}
 	default:
 		panic(fmt.Sprintf("Unknown request type: %v", request.Type))
 	}
 }
 
+// applyTxn applies txn to newState, returning its response and whether it
+// changed any key. Like etcd, conditions of nested txns are evaluated against
+// prevState before any operation is applied, and all changes, including ones
+// done by nested txns, share a single revision.
+func applyTxn(prevState, newState EtcdState, txn *TxnRequest) (resp TxnResponse, increaseRevision bool) {
+	for _, cond := range txn.Conditions {
+		if !prevState.evaluate(cond) {
+			resp.Failure = true
+			break
+		}
+	}
+	operations := txn.OperationsOnSuccess
+	if resp.Failure {
+		operations = txn.OperationsOnFailure
+	}
+	resp.Results = make([]EtcdOperationResult, len(operations))
+	for i, op := range operations {
+		switch op.Type {
+		case RangeOperation:
+			resp.Results[i] = EtcdOperationResult{
+				RangeResponse: newState.getRange(op.Range),
+			}
+		case PutOperation:
+			_, leaseExists := newState.Leases[op.Put.LeaseID]
+			if op.Put.LeaseID != 0 && !leaseExists {
+				break
+			}
+			if op.Put.PrevKV {
+				resp.Results[i].PrevKV = newState.getKeyValue(op.Put.Key)
+			}
+			version, ok := newState.KeyVersions[op.Put.Key]
+			if !ok {
+				version = KeyVersion{CreateRevision: newState.Revision + 1}
+			}
+			version.Version++
+			newState.KeyVersions[op.Put.Key] = version
+			newState.KeyValues[op.Put.Key] = ValueRevision{
+				Value:       op.Put.Value,
+				ModRevision: newState.Revision + 1,
+			}
+			increaseRevision = true
+			newState = detachFromOldLease(newState, op.Put.Key)
+			if leaseExists {
+				newState = attachToNewLease(newState, op.Put.LeaseID, op.Put.Key)
+			}
+		case DeleteOperation:
+			for _, key := range newState.deletedKeys(op.Delete) {
+				if op.Delete.PrevKV && op.Delete.End == "" {
+					resp.Results[i].PrevKV = newState.getKeyValue(key)
+				}
+				delete(newState.KeyValues, key)
+				delete(newState.KeyVersions, key)
+				increaseRevision = true
+				newState = detachFromOldLease(newState, key)
+				resp.Results[i].Deleted++
+			}
+		case TxnOperation:
+			nestedResp, nestedIncrease := applyTxn(prevState, newState, op.Txn)
+			resp.Results[i].Txn = &nestedResp
+			increaseRevision = increaseRevision || nestedIncrease
+		default:
+			panic("unsupported operation")
+		}
+	}
+	return resp, increaseRevision
+}
+
+// deletedKeys returns existing keys deleted by delete operation, sorted the
+// same way as etcd deletes them.
+func (s EtcdState) deletedKeys(options DeleteOptions) (keys []string) {
+	if options.End == "" {
+		if _, ok := s.KeyValues[options.Key]; ok {
+			keys = append(keys, options.Key)
+		}
+		return keys
+	}
+	for key := range s.KeyValues {
+		if options.Contains(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getKeyValue returns current key-value of key, nil if it doesn't exist.
+func (s EtcdState) getKeyValue(key string) *KeyValue {
+	val, ok := s.KeyValues[key]
+	if !ok {
+		return nil
+	}
+	return &KeyValue{Key: key, ValueRevision: val, Lease: s.KeyLeases[key]}
+}
+
+// FromKey is range end selecting all keys greater than or equal to range start.
+const FromKey = "\x00"
+
 func (s EtcdState) getRange(options RangeOptions) RangeResponse {
 	response := RangeResponse{
 		KVs: []KeyValue{},
@@ -221,8 +298,8 @@ func (s EtcdState) getRange(options RangeOptions) RangeResponse {
 	if options.End != "" {
 		var count int64
 		for k, v := range s.KeyValues {
-			if k >= options.Start && k < options.End {
-				response.KVs = append(response.KVs, KeyValue{Key: k, ValueRevision: v})
+			if k >= options.Start && (options.End == FromKey || k < options.End) {
+				response.KVs = append(response.KVs, KeyValue{Key: k, ValueRevision: v, Lease: s.KeyLeases[k]})
 				count++
 			}
 		}
@@ -231,6 +308,7 @@ func (s EtcdState) getRange(options RangeOptions) RangeResponse {
 		})
 		if options.Limit != 0 && count > options.Limit {
 			response.KVs = response.KVs[:options.Limit]
+			response.More = true
 		}
 		response.Count = count
 	} else {
@@ -239,10 +317,15 @@ func (s EtcdState) getRange(options RangeOptions) RangeResponse {
 			response.KVs = append(response.KVs, KeyValue{
 				Key:           options.Start,
 				ValueRevision: value,
+				Lease:         s.KeyLeases[options.Start],
 			})
 			response.Count = 1
 		}
 	}
+	if options.CountOnly {
+		response.KVs = []KeyValue{}
+		response.More = false
+	}
 	return response
 }
 
@@ -267,28 +350,42 @@ const (
 	Txn         RequestType = "txn"
 	LeaseGrant  RequestType = "leaseGrant"
 	LeaseRevoke RequestType = "leaseRevoke"
-	Defragment  RequestType = "defragment"
-	Compact     RequestType = "compact"
+	// LeaseKeepAlive renews lease once.
+	LeaseKeepAlive RequestType = "leaseKeepAlive"
+	Defragment     RequestType = "defragment"
+	Compact        RequestType = "compact"
+	Snapshot       RequestType = "snapshot"
+	MoveLeader     RequestType = "moveLeader"
 )
 
 type EtcdRequest struct {
-	Type        RequestType
-	LeaseGrant  *LeaseGrantRequest
-	LeaseRevoke *LeaseRevokeRequest
-	Range       *RangeRequest
-	Txn         *TxnRequest
-	Defragment  *DefragmentRequest
-	Compact     *CompactRequest
+	Type           RequestType
+	LeaseGrant     *LeaseGrantRequest
+	LeaseRevoke    *LeaseRevokeRequest
+	LeaseKeepAlive *LeaseKeepAliveRequest
+	Range          *RangeRequest
+	Txn            *TxnRequest
+	Defragment     *DefragmentRequest
+	Compact        *CompactRequest
+	Snapshot       *SnapshotRequest
+	MoveLeader     *MoveLeaderRequest
 }
 
 func (r *EtcdRequest) IsRead() bool {
-	if r.Type == Range {
+	if r.Type == Range || r.Type == Snapshot {
 		return true
 	}
 	if r.Type != Txn {
 		return false
 	}
-	for _, op := range append(r.Txn.OperationsOnSuccess, r.Txn.OperationsOnFailure...) {
+	return r.Txn.isRead()
+}
+
+func (t *TxnRequest) isRead() bool {
+	for _, op := range append(t.OperationsOnSuccess, t.OperationsOnFailure...) {
+		if op.Type == TxnOperation && op.Txn.isRead() {
+			continue
+		}
 		if op.Type != RangeOperation {
 			return false
 		}
@@ -299,22 +396,46 @@ func (r *EtcdRequest) IsRead() bool {
 type RangeRequest struct {
 	RangeOptions
 	Revision int64
+	// Serializable range is served from local state of the contacted member,
+	// which might be behind the cluster.
+	Serializable bool
+}
+
+// IsLinearizable reports whether range observes the latest state of cluster.
+func (r RangeRequest) IsLinearizable() bool {
+	return r.Revision == 0 && !r.Serializable
 }
 
 type RangeOptions struct {
-	Start string
-	End   string
-	Limit int64
+	Start     string
+	End       string
+	Limit     int64
+	CountOnly bool
 }
 
 type PutOptions struct {
 	Key     string
 	Value   ValueOrHash
 	LeaseID int64
+	// PrevKV requests key-value from before the put in response.
+	PrevKV bool
 }
 
 type DeleteOptions struct {
 	Key string
+	// End is range end of deleted keys, the same as for RangeOptions.
+	// Empty End deletes only Key.
+	End string
+	// PrevKV requests deleted key-value in response, only supported for single key.
+	PrevKV bool
+}
+
+// Contains reports whether key is deleted by the operation.
+func (o DeleteOptions) Contains(key string) bool {
+	if o.End == "" {
+		return key == o.Key
+	}
+	return key >= o.Key && (o.End == FromKey || key < o.End)
 }
 
 type TxnRequest struct {
@@ -323,9 +444,95 @@ type TxnRequest struct {
 	OperationsOnFailure []EtcdOperation
 }
 
+// EtcdCondition compares target field of key against expected value. Zero
+// value of Target and Result is mod revision equality.
 type EtcdCondition struct {
-	Key              string
+	Key    string
+	Target CompareTarget
+	Result CompareResult
+	// ExpectedRevision is expected mod or create revision.
 	ExpectedRevision int64
+	ExpectedVersion  int64
+	ExpectedValue    ValueOrHash
+	ExpectedLease    int64
+}
+
+type CompareTarget string
+
+const (
+	CompareModRevision    CompareTarget = ""
+	CompareCreateRevision CompareTarget = "create"
+	CompareVersion        CompareTarget = "version"
+	CompareValue          CompareTarget = "value"
+	CompareLease          CompareTarget = "lease"
+)
+
+type CompareResult string
+
+const (
+	CompareEqual    CompareResult = ""
+	CompareNotEqual CompareResult = "!="
+	CompareLess     CompareResult = "<"
+	CompareGreater  CompareResult = ">"
+)
+
+// IsModRevisionEqual reports whether condition checks equality of mod revision.
+func (c EtcdCondition) IsModRevisionEqual() bool {
+	return c.Target == CompareModRevision && c.Result == CompareEqual
+}
+
+// ExecutedOperations returns operations executed by txn given its response,
+// flattening nested txns in the order etcd applies them.
+func (t *TxnRequest) ExecutedOperations(resp *TxnResponse) (ops []EtcdOperation) {
+	operations := t.OperationsOnSuccess
+	if resp.Failure {
+		operations = t.OperationsOnFailure
+	}
+	for i, op := range operations {
+		if op.Type != TxnOperation {
+			ops = append(ops, op)
+			continue
+		}
+		if i < len(resp.Results) && resp.Results[i].Txn != nil {
+			ops = append(ops, op.Txn.ExecutedOperations(resp.Results[i].Txn)...)
+		}
+	}
+	return ops
+}
+
+// ExecutedResults returns results of operations returned by ExecutedOperations, in the same order.
+// Results can be shorter than operations if response doesn't include all results.
+func (t *TxnRequest) ExecutedResults(resp *TxnResponse) (results []EtcdOperationResult) {
+	operations := t.OperationsOnSuccess
+	if resp.Failure {
+		operations = t.OperationsOnFailure
+	}
+	for i, op := range operations {
+		if i >= len(resp.Results) {
+			break
+		}
+		if op.Type != TxnOperation {
+			results = append(results, resp.Results[i])
+			continue
+		}
+		if resp.Results[i].Txn != nil {
+			results = append(results, op.Txn.ExecutedResults(resp.Results[i].Txn)...)
+		}
+	}
+	return results
+}
+
+// AllOperations returns operations of both branches of txn, flattening nested txns,
+// for txns which response is not known so either branch could have been executed.
+func (t *TxnRequest) AllOperations() (ops []EtcdOperation) {
+	for _, op := range append(append([]EtcdOperation{}, t.OperationsOnSuccess...), t.OperationsOnFailure...) {
+		if op.Type == TxnOperation {
+			ops = append(ops, op.Txn.AllOperations()...)
+			continue
+		}
+		ops = append(ops, op)
+	}
+	return ops
 }
 
 type EtcdOperation struct {
@@ -333,6 +540,7 @@ type EtcdOperation struct {
 	Range  RangeOptions
 	Put    PutOptions
 	Delete DeleteOptions
+	Txn    *TxnRequest
 }
 
 type OperationType string
@@ -341,6 +549,7 @@ const (
 	RangeOperation  OperationType = "range-operation"
 	PutOperation    OperationType = "put-operation"
 	DeleteOperation OperationType = "delete-operation"
+	TxnOperation    OperationType = "txn-operation"
 )
 
 type LeaseGrantRequest struct {
@@ -349,30 +558,60 @@ type LeaseGrantRequest struct {
 type LeaseRevokeRequest struct {
 	LeaseID int64
 }
+type LeaseKeepAliveRequest struct {
+	LeaseID int64
+}
 type DefragmentRequest struct{}
 
 // MaybeEtcdResponse extends EtcdResponse to represent partial or failed responses.
 // Possible states:
 // * Normal response. Only EtcdResponse is set.
 // * Partial response. The EtcdResponse.Revision and PartialResponse are set.
-// * Failed response. Only Err is set.
+// * Indeterminate response. The Error and Indeterminate are set. Request might have been persisted.
+// * Rejected response. Only Error is set. Request was not persisted.
+// Failure classifies the outcome, it is FailureNone only for successful responses.
 type MaybeEtcdResponse struct {
 	EtcdResponse
 	PartialResponse bool
+	Indeterminate   bool
 	Error           string
+	Failure         FailureReason
+	// CallDuration is how long the request took. Unlike operation return time,
+	// known also for failed requests.
+	CallDuration time.Duration
+	// Header of response returned by member that served the request,
+	// zero for failed requests and responses without header.
+	Header ResponseHeader
 }
 
+// FailureReason distinguishes why request failed, as it impacts how the outcome can be interpreted.
+type FailureReason string
+
+const (
+	// FailureNone means request succeeded.
+	FailureNone FailureReason = ""
+	// FailureServerError covers errors returned by server or transport.
+	FailureServerError FailureReason = "server-error"
+	// FailureDeadlineExceeded means client gave up on the request due to its deadline.
+	FailureDeadlineExceeded FailureReason = "deadline-exceeded"
+	// FailureCanceled means client canceled the request.
+	FailureCanceled FailureReason = "canceled"
+)
+
 var ErrEtcdFutureRev = errors.New("future rev")
 
 type EtcdResponse struct {
-	Txn         *TxnResponse
-	Range       *RangeResponse
-	LeaseGrant  *LeaseGrantReponse
-	LeaseRevoke *LeaseRevokeResponse
-	Defragment  *DefragmentResponse
-	Compact     *CompactResponse
-	ClientError string
-	Revision    int64
+	Txn            *TxnResponse
+	Range          *RangeResponse
+	LeaseGrant     *LeaseGrantReponse
+	LeaseRevoke    *LeaseRevokeResponse
+	LeaseKeepAlive *LeaseKeepAliveResponse
+	Defragment     *DefragmentResponse
+	Compact        *CompactResponse
+	Snapshot       *SnapshotResponse
+	MoveLeader     *MoveLeaderResponse
+	ClientError    string
+	Revision       int64
 }
 
 func Match(r1, r2 MaybeEtcdResponse) bool {
@@ -387,22 +626,31 @@ type TxnResponse struct {
 type RangeResponse struct {
 	KVs   []KeyValue
 	Count int64
+	More  bool
 }
 
 type LeaseGrantReponse struct {
 	LeaseID int64
 }
 type LeaseRevokeResponse struct{}
+type LeaseKeepAliveResponse struct{}
 type DefragmentResponse struct{}
 
 type EtcdOperationResult struct {
 	RangeResponse
 	Deleted int64
+	// PrevKV is key-value from before put or delete, nil if key didn't exist or
+	// it was not requested.
+	PrevKV *KeyValue
+	// Txn is response of nested txn operation.
+	Txn *TxnResponse
 }
 
 type KeyValue struct {
 	Key string
 	ValueRevision
+	// Lease is ID of the lease the key is attached to at read time, zero if none.
+	Lease int64
 }
 
 var leased = struct{}{}
@@ -419,6 +667,64 @@ func (el EtcdLease) DeepCopy() EtcdLease {
 	}
 }
 
+type KeyVersion struct {
+	CreateRevision int64
+	Version        int64
+}
+
+// evaluate returns result of txn condition the same way as etcd, comparing
+// fields of missing key as zero, except for value comparison which fails.
+func (s EtcdState) evaluate(cond EtcdCondition) bool {
+	val, exists := s.KeyValues[cond.Key]
+	var got, expected int64
+	switch cond.Target {
+	case CompareModRevision:
+		got, expected = val.ModRevision, cond.ExpectedRevision
+	case CompareCreateRevision:
+		got, expected = s.KeyVersions[cond.Key].CreateRevision, cond.ExpectedRevision
+	case CompareVersion:
+		got, expected = s.KeyVersions[cond.Key].Version, cond.ExpectedVersion
+	case CompareLease:
+		got, expected = s.KeyLeases[cond.Key], cond.ExpectedLease
+	case CompareValue:
+		if !exists {
+			return false
+		}
+		return compareResult(compareValues(val.Value, cond.ExpectedValue, cond.Result), cond.Result)
+	default:
+		panic(fmt.Sprintf("unsupported compare target %q", cond.Target))
+	}
+	return compareResult(cmp.Compare(got, expected), cond.Result)
+}
+
+func compareValues(got, expected ValueOrHash, result CompareResult) int {
+	if got.Hash == 0 && expected.Hash == 0 {
+		return strings.Compare(got.Value, expected.Value)
+	}
+	if result != CompareEqual && result != CompareNotEqual {
+		panic(fmt.Sprintf("unsupported compare result %q of hashed values", result))
+	}
+	if got == expected {
+		return 0
+	}
+	return 1
+}
+
+func compareResult(c int, result CompareResult) bool {
+	switch result {
+	case CompareEqual:
+		return c == 0
+	case CompareNotEqual:
+		return c != 0
+	case CompareLess:
+		return c < 0
+	case CompareGreater:
+		return c > 0
+	default:
+		panic(fmt.Sprintf("unsupported compare result %q", result))
+	}
+}
+
 type ValueRevision struct {
 	Value       ValueOrHash
 	ModRevision int64
@@ -446,4 +752,20 @@ type CompactResponse struct {
 
 type CompactRequest struct {
 	Revision int64
+	// Physical is set if client waited for compaction to be applied to the backend.
+	Physical bool
+}
+
+type SnapshotRequest struct{}
+
+type SnapshotResponse struct {
+	Size int64
+	// Revision is the revision of key-value store in the snapshot, read from the snapshot itself.
+	Revision int64
 }
+
+type MoveLeaderRequest struct {
+	TargetID uint64
+}
+
+type MoveLeaderResponse struct{}
@@ -21,6 +21,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
 
 func TestModelDeterministic(t *testing.T) {
@@ -108,15 +109,33 @@ var commonTestScenarios = []modelTestCase{
 				{Key: []byte("key2"), Value: []byte("2"), ModRevision: 3},
 				{Key: []byte("key3"), Value: []byte("3"), ModRevision: 4},
 			}, 3, 4)},
-			{req: listRequest("key", 2), resp: rangeResponse([]*mvccpb.KeyValue{
+			{req: listRequest("key", 2), resp: limitedRangeResponse([]*mvccpb.KeyValue{
 				{Key: []byte("key1"), Value: []byte("1"), ModRevision: 2},
 				{Key: []byte("key2"), Value: []byte("2"), ModRevision: 3},
 			}, 3, 4)},
-			{req: listRequest("key", 1), resp: rangeResponse([]*mvccpb.KeyValue{
+			{req: listRequest("key", 1), resp: limitedRangeResponse([]*mvccpb.KeyValue{
 				{Key: []byte("key1"), Value: []byte("1"), ModRevision: 2},
 			}, 3, 4)},
 		},
 	},
+	{
+		name: "Range from key should return all keys greater or equal to start",
+		operations: []testOperation{
+			{req: putRequest("key1", "1"), resp: putResponse(2)},
+			{req: putRequest("key2", "2"), resp: putResponse(3)},
+			{req: putRequest("lock", "3"), resp: putResponse(4)},
+			{req: rangeRequest("key2", "\x00", 0), resp: rangeResponse([]*mvccpb.KeyValue{
+				{Key: []byte("key2"), Value: []byte("2"), ModRevision: 3},
+				{Key: []byte("lock"), Value: []byte("3"), ModRevision: 4},
+			}, 2, 4)},
+			{req: rangeRequest("key2", "\x00", 1), resp: limitedRangeResponse([]*mvccpb.KeyValue{
+				{Key: []byte("key2"), Value: []byte("2"), ModRevision: 3},
+			}, 2, 4)},
+			{req: rangeRequest("key2", "lock", 0), resp: rangeResponse([]*mvccpb.KeyValue{
+				{Key: []byte("key2"), Value: []byte("2"), ModRevision: 3},
+			}, 1, 4)},
+		},
+	},
 	{
 		name: "Range response should be ordered by key",
 		operations: []testOperation{
@@ -233,6 +252,67 @@ var commonTestScenarios = []modelTestCase{
 			{req: getRequest("key"), resp: getResponse("key", "2", 3, 3)},
 		},
 	},
+	{
+		name: "Txn evaluates conditions on create revision, version, value and lease",
+		operations: []testOperation{
+			{req: putRequest("key", "1"), resp: putResponse(2)},
+			{req: putRequest("key", "2"), resp: putResponse(3)},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareCreateRevision, ExpectedRevision: 3}, putOperation("key", "3"), nil), resp: txnPutResponse(true, 4), expectFailure: true},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareCreateRevision, ExpectedRevision: 2}, putOperation("key", "3"), nil), resp: txnPutResponse(true, 4)},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareVersion, ExpectedVersion: 2}, putOperation("key", "4"), nil), resp: txnPutResponse(true, 5), expectFailure: true},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareVersion, Result: CompareGreater, ExpectedVersion: 2}, putOperation("key", "4"), nil), resp: txnPutResponse(true, 5)},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareValue, ExpectedValue: ToValueOrHash("3")}, putOperation("key", "5"), nil), resp: txnPutResponse(true, 6), expectFailure: true},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareValue, Result: CompareLess, ExpectedValue: ToValueOrHash("5")}, putOperation("key", "5"), nil), resp: txnPutResponse(true, 6)},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "missing", Target: CompareValue, Result: CompareNotEqual, ExpectedValue: ToValueOrHash("5")}, putOperation("key", "6"), nil), resp: txnPutResponse(true, 7), expectFailure: true},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "missing", Target: CompareValue, Result: CompareNotEqual, ExpectedValue: ToValueOrHash("5")}, putOperation("key", "6"), nil), resp: txnEmptyResponse(false, 6)},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareLease, Result: CompareNotEqual}, putOperation("key", "6"), nil), resp: txnPutResponse(true, 7), expectFailure: true},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareLease}, putOperation("key", "6"), nil), resp: txnPutResponse(true, 7)},
+			{req: deleteRequest("key"), resp: deleteResponse(1, 8)},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareVersion}, putOperation("key", "7"), nil), resp: txnPutResponse(true, 9)},
+			{req: txnRequestSingleOperation(&EtcdCondition{Key: "key", Target: CompareCreateRevision, ExpectedRevision: 9}, putOperation("key", "8"), nil), resp: txnPutResponse(true, 10)},
+		},
+	},
+	{
+		name: "Nested txn changes share revision of outer txn and evaluate conditions before it",
+		operations: []testOperation{
+			{req: putRequest("key", "1"), resp: putResponse(2)},
+			{req: txnRequest(nil, []EtcdOperation{*putOperation("a", "1"), nestedTxnOperation([]EtcdCondition{{Key: "key", ExpectedRevision: 2}}, []EtcdOperation{*putOperation("key", "2")}, nil)}, nil), resp: txnResponse([]EtcdOperationResult{{}, {Txn: &TxnResponse{Results: []EtcdOperationResult{{}}}}}, true, 4), expectFailure: true},
+			{req: txnRequest(nil, []EtcdOperation{*putOperation("a", "1"), nestedTxnOperation([]EtcdCondition{{Key: "key", ExpectedRevision: 2}}, []EtcdOperation{*putOperation("key", "2")}, nil)}, nil), resp: txnResponse([]EtcdOperationResult{{}, {Txn: &TxnResponse{Results: []EtcdOperationResult{{}}}}}, true, 3)},
+			{req: getRequest("key"), resp: getResponse("key", "2", 3, 3)},
+			{req: txnRequest(nil, []EtcdOperation{*putOperation("b", "1"), nestedTxnOperation([]EtcdCondition{{Key: "b", ExpectedRevision: 0}}, []EtcdOperation{*putOperation("c", "1")}, nil)}, nil), resp: txnResponse([]EtcdOperationResult{{}, {Txn: &TxnResponse{Failure: true, Results: []EtcdOperationResult{}}}}, true, 4), expectFailure: true},
+			{req: txnRequest(nil, []EtcdOperation{*putOperation("b", "1"), nestedTxnOperation([]EtcdCondition{{Key: "b", ExpectedRevision: 0}}, []EtcdOperation{*putOperation("c", "1")}, nil)}, nil), resp: txnResponse([]EtcdOperationResult{{}, {Txn: &TxnResponse{Results: []EtcdOperationResult{{}}}}}, true, 4)},
+			{req: getRequest("c"), resp: getResponse("c", "1", 4, 4)},
+			{req: txnRequest(nil, []EtcdOperation{nestedTxnOperation([]EtcdCondition{{Key: "c", ExpectedRevision: 3}}, nil, []EtcdOperation{{Type: RangeOperation, Range: RangeOptions{Start: "c"}}})}, nil), resp: txnResponse([]EtcdOperationResult{{Txn: &TxnResponse{Failure: true, Results: []EtcdOperationResult{{RangeResponse: RangeResponse{KVs: []KeyValue{{Key: "c", ValueRevision: ValueRevision{Value: ToValueOrHash("1"), ModRevision: 4}}}, Count: 1}}}}}}, true, 4)},
+		},
+	},
+	{
+		name: "Put and delete return previous key-value if requested",
+		operations: []testOperation{
+			{req: putWithPrevKVRequest("key", "1"), resp: putWithPrevKVResponse(&KeyValue{Key: "key"}, 2), expectFailure: true},
+			{req: putWithPrevKVRequest("key", "1"), resp: putWithPrevKVResponse(nil, 2)},
+			{req: putWithPrevKVRequest("key", "2"), resp: putWithPrevKVResponse(&KeyValue{Key: "key", ValueRevision: ValueRevision{Value: ToValueOrHash("2"), ModRevision: 2}}, 3), expectFailure: true},
+			{req: putWithPrevKVRequest("key", "2"), resp: putWithPrevKVResponse(&KeyValue{Key: "key", ValueRevision: ValueRevision{Value: ToValueOrHash("1"), ModRevision: 2}}, 3)},
+			{req: deleteWithPrevKVRequest("key"), resp: deleteWithPrevKVResponse(1, nil, 4), expectFailure: true},
+			{req: deleteWithPrevKVRequest("key"), resp: deleteWithPrevKVResponse(1, &KeyValue{Key: "key", ValueRevision: ValueRevision{Value: ToValueOrHash("2"), ModRevision: 3}}, 4)},
+			{req: deleteWithPrevKVRequest("key"), resp: deleteWithPrevKVResponse(0, nil, 4)},
+		},
+	},
+	{
+		name: "Delete range removes all keys in range at single revision",
+		operations: []testOperation{
+			{req: putRequest("a/1", "1"), resp: putResponse(2)},
+			{req: putRequest("a/2", "2"), resp: putResponse(3)},
+			{req: putRequest("b", "3"), resp: putResponse(4)},
+			{req: deleteRangeRequest("a/", "a0"), resp: deleteResponse(1, 5), expectFailure: true},
+			{req: deleteRangeRequest("a/", "a0"), resp: deleteResponse(2, 6), expectFailure: true},
+			{req: deleteRangeRequest("a/", "a0"), resp: deleteResponse(2, 5)},
+			{req: getRequest("a/1"), resp: emptyGetResponse(5)},
+			{req: getRequest("b"), resp: getResponse("b", "3", 4, 5)},
+			{req: deleteRangeRequest("a/", "a0"), resp: deleteResponse(0, 5)},
+			{req: deleteRangeRequest("", FromKey), resp: deleteResponse(1, 6)},
+			{req: getRequest("b"), resp: emptyGetResponse(6)},
+		},
+	},
 	{
 		name: "Txn can expect on key not existing",
 		operations: []testOperation{
@@ -262,7 +342,7 @@ var commonTestScenarios = []modelTestCase{
 			{req: leaseGrantRequest(1), resp: leaseGrantResponse(1)},
 			{req: putWithLeaseRequest("key", "2", 1), resp: putResponse(2)},
 			{req: putWithLeaseRequest("key", "3", 2), resp: putResponse(3), expectFailure: true},
-			{req: getRequest("key"), resp: getResponse("key", "2", 2, 2)},
+			{req: getRequest("key"), resp: getWithLeaseResponse("key", "2", 2, 2, 1)},
 		},
 	},
 	{
@@ -270,7 +350,7 @@ var commonTestScenarios = []modelTestCase{
 		operations: []testOperation{
 			{req: leaseGrantRequest(1), resp: leaseGrantResponse(1)},
 			{req: putWithLeaseRequest("key", "2", 1), resp: putResponse(2)},
-			{req: getRequest("key"), resp: getResponse("key", "2", 2, 2)},
+			{req: getRequest("key"), resp: getWithLeaseResponse("key", "2", 2, 2, 1)},
 			{req: leaseRevokeRequest(1), resp: leaseRevokeResponse(3)},
 			{req: putWithLeaseRequest("key", "4", 1), resp: putResponse(4), expectFailure: true},
 			{req: getRequest("key"), resp: emptyGetResponse(3)},
@@ -303,18 +383,29 @@ var commonTestScenarios = []modelTestCase{
 			{req: putWithLeaseRequest("key", "2", 1), resp: putResponse(2)},
 			{req: putWithLeaseRequest("key", "3", 2), resp: putResponse(3)},
 			{req: leaseRevokeRequest(1), resp: leaseRevokeResponse(3)},
-			{req: getRequest("key"), resp: getResponse("key", "3", 3, 3)},
+			{req: getRequest("key"), resp: getWithLeaseResponse("key", "3", 3, 3, 2)},
 			{req: leaseRevokeRequest(2), resp: leaseRevokeResponse(4)},
 			{req: getRequest("key"), resp: emptyGetResponse(4)},
 		},
 	},
+	{
+		name: "Keep alive should succeed only for existing lease",
+		operations: []testOperation{
+			{req: leaseKeepAliveRequest(1), resp: MaybeEtcdResponse{EtcdResponse: EtcdResponse{ClientError: rpctypes.ErrLeaseNotFound.Error()}}},
+			{req: leaseGrantRequest(1), resp: leaseGrantResponse(1)},
+			{req: leaseKeepAliveRequest(1), resp: leaseKeepAliveResponse(-1)},
+			{req: leaseKeepAliveRequest(1), resp: leaseKeepAliveResponse(1), expectFailure: true},
+			{req: leaseRevokeRequest(1), resp: leaseRevokeResponse(1)},
+			{req: leaseKeepAliveRequest(1), resp: leaseKeepAliveResponse(-1), expectFailure: true},
+		},
+	},
 	{
 		name: "Update key with same lease",
 		operations: []testOperation{
 			{req: leaseGrantRequest(1), resp: leaseGrantResponse(1)},
 			{req: putWithLeaseRequest("key", "2", 1), resp: putResponse(2)},
 			{req: putWithLeaseRequest("key", "3", 1), resp: putResponse(3)},
-			{req: getRequest("key"), resp: getResponse("key", "3", 3, 3)},
+			{req: getRequest("key"), resp: getWithLeaseResponse("key", "3", 3, 3, 1)},
 		},
 	},
 	{
@@ -405,4 +496,46 @@ var commonTestScenarios = []modelTestCase{
 			{req: defragmentRequest(), resp: defragmentResponse(6)},
 		},
 	},
+	{
+		name: "Snapshot success between all other request types",
+		operations: []testOperation{
+			{req: snapshotRequest(), resp: snapshotResponse(100, 1)},
+			{req: putRequest("key", "1"), resp: putResponse(2)},
+			{req: snapshotRequest(), resp: snapshotResponse(200, 2)},
+			{req: getRequest("key"), resp: getResponse("key", "1", 2, 2)},
+			{req: snapshotRequest(), resp: snapshotResponse(200, 2)},
+			{req: deleteRequest("key"), resp: deleteResponse(1, 3)},
+			{req: snapshotRequest(), resp: MaybeEtcdResponse{EtcdResponse: EtcdResponse{Snapshot: &SnapshotResponse{Size: 200}, Revision: 3}}, expectFailure: true},
+		},
+	},
+}
+
+func TestTxnRequestExecutedResults(t *testing.T) {
+	rangeResult := EtcdOperationResult{RangeResponse: RangeResponse{KVs: []KeyValue{{Key: "c", ValueRevision: ValueRevision{Value: ToValueOrHash("1"), ModRevision: 4}}}, Count: 1}}
+	deleteResult := EtcdOperationResult{Deleted: 1}
+	request := &TxnRequest{
+		OperationsOnSuccess: []EtcdOperation{
+			*putOperation("a", "1"),
+			nestedTxnOperation(nil, []EtcdOperation{*putOperation("b", "1")}, []EtcdOperation{{Type: RangeOperation, Range: RangeOptions{Start: "c"}}}),
+			{Type: DeleteOperation, Delete: DeleteOptions{Key: "d"}},
+		},
+	}
+	response := &TxnResponse{Results: []EtcdOperationResult{{}, {Txn: &TxnResponse{Failure: true, Results: []EtcdOperationResult{rangeResult}}}, deleteResult}}
+
+	wantOps := []EtcdOperation{*putOperation("a", "1"), {Type: RangeOperation, Range: RangeOptions{Start: "c"}}, {Type: DeleteOperation, Delete: DeleteOptions{Key: "d"}}}
+	if diff := cmp.Diff(wantOps, request.ExecutedOperations(response)); diff != "" {
+		t.Errorf("ExecutedOperations(...) mismatch (-want +got):\n%s", diff)
+	}
+	wantResults := []EtcdOperationResult{{}, rangeResult, deleteResult}
+	if diff := cmp.Diff(wantResults, request.ExecutedResults(response)); diff != "" {
+		t.Errorf("ExecutedResults(...) mismatch (-want +got):\n%s", diff)
+	}
+	wantAll := []EtcdOperation{*putOperation("a", "1"), *putOperation("b", "1"), {Type: RangeOperation, Range: RangeOptions{Start: "c"}}, {Type: DeleteOperation, Delete: DeleteOptions{Key: "d"}}}
+	if diff := cmp.Diff(wantAll, request.AllOperations()); diff != "" {
+		t.Errorf("AllOperations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func nestedTxnOperation(conds []EtcdCondition, onSuccess []EtcdOperation, onFailure []EtcdOperation) EtcdOperation {
+	return EtcdOperation{Type: TxnOperation, Txn: &TxnRequest{Conditions: conds, OperationsOnSuccess: onSuccess, OperationsOnFailure: onFailure}}
 }
@@ -0,0 +1,136 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"sort"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// HashKV computes hash of key-value store that etcd returns from HashKV at revision rev,
+// after applying persisted requests. Content of key bucket is reconstructed from requests, so
+// histories with compaction or with values recorded only as hashes are not supported.
+func HashKV(persistedRequests []EtcdRequest, rev int64) (uint32, error) {
+	h := kvBucketHasher{
+		hash: crc32.New(crc32.MakeTable(crc32.Castagnoli)),
+		kvs:  map[string]mvccpb.KeyValue{},
+	}
+	h.hash.Write(schema.Key.Name())
+	state := freshEtcdState()
+	for _, request := range persistedRequests {
+		if request.Type == Compact {
+			return 0, errors.New("hashKV of history with compaction is not supported")
+		}
+		newState, response := state.Step(request)
+		if newState.Revision > rev {
+			break
+		}
+		if newState.Revision != state.Revision {
+			if err := h.writeChanges(state, request, response); err != nil {
+				return 0, err
+			}
+		}
+		state = newState
+	}
+	if state.Revision != rev {
+		return 0, fmt.Errorf("history ends at revision %d, before requested revision %d", state.Revision, rev)
+	}
+	return h.hash.Sum32(), nil
+}
+
+// kvBucketHasher hashes entries of key bucket the same way as mvcc, tracking live keys
+// to know their create revision and version.
+type kvBucketHasher struct {
+	hash hash.Hash32
+	kvs  map[string]mvccpb.KeyValue
+}
+
+func (h *kvBucketHasher) writeChanges(prevState EtcdState, request EtcdRequest, response MaybeEtcdResponse) error {
+	var sub int64
+	switch request.Type {
+	case Txn:
+		for _, op := range request.Txn.ExecutedOperations(response.Txn) {
+			switch op.Type {
+			case PutOperation:
+				if _, leaseExists := prevState.Leases[op.Put.LeaseID]; op.Put.LeaseID != 0 && !leaseExists {
+					continue
+				}
+				if op.Put.Value.Hash != 0 {
+					return fmt.Errorf("value of key %q at revision %d is recorded only as hash", op.Put.Key, response.Revision)
+				}
+				kv, ok := h.kvs[op.Put.Key]
+				if !ok {
+					kv = mvccpb.KeyValue{Key: []byte(op.Put.Key), CreateRevision: response.Revision}
+				}
+				kv.ModRevision = response.Revision
+				kv.Version++
+				kv.Value = []byte(op.Put.Value.Value)
+				kv.Lease = op.Put.LeaseID
+				h.kvs[op.Put.Key] = kv
+				h.write(mvcc.Revision{Main: response.Revision, Sub: sub}, false, kv)
+				sub++
+			case DeleteOperation:
+				var keys []string
+				for key := range h.kvs {
+					if op.Delete.Contains(key) {
+						keys = append(keys, key)
+					}
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					delete(h.kvs, key)
+					h.write(mvcc.Revision{Main: response.Revision, Sub: sub}, true, mvccpb.KeyValue{Key: []byte(key)})
+					sub++
+				}
+			}
+		}
+	case LeaseRevoke:
+		// Lessor deletes keys attached to lease in sorted order.
+		var keys []string
+		for key := range prevState.Leases[request.LeaseRevoke.LeaseID].Keys {
+			if _, ok := h.kvs[key]; ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			delete(h.kvs, key)
+			h.write(mvcc.Revision{Main: response.Revision, Sub: sub}, true, mvccpb.KeyValue{Key: []byte(key)})
+			sub++
+		}
+	}
+	return nil
+}
+
+func (h *kvBucketHasher) write(rev mvcc.Revision, tombstone bool, kv mvccpb.KeyValue) {
+	key := mvcc.RevToBytes(rev, mvcc.NewRevBytes())
+	if tombstone {
+		// Same mark as mvcc appends to revision of deleted keys.
+		key = append(key, 't')
+	}
+	value, err := kv.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	h.hash.Write(key)
+	h.hash.Write(value)
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashKV(t *testing.T) {
+	requests := []EtcdRequest{
+		putRequest("a", "1"),
+		putRequest("b", "2"),
+		deleteRequest("a"),
+	}
+	hash, err := HashKV(requests, 4)
+	require.NoError(t, err)
+	sameHash, err := HashKV(append(requests, putRequest("c", "3")), 4)
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash, "requests after revision should not change hash")
+	previousHash, err := HashKV(requests, 3)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, previousHash)
+
+	_, err = HashKV(requests, 5)
+	require.ErrorContains(t, err, "history ends at revision 4")
+	_, err = HashKV(append([]EtcdRequest{compactRequest(1, false)}, requests...), 4)
+	require.ErrorContains(t, err, "compaction is not supported")
+	_, err = HashKV([]EtcdRequest{putRequest("a", strings.Repeat("a", 100))}, 2)
+	require.ErrorContains(t, err, "recorded only as hash")
+}
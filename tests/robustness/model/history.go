@@ -15,14 +15,19 @@
 package model
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/anishathalye/porcupine"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/tests/v3/robustness/identity"
@@ -57,15 +62,55 @@ func NewAppendableHistory(ids identity.Provider) *AppendableHistory {
 
 func (h *AppendableHistory) AppendRange(startKey, endKey string, revision, limit int64, start, end time.Duration, resp *clientv3.GetResponse, err error) {
 	request := staleRangeRequest(startKey, endKey, limit, revision)
+	if err != nil {
+		if strings.Contains(err.Error(), mvcc.ErrCompacted.Error()) {
+			h.appendSuccessful(request, start, end, MaybeEtcdResponse{
+				EtcdResponse: EtcdResponse{ClientError: mvcc.ErrCompacted.Error()},
+			}, nil)
+			return
+		}
+		h.appendFailed(request, start, end, err)
+		return
+	}
+	var respRevision int64
+	var header *etcdserverpb.ResponseHeader
+	if resp != nil && resp.Header != nil {
+		respRevision = resp.Header.Revision
+		header = resp.Header
+	}
+	response := rangeResponse(resp.Kvs, resp.Count, respRevision)
+	response.Range.More = resp.More
+	h.appendSuccessful(request, start, end, response, header)
+}
+
+func (h *AppendableHistory) AppendSerializableGet(key string, start, end time.Duration, resp *clientv3.GetResponse, err error) {
+	request := serializableGetRequest(key)
+	if err != nil {
+		h.appendFailed(request, start, end, err)
+		return
+	}
+	var respRevision int64
+	var header *etcdserverpb.ResponseHeader
+	if resp != nil && resp.Header != nil {
+		respRevision = resp.Header.Revision
+		header = resp.Header
+	}
+	h.appendSuccessful(request, start, end, rangeResponse(resp.Kvs, resp.Count, respRevision), header)
+}
+
+func (h *AppendableHistory) AppendCount(startKey, endKey string, start, end time.Duration, resp *clientv3.GetResponse, err error) {
+	request := countRequest(startKey, endKey)
 	if err != nil {
 		h.appendFailed(request, start, end, err)
 		return
 	}
 	var respRevision int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.Header != nil {
 		respRevision = resp.Header.Revision
+		header = resp.Header
 	}
-	h.appendSuccessful(request, start, end, rangeResponse(resp.Kvs, resp.Count, respRevision))
+	h.appendSuccessful(request, start, end, rangeResponse(resp.Kvs, resp.Count, respRevision), header)
 }
 
 func (h *AppendableHistory) AppendPut(key, value string, start, end time.Duration, resp *clientv3.PutResponse, err error) {
@@ -75,10 +120,12 @@ func (h *AppendableHistory) AppendPut(key, value string, start, end time.Duratio
 		return
 	}
 	var revision int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.Header != nil {
 		revision = resp.Header.Revision
+		header = resp.Header
 	}
-	h.appendSuccessful(request, start, end, putResponse(revision))
+	h.appendSuccessful(request, start, end, putResponse(revision), header)
 }
 
 func (h *AppendableHistory) AppendPutWithLease(key, value string, leaseID int64, start, end time.Duration, resp *clientv3.PutResponse, err error) {
@@ -88,10 +135,29 @@ func (h *AppendableHistory) AppendPutWithLease(key, value string, leaseID int64,
 		return
 	}
 	var revision int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.Header != nil {
 		revision = resp.Header.Revision
+		header = resp.Header
 	}
-	h.appendSuccessful(request, start, end, putResponse(revision))
+	h.appendSuccessful(request, start, end, putResponse(revision), header)
+}
+
+func (h *AppendableHistory) AppendPutWithPrevKV(key, value string, start, end time.Duration, resp *clientv3.PutResponse, err error) {
+	request := putWithPrevKVRequest(key, value)
+	if err != nil {
+		h.appendFailed(request, start, end, err)
+		return
+	}
+	var revision int64
+	var prevKV *KeyValue
+	var header *etcdserverpb.ResponseHeader
+	if resp != nil && resp.Header != nil {
+		revision = resp.Header.Revision
+		prevKV = toPrevKV(resp.PrevKv)
+		header = resp.Header
+	}
+	h.appendSuccessful(request, start, end, putWithPrevKVResponse(prevKV, revision), header)
 }
 
 func (h *AppendableHistory) AppendLeaseGrant(start, end time.Duration, resp *clientv3.LeaseGrantResponse, err error) {
@@ -105,10 +171,12 @@ func (h *AppendableHistory) AppendLeaseGrant(start, end time.Duration, resp *cli
 		return
 	}
 	var revision int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.ResponseHeader != nil {
 		revision = resp.ResponseHeader.Revision
+		header = resp.ResponseHeader
 	}
-	h.appendSuccessful(request, start, end, leaseGrantResponse(revision))
+	h.appendSuccessful(request, start, end, leaseGrantResponse(revision), header)
 }
 
 func (h *AppendableHistory) AppendLeaseRevoke(id int64, start, end time.Duration, resp *clientv3.LeaseRevokeResponse, err error) {
@@ -118,10 +186,32 @@ func (h *AppendableHistory) AppendLeaseRevoke(id int64, start, end time.Duration
 		return
 	}
 	var revision int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.Header != nil {
 		revision = resp.Header.Revision
+		header = resp.Header
+	}
+	h.appendSuccessful(request, start, end, leaseRevokeResponse(revision), header)
+}
+
+func (h *AppendableHistory) AppendLeaseKeepAliveOnce(id int64, start, end time.Duration, resp *clientv3.LeaseKeepAliveResponse, err error) {
+	request := leaseKeepAliveRequest(id)
+	if err != nil {
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			h.appendSuccessful(request, start, end, MaybeEtcdResponse{
+				EtcdResponse: EtcdResponse{ClientError: rpctypes.ErrLeaseNotFound.Error()},
+			}, nil)
+			return
+		}
+		h.appendFailed(request, start, end, err)
+		return
+	}
+	var header *etcdserverpb.ResponseHeader
+	if resp != nil {
+		header = resp.ResponseHeader
 	}
-	h.appendSuccessful(request, start, end, leaseRevokeResponse(revision))
+	// Set fake revision as keep alive is served by leader lessor without going through raft.
+	h.appendSuccessful(request, start, end, leaseKeepAliveResponse(-1), header)
 }
 
 func (h *AppendableHistory) AppendDelete(key string, start, end time.Duration, resp *clientv3.DeleteResponse, err error) {
@@ -132,18 +222,70 @@ func (h *AppendableHistory) AppendDelete(key string, start, end time.Duration, r
 	}
 	var revision int64
 	var deleted int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.Header != nil {
 		revision = resp.Header.Revision
 		deleted = resp.Deleted
+		header = resp.Header
 	}
-	h.appendSuccessful(request, start, end, deleteResponse(deleted, revision))
+	h.appendSuccessful(request, start, end, deleteResponse(deleted, revision), header)
 }
 
-func (h *AppendableHistory) AppendTxn(cmp []clientv3.Cmp, clientOnSuccessOps, clientOnFailure []clientv3.Op, start, end time.Duration, resp *clientv3.TxnResponse, err error) {
-	conds := []EtcdCondition{}
-	for _, cmp := range cmp {
-		conds = append(conds, toEtcdCondition(cmp))
+func (h *AppendableHistory) AppendDeleteRange(start, end string, callTime, returnTime time.Duration, resp *clientv3.DeleteResponse, err error) {
+	request := deleteRangeRequest(start, end)
+	if err != nil {
+		h.appendFailed(request, callTime, returnTime, err)
+		return
+	}
+	var revision int64
+	var deleted int64
+	var header *etcdserverpb.ResponseHeader
+	if resp != nil && resp.Header != nil {
+		revision = resp.Header.Revision
+		deleted = resp.Deleted
+		header = resp.Header
+	}
+	h.appendSuccessful(request, callTime, returnTime, deleteResponse(deleted, revision), header)
+}
+
+func (h *AppendableHistory) AppendDeleteWithPrevKV(key string, start, end time.Duration, resp *clientv3.DeleteResponse, err error) {
+	request := deleteWithPrevKVRequest(key)
+	if err != nil {
+		h.appendFailed(request, start, end, err)
+		return
+	}
+	var revision int64
+	var deleted int64
+	var prevKV *KeyValue
+	var header *etcdserverpb.ResponseHeader
+	if resp != nil && resp.Header != nil {
+		revision = resp.Header.Revision
+		deleted = resp.Deleted
+		if len(resp.PrevKvs) != 0 {
+			prevKV = toPrevKV(resp.PrevKvs[0])
+		}
+		header = resp.Header
+	}
+	h.appendSuccessful(request, start, end, deleteWithPrevKVResponse(deleted, prevKV, revision), header)
+}
+
+// toPrevKV converts previous key-value returned by put or delete, keeping nil
+// for key that didn't exist.
+func toPrevKV(kv *mvccpb.KeyValue) *KeyValue {
+	if kv == nil {
+		return nil
 	}
+	return &KeyValue{
+		Key: string(kv.Key),
+		ValueRevision: ValueRevision{
+			Value:       ToValueOrHash(string(kv.Value)),
+			ModRevision: kv.ModRevision,
+		},
+		Lease: kv.Lease,
+	}
+}
+
+func (h *AppendableHistory) AppendTxn(cmp []clientv3.Cmp, clientOnSuccessOps, clientOnFailure []clientv3.Op, start, end time.Duration, resp *clientv3.TxnResponse, err error) {
 	modelOnSuccess := []EtcdOperation{}
 	for _, op := range clientOnSuccessOps {
 		modelOnSuccess = append(modelOnSuccess, toEtcdOperation(op))
@@ -152,23 +294,44 @@ func (h *AppendableHistory) AppendTxn(cmp []clientv3.Cmp, clientOnSuccessOps, cl
 	for _, op := range clientOnFailure {
 		modelOnFailure = append(modelOnFailure, toEtcdOperation(op))
 	}
+	h.appendTxn(cmp, modelOnSuccess, modelOnFailure, start, end, resp, err)
+}
+
+// AppendTxnPutWithLease records a transaction that puts key attached to the lease if conditions are met.
+// Lease of puts passed to AppendTxn is not recorded, as clientv3.Op doesn't expose it.
+func (h *AppendableHistory) AppendTxnPutWithLease(cmp []clientv3.Cmp, key, value string, leaseID int64, start, end time.Duration, resp *clientv3.TxnResponse, err error) {
+	onSuccess := []EtcdOperation{
+		{Type: PutOperation, Put: PutOptions{Key: key, Value: ToValueOrHash(value), LeaseID: leaseID}},
+	}
+	h.appendTxn(cmp, onSuccess, []EtcdOperation{}, start, end, resp, err)
+}
+
+func (h *AppendableHistory) appendTxn(cmp []clientv3.Cmp, modelOnSuccess, modelOnFailure []EtcdOperation, start, end time.Duration, resp *clientv3.TxnResponse, err error) {
+	conds := []EtcdCondition{}
+	for _, cmp := range cmp {
+		conds = append(conds, toEtcdCondition(cmp))
+	}
 	request := txnRequest(conds, modelOnSuccess, modelOnFailure)
 	if err != nil {
 		h.appendFailed(request, start, end, err)
 		return
 	}
 	var revision int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.Header != nil {
 		revision = resp.Header.Revision
+		header = resp.Header
 	}
 	results := []EtcdOperationResult{}
 	for _, resp := range resp.Responses {
 		results = append(results, toEtcdOperationResult(resp))
 	}
-	h.appendSuccessful(request, start, end, txnResponse(results, resp.Succeeded, revision))
+	h.appendSuccessful(request, start, end, txnResponse(results, resp.Succeeded, revision), header)
 }
 
-func (h *AppendableHistory) appendSuccessful(request EtcdRequest, start, end time.Duration, response MaybeEtcdResponse) {
+func (h *AppendableHistory) appendSuccessful(request EtcdRequest, start, end time.Duration, response MaybeEtcdResponse, header *etcdserverpb.ResponseHeader) {
+	response.CallDuration = end - start
+	response.Header = toResponseHeader(header)
 	op := porcupine.Operation{
 		ClientId: h.streamID,
 		Input:    request,
@@ -179,19 +342,56 @@ func (h *AppendableHistory) appendSuccessful(request EtcdRequest, start, end tim
 	h.append(op)
 }
 
-func toEtcdCondition(cmp clientv3.Cmp) (cond EtcdCondition) {
-	switch {
-	case cmp.Result == etcdserverpb.Compare_EQUAL && cmp.Target == etcdserverpb.Compare_MOD:
-		cond.Key = string(cmp.KeyBytes())
-	case cmp.Result == etcdserverpb.Compare_EQUAL && cmp.Target == etcdserverpb.Compare_CREATE:
-		cond.Key = string(cmp.KeyBytes())
-	default:
-		panic(fmt.Sprintf("Compare not supported, target: %q, result: %q", cmp.Target, cmp.Result))
+// toEtcdCondition converts compare generated by traffic, which is expected to always be supported.
+func toEtcdCondition(cmp clientv3.Cmp) EtcdCondition {
+	cond, err := ToEtcdCondition((*etcdserverpb.Compare)(&cmp))
+	if err != nil {
+		panic(err)
 	}
-	cond.ExpectedRevision = cmp.TargetUnion.(*etcdserverpb.Compare_ModRevision).ModRevision
 	return cond
 }
 
+// ToEtcdCondition converts compare of a single key to txn condition.
+// Returns error for compares of range and unsupported targets or results.
+func ToEtcdCondition(cmp *etcdserverpb.Compare) (cond EtcdCondition, err error) {
+	if len(cmp.RangeEnd) != 0 {
+		return cond, fmt.Errorf("compare of range not supported, key: %q, range end: %q", cmp.Key, cmp.RangeEnd)
+	}
+	cond.Key = string(cmp.Key)
+	switch cmp.Result {
+	case etcdserverpb.Compare_EQUAL:
+		cond.Result = CompareEqual
+	case etcdserverpb.Compare_NOT_EQUAL:
+		cond.Result = CompareNotEqual
+	case etcdserverpb.Compare_LESS:
+		cond.Result = CompareLess
+	case etcdserverpb.Compare_GREATER:
+		cond.Result = CompareGreater
+	default:
+		return cond, fmt.Errorf("compare not supported, target: %q, result: %q", cmp.Target, cmp.Result)
+	}
+	switch cmp.Target {
+	case etcdserverpb.Compare_MOD:
+		cond.Target = CompareModRevision
+		cond.ExpectedRevision = cmp.GetModRevision()
+	case etcdserverpb.Compare_CREATE:
+		cond.Target = CompareCreateRevision
+		cond.ExpectedRevision = cmp.GetCreateRevision()
+	case etcdserverpb.Compare_VERSION:
+		cond.Target = CompareVersion
+		cond.ExpectedVersion = cmp.GetVersion()
+	case etcdserverpb.Compare_VALUE:
+		cond.Target = CompareValue
+		cond.ExpectedValue = ToValueOrHash(string(cmp.GetValue()))
+	case etcdserverpb.Compare_LEASE:
+		cond.Target = CompareLease
+		cond.ExpectedLease = cmp.GetLease()
+	default:
+		return cond, fmt.Errorf("compare not supported, target: %q, result: %q", cmp.Target, cmp.Result)
+	}
+	return cond, nil
+}
+
 func toEtcdOperation(option clientv3.Op) (op EtcdOperation) {
 	switch {
 	case option.IsGet():
@@ -204,13 +404,32 @@ func toEtcdOperation(option clientv3.Op) (op EtcdOperation) {
 		op.Type = PutOperation
 		op.Put = PutOptions{
 			Key:   string(option.KeyBytes()),
-			Value: ValueOrHash{Value: string(option.ValueBytes())},
+			Value: ToValueOrHash(string(option.ValueBytes())),
 		}
 	case option.IsDelete():
 		op.Type = DeleteOperation
 		op.Delete = DeleteOptions{
 			Key: string(option.KeyBytes()),
+			End: string(option.RangeBytes()),
+		}
+	case option.IsTxn():
+		cmps, thenOps, elseOps := option.Txn()
+		txn := &TxnRequest{
+			Conditions:          []EtcdCondition{},
+			OperationsOnSuccess: []EtcdOperation{},
+			OperationsOnFailure: []EtcdOperation{},
+		}
+		for _, cmp := range cmps {
+			txn.Conditions = append(txn.Conditions, toEtcdCondition(cmp))
+		}
+		for _, thenOp := range thenOps {
+			txn.OperationsOnSuccess = append(txn.OperationsOnSuccess, toEtcdOperation(thenOp))
+		}
+		for _, elseOp := range elseOps {
+			txn.OperationsOnFailure = append(txn.OperationsOnFailure, toEtcdOperation(elseOp))
 		}
+		op.Type = TxnOperation
+		op.Txn = txn
 	default:
 		panic("Unsupported operation")
 	}
@@ -229,12 +448,14 @@ func toEtcdOperationResult(resp *etcdserverpb.ResponseOp) EtcdOperationResult {
 					Value:       ToValueOrHash(string(kv.Value)),
 					ModRevision: kv.ModRevision,
 				},
+				Lease: kv.Lease,
 			}
 		}
 		return EtcdOperationResult{
 			RangeResponse: RangeResponse{
 				KVs:   kvs,
 				Count: getResp.Count,
+				More:  getResp.More,
 			},
 		}
 	case resp.GetResponsePut() != nil:
@@ -243,6 +464,15 @@ func toEtcdOperationResult(resp *etcdserverpb.ResponseOp) EtcdOperationResult {
 		return EtcdOperationResult{
 			Deleted: resp.GetResponseDeleteRange().Deleted,
 		}
+	case resp.GetResponseTxn() != nil:
+		txnResp := resp.GetResponseTxn()
+		results := []EtcdOperationResult{}
+		for _, resp := range txnResp.Responses {
+			results = append(results, toEtcdOperationResult(resp))
+		}
+		return EtcdOperationResult{
+			Txn: &TxnResponse{Failure: !txnResp.Succeeded, Results: results},
+		}
 	default:
 		panic("Unsupported operation")
 	}
@@ -255,39 +485,71 @@ func (h *AppendableHistory) AppendDefragment(start, end time.Duration, resp *cli
 		return
 	}
 	var revision int64
+	var header *etcdserverpb.ResponseHeader
 	if resp != nil && resp.Header != nil {
 		revision = resp.Header.Revision
+		header = resp.Header
 	}
-	h.appendSuccessful(request, start, end, defragmentResponse(revision))
+	h.appendSuccessful(request, start, end, defragmentResponse(revision), header)
 }
 
-func (h *AppendableHistory) AppendCompact(rev int64, start, end time.Duration, resp *clientv3.CompactResponse, err error) {
-	request := compactRequest(rev)
+func (h *AppendableHistory) AppendCompact(rev int64, physical bool, start, end time.Duration, resp *clientv3.CompactResponse, err error) {
+	request := compactRequest(rev, physical)
 	if err != nil {
 		if strings.Contains(err.Error(), mvcc.ErrCompacted.Error()) {
 			h.appendSuccessful(request, start, end, MaybeEtcdResponse{
 				EtcdResponse: EtcdResponse{ClientError: mvcc.ErrCompacted.Error()},
-			})
+			}, nil)
 			return
 		}
 		h.appendFailed(request, start, end, err)
 		return
 	}
+	var header *etcdserverpb.ResponseHeader
+	if resp != nil {
+		header = resp.Header
+	}
 	// Set fake revision as compaction returns non-linearizable revision.
 	// TODO: Model non-linearizable response revision in model.
-	h.appendSuccessful(request, start, end, compactResponse(-1))
+	h.appendSuccessful(request, start, end, compactResponse(-1), header)
+}
+
+func (h *AppendableHistory) AppendSnapshot(start, end time.Duration, size, revision int64, err error) {
+	request := snapshotRequest()
+	if err != nil {
+		h.appendFailed(request, start, end, err)
+		return
+	}
+	// Set fake response revision as snapshot stream doesn't return revision.
+	// Revision the snapshot was taken at is recorded separately in the snapshot response.
+	h.appendSuccessful(request, start, end, snapshotResponse(size, revision), nil)
+}
+
+func (h *AppendableHistory) AppendMoveLeader(targetID uint64, start, end time.Duration, err error) {
+	request := moveLeaderRequest(targetID)
+	if err != nil {
+		h.appendFailed(request, start, end, err)
+		return
+	}
+	h.appendSuccessful(request, start, end, moveLeaderResponse(), nil)
 }
 
 func (h *AppendableHistory) appendFailed(request EtcdRequest, start, end time.Duration, err error) {
+	response := failedResponse(err)
+	// Client retries idempotent requests, so only transactions can be considered rejected.
+	if request.Type == Txn && isRejected(err) {
+		response = rejectedResponse(err)
+	}
+	response.CallDuration = end - start
 	op := porcupine.Operation{
 		ClientId: h.streamID,
 		Input:    request,
 		Call:     start.Nanoseconds(),
-		Output:   failedResponse(err),
+		Output:   response,
 		Return:   end.Nanoseconds(),
 	}
 	isRead := request.IsRead()
-	if !isRead {
+	if !isRead && response.Indeterminate {
 		// Failed writes can still be persisted, setting -1 for now as don't know when request has took effect.
 		op.Return = -1
 		// Operations of single client needs to be sequential.
@@ -333,10 +595,20 @@ func staleListRequest(key string, limit, revision int64) EtcdRequest {
 	return staleRangeRequest(key, clientv3.GetPrefixRangeEnd(key), limit, revision)
 }
 
+func serializableGetRequest(key string) EtcdRequest {
+	return EtcdRequest{Type: Range, Range: &RangeRequest{RangeOptions: RangeOptions{Start: key}, Serializable: true}}
+}
+
 func staleRangeRequest(start, end string, limit, revision int64) EtcdRequest {
 	return EtcdRequest{Type: Range, Range: &RangeRequest{RangeOptions: RangeOptions{Start: start, End: end, Limit: limit}, Revision: revision}}
 }
 
+func countRequest(start, end string) EtcdRequest {
+	request := rangeRequest(start, end, 0)
+	request.Range.CountOnly = true
+	return request
+}
+
 func emptyGetResponse(revision int64) MaybeEtcdResponse {
 	return rangeResponse([]*mvccpb.KeyValue{}, 0, revision)
 }
@@ -345,6 +617,10 @@ func getResponse(key, value string, modRevision, revision int64) MaybeEtcdRespon
 	return rangeResponse([]*mvccpb.KeyValue{{Key: []byte(key), Value: []byte(value), ModRevision: modRevision}}, 1, revision)
 }
 
+func getWithLeaseResponse(key, value string, modRevision, revision, leaseID int64) MaybeEtcdResponse {
+	return rangeResponse([]*mvccpb.KeyValue{{Key: []byte(key), Value: []byte(value), ModRevision: modRevision, Lease: leaseID}}, 1, revision)
+}
+
 func rangeResponse(kvs []*mvccpb.KeyValue, count int64, revision int64) MaybeEtcdResponse {
 	result := RangeResponse{KVs: make([]KeyValue, len(kvs)), Count: count}
 
@@ -355,13 +631,65 @@ func rangeResponse(kvs []*mvccpb.KeyValue, count int64, revision int64) MaybeEtc
 				Value:       ToValueOrHash(string(kv.Value)),
 				ModRevision: kv.ModRevision,
 			},
+			Lease: kv.Lease,
 		}
 	}
 	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Range: &result, Revision: revision}}
 }
 
+func limitedRangeResponse(kvs []*mvccpb.KeyValue, count int64, revision int64) MaybeEtcdResponse {
+	resp := rangeResponse(kvs, count, revision)
+	resp.Range.More = true
+	return resp
+}
+
 func failedResponse(err error) MaybeEtcdResponse {
-	return MaybeEtcdResponse{Error: err.Error()}
+	return MaybeEtcdResponse{Error: err.Error(), Indeterminate: true, Failure: failureReason(err)}
+}
+
+func rejectedResponse(err error) MaybeEtcdResponse {
+	return MaybeEtcdResponse{Error: err.Error(), Failure: failureReason(err)}
+}
+
+// failureReason classifies error as deadline exceeded, cancellation or server error.
+func failureReason(err error) FailureReason {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return FailureDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return FailureCanceled
+	}
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		return FailureDeadlineExceeded
+	case codes.Canceled:
+		return FailureCanceled
+	default:
+		return FailureServerError
+	}
+}
+
+// isRejected returns true if error guarantees that request was rejected before being persisted.
+// Timeouts, connection errors and unknown errors leave request outcome indeterminate.
+func isRejected(err error) bool {
+	var code codes.Code
+	var etcdErr rpctypes.EtcdError
+	if errors.As(err, &etcdErr) {
+		code = etcdErr.Code()
+	} else {
+		s, ok := status.FromError(err)
+		if !ok {
+			return false
+		}
+		code = s.Code()
+	}
+	switch code {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.FailedPrecondition, codes.OutOfRange, codes.Unauthenticated, codes.Unimplemented, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
 }
 
 func partialResponse(revision int64) MaybeEtcdResponse {
@@ -376,6 +704,14 @@ func putResponse(revision int64) MaybeEtcdResponse {
 	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Txn: &TxnResponse{Results: []EtcdOperationResult{{}}}, Revision: revision}}
 }
 
+func putWithPrevKVRequest(key, value string) EtcdRequest {
+	return EtcdRequest{Type: Txn, Txn: &TxnRequest{OperationsOnSuccess: []EtcdOperation{{Type: PutOperation, Put: PutOptions{Key: key, Value: ToValueOrHash(value), PrevKV: true}}}}}
+}
+
+func putWithPrevKVResponse(prevKV *KeyValue, revision int64) MaybeEtcdResponse {
+	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Txn: &TxnResponse{Results: []EtcdOperationResult{{PrevKV: prevKV}}}, Revision: revision}}
+}
+
 func deleteRequest(key string) EtcdRequest {
 	return EtcdRequest{Type: Txn, Txn: &TxnRequest{OperationsOnSuccess: []EtcdOperation{{Type: DeleteOperation, Delete: DeleteOptions{Key: key}}}}}
 }
@@ -384,6 +720,18 @@ func deleteResponse(deleted int64, revision int64) MaybeEtcdResponse {
 	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Txn: &TxnResponse{Results: []EtcdOperationResult{{Deleted: deleted}}}, Revision: revision}}
 }
 
+func deleteRangeRequest(start, end string) EtcdRequest {
+	return EtcdRequest{Type: Txn, Txn: &TxnRequest{OperationsOnSuccess: []EtcdOperation{{Type: DeleteOperation, Delete: DeleteOptions{Key: start, End: end}}}}}
+}
+
+func deleteWithPrevKVRequest(key string) EtcdRequest {
+	return EtcdRequest{Type: Txn, Txn: &TxnRequest{OperationsOnSuccess: []EtcdOperation{{Type: DeleteOperation, Delete: DeleteOptions{Key: key, PrevKV: true}}}}}
+}
+
+func deleteWithPrevKVResponse(deleted int64, prevKV *KeyValue, revision int64) MaybeEtcdResponse {
+	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Txn: &TxnResponse{Results: []EtcdOperationResult{{Deleted: deleted, PrevKV: prevKV}}}, Revision: revision}}
+}
+
 func compareRevisionAndPutRequest(key string, expectedRevision int64, value string) EtcdRequest {
 	return txnRequestSingleOperation(compareRevision(key, expectedRevision), putOperation(key, value), nil)
 }
@@ -455,6 +803,14 @@ func leaseRevokeResponse(revision int64) MaybeEtcdResponse {
 	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{LeaseRevoke: &LeaseRevokeResponse{}, Revision: revision}}
 }
 
+func leaseKeepAliveRequest(leaseID int64) EtcdRequest {
+	return EtcdRequest{Type: LeaseKeepAlive, LeaseKeepAlive: &LeaseKeepAliveRequest{LeaseID: leaseID}}
+}
+
+func leaseKeepAliveResponse(revision int64) MaybeEtcdResponse {
+	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{LeaseKeepAlive: &LeaseKeepAliveResponse{}, Revision: revision}}
+}
+
 func defragmentRequest() EtcdRequest {
 	return EtcdRequest{Type: Defragment, Defragment: &DefragmentRequest{}}
 }
@@ -463,18 +819,48 @@ func defragmentResponse(revision int64) MaybeEtcdResponse {
 	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Defragment: &DefragmentResponse{}, Revision: revision}}
 }
 
-func compactRequest(rev int64) EtcdRequest {
-	return EtcdRequest{Type: Compact, Compact: &CompactRequest{Revision: rev}}
+func compactRequest(rev int64, physical bool) EtcdRequest {
+	return EtcdRequest{Type: Compact, Compact: &CompactRequest{Revision: rev, Physical: physical}}
 }
 
 func compactResponse(revision int64) MaybeEtcdResponse {
 	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Compact: &CompactResponse{}, Revision: revision}}
 }
 
+func snapshotRequest() EtcdRequest {
+	return EtcdRequest{Type: Snapshot, Snapshot: &SnapshotRequest{}}
+}
+
+func snapshotResponse(size, revision int64) MaybeEtcdResponse {
+	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{Snapshot: &SnapshotResponse{Size: size, Revision: revision}, Revision: -1}}
+}
+
+func moveLeaderRequest(targetID uint64) EtcdRequest {
+	return EtcdRequest{Type: MoveLeader, MoveLeader: &MoveLeaderRequest{TargetID: targetID}}
+}
+
+func moveLeaderResponse() MaybeEtcdResponse {
+	return MaybeEtcdResponse{EtcdResponse: EtcdResponse{MoveLeader: &MoveLeaderResponse{}, Revision: -1}}
+}
+
 type History struct {
 	operations []porcupine.Operation
 }
 
+// ResponseHeader is a header of response returned by etcd member that served the request.
+type ResponseHeader struct {
+	Revision int64
+	RaftTerm uint64
+	MemberID uint64
+}
+
+func toResponseHeader(header *etcdserverpb.ResponseHeader) ResponseHeader {
+	if header == nil {
+		return ResponseHeader{}
+	}
+	return ResponseHeader{Revision: header.Revision, RaftTerm: header.RaftTerm, MemberID: header.MemberId}
+}
+
 func (h History) Len() int {
 	return len(h.operations)
 }
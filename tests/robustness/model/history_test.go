@@ -0,0 +1,86 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func TestToEtcdCondition(t *testing.T) {
+	tcs := []struct {
+		name      string
+		compare   *etcdserverpb.Compare
+		expect    EtcdCondition
+		expectErr bool
+	}{
+		{
+			name: "Mod revision equal",
+			compare: &etcdserverpb.Compare{
+				Key:         []byte("key"),
+				Result:      etcdserverpb.Compare_EQUAL,
+				Target:      etcdserverpb.Compare_MOD,
+				TargetUnion: &etcdserverpb.Compare_ModRevision{ModRevision: 2},
+			},
+			expect: EtcdCondition{Key: "key", Target: CompareModRevision, Result: CompareEqual, ExpectedRevision: 2},
+		},
+		{
+			name: "Value greater",
+			compare: &etcdserverpb.Compare{
+				Key:         []byte("key"),
+				Result:      etcdserverpb.Compare_GREATER,
+				Target:      etcdserverpb.Compare_VALUE,
+				TargetUnion: &etcdserverpb.Compare_Value{Value: []byte("1")},
+			},
+			expect: EtcdCondition{Key: "key", Target: CompareValue, Result: CompareGreater, ExpectedValue: ToValueOrHash("1")},
+		},
+		{
+			name: "Compare of range returns error",
+			compare: &etcdserverpb.Compare{
+				Key:      []byte("a"),
+				RangeEnd: []byte("b"),
+				Result:   etcdserverpb.Compare_EQUAL,
+				Target:   etcdserverpb.Compare_MOD,
+			},
+			expectErr: true,
+		},
+		{
+			name: "Unsupported target returns error",
+			compare: &etcdserverpb.Compare{
+				Key:    []byte("key"),
+				Result: etcdserverpb.Compare_EQUAL,
+				Target: etcdserverpb.Compare_CompareTarget(100),
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cond, err := ToEtcdCondition(tc.compare)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("ToEtcdCondition() error = %v, expectErr %v", err, tc.expectErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expect, cond); diff != "" {
+				t.Errorf("ToEtcdCondition() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+type MembershipOperationType string
+
+const (
+	MemberList MembershipOperationType = "memberList"
+	MemberAdd  MembershipOperationType = "memberAdd"
+)
+
+// MembershipOperation records a cluster membership request and member IDs returned by it.
+// Membership is not part of the key-value model, so it's recorded outside of linearizable history.
+type MembershipOperation struct {
+	Type   MembershipOperationType
+	Call   time.Duration
+	Return time.Duration
+	// PeerURLs and IsLearner of member requested to be added.
+	PeerURLs  []string
+	IsLearner bool
+	// MemberID of added member.
+	MemberID uint64
+	// MemberIDs of cluster members returned in response.
+	MemberIDs []uint64
+	Error     string
+}
@@ -57,8 +57,11 @@ type nonDeterministicState []EtcdState
 func (states nonDeterministicState) apply(request EtcdRequest, response MaybeEtcdResponse) (bool, nonDeterministicState) {
 	var newStates nonDeterministicState
 	switch {
-	case response.Error != "":
+	case response.Error != "" && response.Indeterminate:
 		newStates = states.stepFailedResponse(request)
+	case response.Error != "":
+		// Rejected request doesn't change state.
+		newStates = states
 	case response.PartialResponse:
 		newStates = states.applyResponseRevision(request, response.EtcdResponse.Revision)
 	default:
@@ -15,6 +15,7 @@
 package model
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -35,6 +36,15 @@ func TestModelNonDeterministic(t *testing.T) {
 				{req: listRequest("key", 0), resp: rangeResponse([]*mvccpb.KeyValue{{Key: []byte("key1"), Value: []byte("1"), ModRevision: 2}, {Key: []byte("key2"), Value: []byte("2"), ModRevision: 3}}, 2, 3)},
 			},
 		},
+		{
+			name: "First Put request is rejected, and is not persisted",
+			operations: []testOperation{
+				{req: putRequest("key1", "1"), resp: rejectedResponse(errors.New("rejected"))},
+				{req: putRequest("key2", "2"), resp: putResponse(3), expectFailure: true},
+				{req: putRequest("key2", "2"), resp: putResponse(2)},
+				{req: listRequest("key", 0), resp: rangeResponse([]*mvccpb.KeyValue{{Key: []byte("key2"), Value: []byte("2"), ModRevision: 2}}, 1, 2)},
+			},
+		},
 		{
 			name: "First Put request fails, and is lost",
 			operations: []testOperation{
@@ -177,6 +187,18 @@ func TestModelNonDeterministic(t *testing.T) {
 				{req: getRequest("key"), resp: emptyGetResponse(5)},
 			},
 		},
+		{
+			name: "Timed out delete range can be lost or persisted",
+			operations: []testOperation{
+				{req: putRequest("a/1", "1"), resp: putResponse(2)},
+				{req: putRequest("a/2", "2"), resp: putResponse(3)},
+				{req: deleteRangeRequest("a/", "a0"), resp: failedResponse(context.DeadlineExceeded)},
+				{req: getRequest("a/1"), resp: getResponse("a/1", "1", 2, 3)},
+				{req: deleteRangeRequest("a/", "a0"), resp: failedResponse(context.DeadlineExceeded)},
+				{req: getRequest("a/2"), resp: emptyGetResponse(4)},
+				{req: getRequest("a/1"), resp: getResponse("a/1", "1", 2, 4), expectFailure: true},
+			},
+		},
 		{
 			name: "Delete can fail but be persisted before put",
 			operations: []testOperation{
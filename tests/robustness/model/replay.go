@@ -55,6 +55,16 @@ func (r *EtcdReplay) StateForRevision(revision int64) (EtcdState, error) {
 	return r.revisionToEtcdState[revision], nil
 }
 
+// CompactRevision returns the highest revision compaction was persisted at.
+func (r *EtcdReplay) CompactRevision() (revision int64) {
+	for _, request := range r.Requests {
+		if request.Type == Compact {
+			revision = max(revision, request.Compact.Revision)
+		}
+	}
+	return revision
+}
+
 func (r *EtcdReplay) EventsForWatch(watch WatchRequest) (events []PersistedEvent) {
 	for _, e := range r.events {
 		if e.Revision < watch.Revision || !e.Match(watch) {
@@ -72,24 +82,18 @@ func toWatchEvents(prevState *EtcdState, request EtcdRequest, response MaybeEtcd
 
 	switch request.Type {
 	case Txn:
-		var ops []EtcdOperation
-		if response.Txn.Failure {
-			ops = request.Txn.OperationsOnFailure
-		} else {
-			ops = request.Txn.OperationsOnSuccess
-		}
-		for _, op := range ops {
+		for _, op := range request.Txn.ExecutedOperations(response.Txn) {
 			switch op.Type {
 			case RangeOperation:
 			case DeleteOperation:
-				e := PersistedEvent{
-					Event: Event{
-						Type: op.Type,
-						Key:  op.Delete.Key,
-					},
-					Revision: response.Revision,
-				}
-				if _, ok := prevState.KeyValues[op.Delete.Key]; ok {
+				for _, key := range prevState.deletedKeys(op.Delete) {
+					e := PersistedEvent{
+						Event: Event{
+							Type: op.Type,
+							Key:  key,
+						},
+						Revision: response.Revision,
+					}
 					events = append(events, e)
 				}
 			case PutOperation:
@@ -108,6 +112,8 @@ func toWatchEvents(prevState *EtcdState, request EtcdRequest, response MaybeEtcd
 				}
 				if _, ok := prevState.KeyValues[op.Put.Key]; !ok {
 					e.IsCreate = true
+				} else {
+					e.IsModify = true
 				}
 				events = append(events, e)
 			default:
@@ -140,12 +146,18 @@ func toWatchEvents(prevState *EtcdState, request EtcdRequest, response MaybeEtcd
 type WatchEvent struct {
 	PersistedEvent
 	PrevValue *ValueRevision
+	// PrevVersion is the version of the previous key-value, set with PrevValue.
+	// It's not part of PrevValue, as the model doesn't track key versions.
+	PrevVersion int64
 }
 
 type PersistedEvent struct {
 	Event
 	Revision int64
 	IsCreate bool
+	// IsModify is set for put events updating an existing key. A put recreating
+	// key after its deletion is a create.
+	IsModify bool
 }
 
 type Event struct {
@@ -155,6 +167,9 @@ type Event struct {
 }
 
 func (e Event) Match(request WatchRequest) bool {
+	if (request.FilterPut && e.Type == PutOperation) || (request.FilterDelete && e.Type == DeleteOperation) {
+		return false
+	}
 	if request.WithPrefix {
 		return strings.HasPrefix(e.Key, request.Key)
 	}
@@ -167,4 +182,9 @@ type WatchRequest struct {
 	WithPrefix         bool
 	WithProgressNotify bool
 	WithPrevKV         bool
+	// WithFragment allows server to split large watch responses into fragments.
+	WithFragment bool
+	// FilterPut and FilterDelete filter out put and delete events from watch responses.
+	FilterPut    bool
+	FilterDelete bool
 }
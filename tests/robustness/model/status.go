@@ -0,0 +1,36 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// StatusObservation records raft progress reported by a member.
+// CommitIndex and AppliedIndex are raft indexes, unlike Revision they also count non-KV entries.
+type StatusObservation struct {
+	Endpoint     string
+	Time         time.Duration
+	Revision     int64
+	Term         uint64
+	CommitIndex  uint64
+	AppliedIndex uint64
+}
+
+// ApplyLag returns number of committed entries that were not yet applied.
+func (s StatusObservation) ApplyLag() uint64 {
+	if s.CommitIndex < s.AppliedIndex {
+		return 0
+	}
+	return s.CommitIndex - s.AppliedIndex
+}
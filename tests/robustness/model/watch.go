@@ -21,10 +21,70 @@ type WatchOperation struct {
 	Responses []WatchResponse
 }
 
+// CancelResponse returns the last response if it canceled the watch.
+// Watch ended without canceled response means the stream was dropped.
+func (op WatchOperation) CancelResponse() (WatchResponse, bool) {
+	if len(op.Responses) == 0 {
+		return WatchResponse{}, false
+	}
+	last := op.Responses[len(op.Responses)-1]
+	return last, last.Canceled
+}
+
+// Duplicates returns events delivered more than once, identified by key and revision.
+func (op WatchOperation) Duplicates() (duplicates []WatchEvent) {
+	type keyRevision struct {
+		key      string
+		revision int64
+	}
+	delivered := map[keyRevision]struct{}{}
+	for _, resp := range op.Responses {
+		for _, event := range resp.Events {
+			id := keyRevision{key: event.Key, revision: event.Revision}
+			if _, found := delivered[id]; found {
+				duplicates = append(duplicates, event)
+			}
+			delivered[id] = struct{}{}
+		}
+	}
+	return duplicates
+}
+
+// ProgressNotifyIntervals returns time between consecutive progress notifications.
+func (op WatchOperation) ProgressNotifyIntervals() (intervals []time.Duration) {
+	var last *WatchResponse
+	for i, resp := range op.Responses {
+		if !resp.IsProgressNotify {
+			continue
+		}
+		if last != nil {
+			intervals = append(intervals, resp.Time-last.Time)
+		}
+		last = &op.Responses[i]
+	}
+	return intervals
+}
+
+// ProgressNotifyLatency returns time from requestTime until the first progress notification
+// received at or after it. Returns false if no progress notification was received.
+func (op WatchOperation) ProgressNotifyLatency(requestTime time.Duration) (time.Duration, bool) {
+	for _, resp := range op.Responses {
+		if resp.IsProgressNotify && resp.Time >= requestTime {
+			return resp.Time - requestTime, true
+		}
+	}
+	return 0, false
+}
+
 type WatchResponse struct {
 	Events           []WatchEvent
 	IsProgressNotify bool
 	Revision         int64
 	Time             time.Duration
 	Error            string
+	// Canceled is set when the server cancelled the watch, for example due to
+	// compaction or auth revocation, or when the client context was done.
+	Canceled        bool
+	CancelReason    string
+	CompactRevision int64
 }
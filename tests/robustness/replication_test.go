@@ -0,0 +1,370 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+)
+
+func TestDropMsgAppStallsReplicationWithStableLeader(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	follower := clus.Procs[(leaderIdx+1)%len(clus.Procs)]
+	if !follower.Failpoints().Available("raftDropMsgApp") {
+		t.Skip("raftDropMsgApp failpoint is not available")
+	}
+	c, err := client.NewRecordingClient(clus.Procs[leaderIdx].EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+	before, err := c.Status(ctx, follower.EndpointsGRPC()[0])
+	require.NoError(t, err)
+
+	require.NoError(t, follower.Failpoints().SetupHTTP(ctx, "raftDropMsgApp", "return"))
+	var revision int64
+	for i := 0; i < 10; i++ {
+		resp, err := c.Put(ctx, fmt.Sprintf("key%d", i), "value")
+		require.NoError(t, err)
+		revision = resp.Header.Revision
+	}
+	// Wait multiple election timeouts, heartbeats keep follower from campaigning.
+	time.Sleep(3 * time.Second)
+	stalled, err := c.Status(ctx, follower.EndpointsGRPC()[0])
+	require.NoError(t, err)
+	require.Equal(t, before.Leader, stalled.Leader, "expected leader to stay")
+	require.Equal(t, before.RaftTerm, stalled.RaftTerm)
+	require.Less(t, stalled.Header.Revision, revision, "expected follower to not replicate entries")
+
+	require.NoError(t, follower.Failpoints().DeactivateHTTP(ctx, "raftDropMsgApp"))
+	require.Equal(t, revision, waitForRevisionConvergence(ctx, t, c, clus))
+}
+
+func TestCorruptRaftMessagesRejected(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	follower := clus.Procs[(leaderIdx+1)%len(clus.Procs)]
+	if !follower.Failpoints().Available("raftCorruptMessage") {
+		t.Skip("raftCorruptMessage failpoint is not available")
+	}
+	c, err := client.NewRecordingClient(clus.Procs[leaderIdx].EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Flipping bytes of a live stream can hit entry data, which is not checksummed
+	// by rafthttp, so only truncation is expected to be rejected.
+	for _, corruption := range []string{"truncate:0", "truncate:16"} {
+		t.Logf("Corrupting raft messages received by member %q with %q", follower.Config().Name, corruption)
+		require.NoError(t, follower.Failpoints().SetupHTTP(ctx, "raftCorruptMessage", fmt.Sprintf("return(%q)", corruption)))
+		var revision int64
+		for i := 0; i < 10; i++ {
+			resp, err := c.Put(ctx, fmt.Sprintf("key%d", i), corruption)
+			require.NoError(t, err)
+			revision = resp.Header.Revision
+		}
+		time.Sleep(3 * time.Second)
+		// Truncated messages fail to decode, they are neither applied nor crash the member.
+		require.True(t, follower.IsRunning(), "expected member to reject corrupted messages without crashing")
+		status, err := c.Status(ctx, follower.EndpointsGRPC()[0])
+		require.NoError(t, err)
+		require.Less(t, status.Header.Revision, revision, "expected member to not apply corrupted messages")
+
+		require.NoError(t, follower.Failpoints().DeactivateHTTP(ctx, "raftCorruptMessage"))
+		require.Equal(t, revision, waitForRevisionConvergence(ctx, t, c, clus))
+		require.NoError(t, CheckHashKV(ctx, clus, revision))
+	}
+}
+
+func TestDelayedStreamFlushSlowsReplication(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	leader := clus.Procs[leaderIdx]
+	if !leader.Failpoints().Available("raftBeforeStreamFlush") {
+		t.Skip("raftBeforeStreamFlush failpoint is not available")
+	}
+	c, err := client.NewRecordingClient(leader.EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+	before, err := c.Status(ctx, leader.EndpointsGRPC()[0])
+	require.NoError(t, err)
+
+	// Stream writers of the leader flush messages to followers with a delay,
+	// so committing an entry waits for the delayed flush. Delay is kept below
+	// heartbeat interval, longer delays back up heartbeats and trigger elections.
+	delay := 50 * time.Millisecond
+	require.NoError(t, leader.Failpoints().SetupHTTP(ctx, "raftBeforeStreamFlush", fmt.Sprintf("sleep(%q)", delay)))
+	var revision int64
+	for i := 0; i < 5; i++ {
+		start := time.Now()
+		resp, err := c.Put(ctx, fmt.Sprintf("key%d", i), "value")
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), delay, "expected put to wait for delayed flush")
+		revision = resp.Header.Revision
+	}
+	after, err := c.Status(ctx, leader.EndpointsGRPC()[0])
+	require.NoError(t, err)
+	require.Equal(t, before.Leader, after.Leader, "expected leader to stay")
+	require.Equal(t, before.RaftTerm, after.RaftTerm)
+
+	require.NoError(t, leader.Failpoints().DeactivateHTTP(ctx, "raftBeforeStreamFlush"))
+	require.Equal(t, revision, waitForRevisionConvergence(ctx, t, c, clus))
+}
+
+func TestResetRaftStreamsReestablished(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	follower := clus.Procs[(leaderIdx+1)%len(clus.Procs)]
+	if !follower.Failpoints().Available("raftResetStream") {
+		t.Skip("raftResetStream failpoint is not available")
+	}
+	c, err := client.NewRecordingClient(clus.Procs[leaderIdx].EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Every stream read by the follower is reset again after re-establishing,
+	// either in the middle of a message or after a period of time. Messages
+	// larger than the byte threshold are never received until it's deactivated.
+	var revision int64
+	for _, reset := range []string{"bytes:100", "after:500ms"} {
+		t.Logf("Resetting raft streams read by member %q with %q", follower.Config().Name, reset)
+		require.NoError(t, follower.Failpoints().SetupHTTP(ctx, "raftResetStream", fmt.Sprintf("return(%q)", reset)))
+		for i := 0; i < 10; i++ {
+			resp, err := c.Put(ctx, fmt.Sprintf("key%d", i), reset)
+			require.NoError(t, err)
+			revision = resp.Header.Revision
+		}
+		time.Sleep(2 * time.Second)
+		require.True(t, follower.IsRunning(), "expected member to handle partially received messages without crashing")
+
+		require.NoError(t, follower.Failpoints().DeactivateHTTP(ctx, "raftResetStream"))
+		require.Equal(t, revision, waitForRevisionConvergence(ctx, t, c, clus), "expected follower to catch up over re-established streams")
+	}
+	require.NoError(t, CheckHashKV(ctx, clus, revision))
+}
+
+func TestDropRaftRequestPathsKeepsPeerEndpoints(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	if !clus.Procs[leaderIdx].Failpoints().Available("raftDropRequestPaths") {
+		t.Skip("raftDropRequestPaths failpoint is not available")
+	}
+	c, err := client.NewRecordingClient(clus.Procs[leaderIdx].EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Drop raft pipeline and stream requests received by every member,
+	// partitioning raft traffic, but not probing, membership and version.
+	for _, member := range clus.Procs {
+		require.NoError(t, member.Failpoints().SetupHTTP(ctx, "raftDropRequestPaths", `return("/raft,/raft/stream/")`))
+	}
+	putCtx, putCancel := context.WithTimeout(ctx, 3*time.Second)
+	_, err = c.Put(putCtx, "key", "value")
+	putCancel()
+	require.Error(t, err, "expected put to fail without raft traffic")
+	for _, member := range clus.Procs {
+		for _, path := range []string{"/raft/probing", "/members", "/version"} {
+			resp, err := http.Get(member.Config().PeerURL.String() + path)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equalf(t, http.StatusOK, resp.StatusCode, "expected %s of member %q to be available", path, member.Config().Name)
+		}
+	}
+
+	for _, member := range clus.Procs {
+		require.NoError(t, member.Failpoints().DeactivateHTTP(ctx, "raftDropRequestPaths"))
+	}
+	clus.WaitLeader(t)
+	resp, err := c.Put(ctx, "key", "value")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, waitForRevisionConvergence(ctx, t, c, clus), resp.Header.Revision)
+}
+
+func TestBlockPeerURLsPartitionsFollower(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	follower := clus.Procs[(leaderIdx+1)%len(clus.Procs)]
+	if !follower.Failpoints().Available("raftBlockPeerURLs") {
+		t.Skip("raftBlockPeerURLs failpoint is not available")
+	}
+	c, err := client.NewRecordingClient(clus.Procs[leaderIdx].EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+	before, err := c.Status(ctx, clus.Procs[leaderIdx].EndpointsGRPC()[0])
+	require.NoError(t, err)
+
+	// Every member blocks raft requests to the other side of partition.
+	var others []string
+	for _, member := range clus.Procs {
+		if member != follower {
+			others = append(others, member.Config().PeerURL.String())
+			require.NoError(t, member.Failpoints().SetupHTTP(ctx, "raftBlockPeerURLs", fmt.Sprintf("return(%q)", follower.Config().PeerURL.String())))
+		}
+	}
+	require.NoError(t, follower.Failpoints().SetupHTTP(ctx, "raftBlockPeerURLs", fmt.Sprintf("return(%q)", strings.Join(others, ","))))
+	var revision int64
+	for i := 0; i < 10; i++ {
+		resp, err := c.Put(ctx, fmt.Sprintf("key%d", i), "value")
+		require.NoError(t, err)
+		revision = resp.Header.Revision
+	}
+	time.Sleep(3 * time.Second)
+	after, err := c.Status(ctx, clus.Procs[leaderIdx].EndpointsGRPC()[0])
+	require.NoError(t, err)
+	require.Equal(t, before.Leader, after.Leader, "expected leader to stay")
+	status, err := c.Status(ctx, follower.EndpointsGRPC()[0])
+	require.NoError(t, err)
+	require.Less(t, status.Header.Revision, revision, "expected partitioned follower to not replicate entries")
+
+	for _, member := range clus.Procs {
+		require.NoError(t, member.Failpoints().DeactivateHTTP(ctx, "raftBlockPeerURLs"))
+	}
+	require.Equal(t, revision, waitForRevisionConvergence(ctx, t, c, clus))
+}
+
+func TestStaleHeartbeatsRejectedAfterLeaderChange(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	followerIdx := (leaderIdx + 1) % len(clus.Procs)
+	newLeaderIdx := (leaderIdx + 2) % len(clus.Procs)
+	follower := clus.Procs[followerIdx]
+	if !follower.Failpoints().Available("raftReplayStaleHeartbeats") {
+		t.Skip("raftReplayStaleHeartbeats failpoint is not available")
+	}
+	c, err := client.NewRecordingClient(clus.Procs[newLeaderIdx].EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Capture heartbeat of the current leader before moving leadership.
+	require.NoError(t, follower.Failpoints().SetupHTTP(ctx, "raftReplayStaleHeartbeats", "return"))
+	time.Sleep(time.Second)
+	require.NoError(t, clus.MoveLeader(ctx, t, newLeaderIdx))
+	before, err := c.Status(ctx, follower.EndpointsGRPC()[0])
+	require.NoError(t, err)
+	e2e.AssertProcessLogs(t, follower, "replaying stale heartbeat")
+
+	var revision int64
+	for i := 0; i < 10; i++ {
+		resp, err := c.Put(ctx, fmt.Sprintf("key%d", i), "value")
+		require.NoError(t, err)
+		revision = resp.Header.Revision
+	}
+	// Wait multiple election timeouts, stale heartbeats are replayed along with every heartbeat of the new leader.
+	time.Sleep(3 * time.Second)
+	after, err := c.Status(ctx, follower.EndpointsGRPC()[0])
+	require.NoError(t, err)
+	require.Equal(t, before.Leader, after.Leader, "expected new leader to stay")
+	require.Equal(t, before.RaftTerm, after.RaftTerm)
+
+	require.NoError(t, follower.Failpoints().DeactivateHTTP(ctx, "raftReplayStaleHeartbeats"))
+	require.Equal(t, revision, waitForRevisionConvergence(ctx, t, c, clus))
+}
+
+func TestBlackholeOnCampaignKeepsLeaderElsewhere(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leaderIdx := clus.WaitLeader(t)
+	candidateIdx := (leaderIdx + 1) % len(clus.Procs)
+	candidate := clus.Procs[candidateIdx]
+	if !candidate.Failpoints().Available("raftBlackholeOnCampaign") {
+		t.Skip("raftBlackholeOnCampaign failpoint is not available")
+	}
+	candidateStatus, err := candidate.RaftStatus(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, candidate.Failpoints().SetupHTTP(ctx, "raftBlackholeOnCampaign", "return"))
+	// Leadership transfer makes candidate campaign immediately, which blackholes it.
+	moveCtx, moveCancel := context.WithTimeout(ctx, 5*time.Second)
+	err = clus.Procs[leaderIdx].Etcdctl().MoveLeader(moveCtx, candidateStatus.MemberID)
+	moveCancel()
+	require.Error(t, err, "expected leadership transfer to blackholed candidate to fail")
+	e2e.AssertProcessLogs(t, candidate, "blackholing member after it started campaigning")
+
+	var others []e2e.EtcdProcess
+	for i, member := range clus.Procs {
+		if i != candidateIdx {
+			others = append(others, member)
+		}
+	}
+	// Members agree on a leader from the given members, so election proceeded without candidate.
+	newLeaderIdx := clus.WaitMembersForLeader(ctx, t, others)
+	c, err := client.NewRecordingClient(others[newLeaderIdx].EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+	var revision int64
+	for i := 0; i < 10; i++ {
+		resp, err := c.Put(ctx, fmt.Sprintf("key%d", i), "value")
+		require.NoError(t, err)
+		revision = resp.Header.Revision
+	}
+
+	require.NoError(t, candidate.Failpoints().DeactivateHTTP(ctx, "raftBlackholeOnCampaign"))
+	require.Equal(t, revision, waitForRevisionConvergence(ctx, t, c, clus))
+}
@@ -15,14 +15,20 @@
 package report
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/anishathalye/porcupine"
 	"go.uber.org/zap"
@@ -32,8 +38,16 @@ import (
 
 type ClientReport struct {
 	ClientID int
+	// Username of authenticated client, not persisted.
+	Username string
 	KeyValue []porcupine.Operation
 	Watch    []model.WatchOperation
+	// Status observations of raft progress, not persisted.
+	Status []model.StatusObservation
+	// Membership operations, not persisted.
+	Membership []model.MembershipOperation
+	// ProgressRequests are times when client requested progress of its watches, not persisted.
+	ProgressRequests []time.Duration
 }
 
 func (r ClientReport) WatchEventCount() int {
@@ -46,7 +60,63 @@ func (r ClientReport) WatchEventCount() int {
 	return count
 }
 
-func persistClientReports(t *testing.T, lg *zap.Logger, path string, reports []ClientReport) {
+// MergeReports merges reports of multiple clients into a single report, with operations
+// of all clients ordered by time on a shared timeline, keeping order of reports for equal
+// times. Reports must be recorded with the same base time. ClientID, Username
+// and ProgressRequests are not preserved.
+func MergeReports(reports []ClientReport) ClientReport {
+	merged := ClientReport{}
+	for _, r := range reports {
+		merged.KeyValue = append(merged.KeyValue, r.KeyValue...)
+		merged.Watch = append(merged.Watch, r.Watch...)
+		merged.Status = append(merged.Status, r.Status...)
+		merged.Membership = append(merged.Membership, r.Membership...)
+	}
+	sort.SliceStable(merged.KeyValue, func(i, j int) bool {
+		return merged.KeyValue[i].Call < merged.KeyValue[j].Call
+	})
+	sort.SliceStable(merged.Watch, func(i, j int) bool {
+		return watchStartTime(merged.Watch[i]) < watchStartTime(merged.Watch[j])
+	})
+	sort.SliceStable(merged.Status, func(i, j int) bool {
+		return merged.Status[i].Time < merged.Status[j].Time
+	})
+	sort.SliceStable(merged.Membership, func(i, j int) bool {
+		return merged.Membership[i].Call < merged.Membership[j].Call
+	})
+	return merged
+}
+
+// watchStartTime approximates when watch started by time of its first response.
+func watchStartTime(op model.WatchOperation) time.Duration {
+	if len(op.Responses) == 0 {
+		return math.MaxInt64
+	}
+	return op.Responses[0].Time
+}
+
+type persistConfig struct {
+	compress bool
+}
+
+// PersistOption configures PersistClientReports.
+type PersistOption func(*persistConfig)
+
+// WithCompression gzip compresses persisted operations, saving them to ".json.gz" files.
+func WithCompression() PersistOption {
+	return func(cfg *persistConfig) { cfg.compress = true }
+}
+
+// PersistClientReports saves operations recorded by clients, each client to a separate directory under path.
+func PersistClientReports(t *testing.T, lg *zap.Logger, path string, reports []ClientReport, opts ...PersistOption) {
+	cfg := persistConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	extension := ".json"
+	if cfg.compress {
+		extension = ".json.gz"
+	}
 	sort.Slice(reports, func(i, j int) bool {
 		return reports[i].ClientID < reports[j].ClientID
 	})
@@ -57,12 +127,12 @@ func persistClientReports(t *testing.T, lg *zap.Logger, path string, reports []C
 			t.Fatal(err)
 		}
 		if len(r.Watch) != 0 {
-			persistWatchOperations(t, lg, filepath.Join(clientDir, "watch.json"), r.Watch)
+			persistWatchOperations(t, lg, filepath.Join(clientDir, "watch"+extension), r.Watch)
 		} else {
 			lg.Info("no watch operations for client, skip persisting", zap.Int("client-id", r.ClientID))
 		}
 		if len(r.KeyValue) != 0 {
-			persistKeyValueOperations(t, lg, filepath.Join(clientDir, "operations.json"), r.KeyValue)
+			persistKeyValueOperations(t, lg, filepath.Join(clientDir, "operations"+extension), r.KeyValue)
 		} else {
 			lg.Info("no KV operations for client, skip persisting", zap.Int("client-id", r.ClientID))
 		}
@@ -109,16 +179,12 @@ func loadClientReport(path string) (report ClientReport, err error) {
 }
 
 func loadWatchOperations(path string) (operations []model.WatchOperation, err error) {
-	_, err = os.Stat(path)
+	file, err := openReportFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
 		return nil, fmt.Errorf("failed to open watch operation file: %q, err: %w", path, err)
 	}
-	file, err := os.OpenFile(path, os.O_RDONLY, 0755)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open watch operation file: %q, err: %w", path, err)
+	if file == nil {
+		return nil, nil
 	}
 	defer file.Close()
 	decoder := json.NewDecoder(file)
@@ -134,16 +200,12 @@ func loadWatchOperations(path string) (operations []model.WatchOperation, err er
 }
 
 func loadKeyValueOperations(path string) (operations []porcupine.Operation, err error) {
-	_, err = os.Stat(path)
+	file, err := openReportFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
 		return nil, fmt.Errorf("failed to open watch operation file: %q, err: %w", path, err)
 	}
-	file, err := os.OpenFile(path, os.O_RDONLY, 0755)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open watch operation file: %q, err: %w", path, err)
+	if file == nil {
+		return nil, nil
 	}
 	defer file.Close()
 	decoder := json.NewDecoder(file)
@@ -170,14 +232,92 @@ func loadKeyValueOperations(path string) (operations []porcupine.Operation, err
 	return operations, nil
 }
 
+// gzipMagic are the first bytes of gzip compressed file.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// openReportFile opens report file at path, or its compressed ".gz" variant,
+// transparently decompressing gzip content detected by its magic bytes.
+// Returns nil if neither file exists.
+func openReportFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		file, err = os.Open(path + ".gz")
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		file.Close()
+		return nil, err
+	}
+	if !bytes.Equal(magic, gzipMagic) {
+		return readCloser{Reader: reader, closers: []io.Closer{file}}, nil
+	}
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return readCloser{Reader: gz, closers: []io.Closer{gz, file}}, nil
+}
+
+// createReportFile creates report file at path, gzip compressing content if path ends with ".gz".
+func createReportFile(path string) (io.WriteCloser, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+	gz := gzip.NewWriter(file)
+	return writeCloser{Writer: gz, closers: []io.Closer{gz, file}}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r readCloser) Close() error {
+	return closeAll(r.closers)
+}
+
+type writeCloser struct {
+	io.Writer
+	closers []io.Closer
+}
+
+func (w writeCloser) Close() error {
+	return closeAll(w.closers)
+}
+
+func closeAll(closers []io.Closer) (err error) {
+	for _, c := range closers {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
 func persistWatchOperations(t *testing.T, lg *zap.Logger, path string, responses []model.WatchOperation) {
 	lg.Info("Saving watch operations", zap.String("path", path))
-	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	file, err := createReportFile(path)
 	if err != nil {
 		t.Errorf("Failed to save watch operations: %v", err)
 		return
 	}
-	defer file.Close()
+	defer func() {
+		if err := file.Close(); err != nil {
+			t.Errorf("Failed to save watch operations: %v", err)
+		}
+	}()
 	encoder := json.NewEncoder(file)
 	for _, resp := range responses {
 		err := encoder.Encode(resp)
@@ -189,12 +329,16 @@ func persistWatchOperations(t *testing.T, lg *zap.Logger, path string, responses
 
 func persistKeyValueOperations(t *testing.T, lg *zap.Logger, path string, operations []porcupine.Operation) {
 	lg.Info("Saving operation history", zap.String("path", path))
-	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	file, err := createReportFile(path)
 	if err != nil {
 		t.Errorf("Failed to save operation history: %v", err)
 		return
 	}
-	defer file.Close()
+	defer func() {
+		if err := file.Close(); err != nil {
+			t.Errorf("Failed to save operation history: %v", err)
+		}
+	}()
 	encoder := json.NewEncoder(file)
 	for _, op := range operations {
 		err := encoder.Encode(op)
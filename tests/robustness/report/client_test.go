@@ -15,13 +15,18 @@
 package report
 
 import (
+	"compress/gzip"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/anishathalye/porcupine"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -84,6 +89,11 @@ func TestPersistLoadClientReports(t *testing.T) {
 	stop = time.Since(baseTime)
 	h.AppendDefragment(start, stop, &clientv3.DefragmentResponse{Header: &etcdserverpb.ResponseHeader{Revision: 2}}, nil)
 
+	start = time.Since(baseTime)
+	time.Sleep(time.Nanosecond)
+	stop = time.Since(baseTime)
+	h.AppendSnapshot(start, stop, 4096, 2, nil)
+
 	watch := model.WatchOperation{
 		Request: model.WatchRequest{
 			Key:                "key",
@@ -132,11 +142,60 @@ func TestPersistLoadClientReports(t *testing.T) {
 			Watch:    []model.WatchOperation{watch},
 		},
 	}
+	for _, compress := range []bool{false, true} {
+		var opts []PersistOption
+		if compress {
+			opts = append(opts, WithCompression())
+		}
+		path := t.TempDir()
+		PersistClientReports(t, zaptest.NewLogger(t), path, reports, opts...)
+		_, err := os.Stat(filepath.Join(path, "client-1", "operations.json.gz"))
+		assert.Equal(t, compress, err == nil, "compressed file presence")
+		got, err := LoadClientReports(path)
+		assert.NoError(t, err)
+		if diff := cmp.Diff(reports, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("Reports don't match after persist and load, compress: %v, %s", compress, diff)
+		}
+	}
+}
+
+func TestLoadClientReportsSniffsCompression(t *testing.T) {
 	path := t.TempDir()
-	persistClientReports(t, zaptest.NewLogger(t), path, reports)
+	clientDir := filepath.Join(path, "client-1")
+	require.NoError(t, os.MkdirAll(clientDir, 0700))
+	// Compressed content in file without ".gz" suffix is detected by magic bytes.
+	file, err := os.Create(filepath.Join(clientDir, "watch.json"))
+	require.NoError(t, err)
+	gz := gzip.NewWriter(file)
+	_, err = gz.Write([]byte(`{"Request":{"Key":"a"}}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, file.Close())
+
 	got, err := LoadClientReports(path)
-	assert.NoError(t, err)
-	if diff := cmp.Diff(reports, got, cmpopts.EquateEmpty()); diff != "" {
-		t.Errorf("Reports don't match after persist and load, %s", diff)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Watch, 1)
+	assert.Equal(t, "a", got[0].Watch[0].Request.Key)
+}
+
+func TestMergeReports(t *testing.T) {
+	reports := []ClientReport{
+		{
+			ClientID: 1,
+			KeyValue: []porcupine.Operation{{ClientId: 1, Call: 1, Return: 2}, {ClientId: 1, Call: 5, Return: 6}},
+			Watch:    []model.WatchOperation{{Responses: []model.WatchResponse{{Time: 4}}}},
+			Status:   []model.StatusObservation{{Time: 3}},
+		},
+		{
+			ClientID: 2,
+			KeyValue: []porcupine.Operation{{ClientId: 2, Call: 3, Return: 4}},
+			Watch:    []model.WatchOperation{{}, {Responses: []model.WatchResponse{{Time: 2}}}},
+			Status:   []model.StatusObservation{{Time: 1}},
+		},
 	}
+	merged := MergeReports(reports)
+	assert.Equal(t, []porcupine.Operation{{ClientId: 1, Call: 1, Return: 2}, {ClientId: 2, Call: 3, Return: 4}, {ClientId: 1, Call: 5, Return: 6}}, merged.KeyValue)
+	assert.Equal(t, []model.WatchOperation{{Responses: []model.WatchResponse{{Time: 2}}}, {Responses: []model.WatchResponse{{Time: 4}}}, {}}, merged.Watch)
+	assert.Equal(t, []model.StatusObservation{{Time: 1}, {Time: 3}}, merged.Status)
 }
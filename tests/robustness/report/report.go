@@ -71,7 +71,11 @@ func (r *TestReport) Report(t *testing.T, force bool) {
 		persistMemberDataDir(t, r.Logger, member, memberDataDir)
 	}
 	if r.Client != nil {
-		persistClientReports(t, r.Logger, path, r.Client)
+		var opts []PersistOption
+		if _, compress := os.LookupEnv("COMPRESS_RESULTS"); compress {
+			opts = append(opts, WithCompression())
+		}
+		PersistClientReports(t, r.Logger, path, r.Client, opts...)
 	}
 	if r.Visualize != nil {
 		err := r.Visualize(filepath.Join(path, "history.html"))
@@ -148,6 +148,7 @@ func parseEntryNormal(ent raftpb.Entry) (*model.EtcdRequest, error) {
 			Key:     string(raftReq.Put.Key),
 			Value:   model.ToValueOrHash(string(raftReq.Put.Value)),
 			LeaseID: raftReq.Put.Lease,
+			PrevKV:  raftReq.Put.PrevKv,
 		}
 		request := model.EtcdRequest{
 			Type: model.Txn,
@@ -159,7 +160,7 @@ func parseEntryNormal(ent raftpb.Entry) (*model.EtcdRequest, error) {
 		}
 		return &request, nil
 	case raftReq.DeleteRange != nil:
-		op := model.DeleteOptions{Key: string(raftReq.DeleteRange.Key)}
+		op := model.DeleteOptions{Key: string(raftReq.DeleteRange.Key), End: string(raftReq.DeleteRange.RangeEnd), PrevKV: raftReq.DeleteRange.PrevKv}
 		request := model.EtcdRequest{
 			Type: model.Txn,
 			Txn: &model.TxnRequest{
@@ -186,30 +187,17 @@ func parseEntryNormal(ent raftpb.Entry) (*model.EtcdRequest, error) {
 	case raftReq.Compaction != nil:
 		request := model.EtcdRequest{
 			Type:    model.Compact,
-			Compact: &model.CompactRequest{Revision: raftReq.Compaction.Revision},
+			Compact: &model.CompactRequest{Revision: raftReq.Compaction.Revision, Physical: raftReq.Compaction.Physical},
 		}
 		return &request, nil
 	case raftReq.Txn != nil:
-		txn := model.TxnRequest{
-			Conditions:          []model.EtcdCondition{},
-			OperationsOnSuccess: []model.EtcdOperation{},
-			OperationsOnFailure: []model.EtcdOperation{},
-		}
-		for _, cmp := range raftReq.Txn.Compare {
-			txn.Conditions = append(txn.Conditions, model.EtcdCondition{
-				Key:              string(cmp.Key),
-				ExpectedRevision: cmp.GetModRevision(),
-			})
-		}
-		for _, op := range raftReq.Txn.Success {
-			txn.OperationsOnSuccess = append(txn.OperationsOnSuccess, toEtcdOperation(op))
-		}
-		for _, op := range raftReq.Txn.Failure {
-			txn.OperationsOnFailure = append(txn.OperationsOnFailure, toEtcdOperation(op))
+		txn, err := toTxnRequest(raftReq.Txn)
+		if err != nil {
+			return nil, err
 		}
 		request := model.EtcdRequest{
 			Type: model.Txn,
-			Txn:  &txn,
+			Txn:  txn,
 		}
 		return &request, nil
 	default:
@@ -217,7 +205,7 @@ func parseEntryNormal(ent raftpb.Entry) (*model.EtcdRequest, error) {
 	}
 }
 
-func toEtcdOperation(op *pb.RequestOp) (operation model.EtcdOperation) {
+func toEtcdOperation(op *pb.RequestOp) (operation model.EtcdOperation, err error) {
 	switch {
 	case op.GetRequestRange() != nil:
 		rangeOp := op.GetRequestRange()
@@ -234,8 +222,10 @@ func toEtcdOperation(op *pb.RequestOp) (operation model.EtcdOperation) {
 		operation = model.EtcdOperation{
 			Type: model.PutOperation,
 			Put: model.PutOptions{
-				Key:   string(putOp.Key),
-				Value: model.ToValueOrHash(string(putOp.Value)),
+				Key:     string(putOp.Key),
+				Value:   model.ToValueOrHash(string(putOp.Value)),
+				LeaseID: putOp.Lease,
+				PrevKV:  putOp.PrevKv,
 			},
 		}
 	case op.GetRequestDeleteRange() != nil:
@@ -243,11 +233,52 @@ func toEtcdOperation(op *pb.RequestOp) (operation model.EtcdOperation) {
 		operation = model.EtcdOperation{
 			Type: model.DeleteOperation,
 			Delete: model.DeleteOptions{
-				Key: string(deleteOp.Key),
+				Key:    string(deleteOp.Key),
+				End:    string(deleteOp.RangeEnd),
+				PrevKV: deleteOp.PrevKv,
 			},
 		}
+	case op.GetRequestTxn() != nil:
+		txn, err := toTxnRequest(op.GetRequestTxn())
+		if err != nil {
+			return operation, err
+		}
+		operation = model.EtcdOperation{
+			Type: model.TxnOperation,
+			Txn:  txn,
+		}
 	default:
 		panic(fmt.Sprintf("Unknown op type %v", op))
 	}
-	return operation
+	return operation, nil
+}
+
+func toTxnRequest(req *pb.TxnRequest) (*model.TxnRequest, error) {
+	txn := model.TxnRequest{
+		Conditions:          []model.EtcdCondition{},
+		OperationsOnSuccess: []model.EtcdOperation{},
+		OperationsOnFailure: []model.EtcdOperation{},
+	}
+	for _, cmp := range req.Compare {
+		cond, err := model.ToEtcdCondition(cmp)
+		if err != nil {
+			return nil, err
+		}
+		txn.Conditions = append(txn.Conditions, cond)
+	}
+	for _, op := range req.Success {
+		operation, err := toEtcdOperation(op)
+		if err != nil {
+			return nil, err
+		}
+		txn.OperationsOnSuccess = append(txn.OperationsOnSuccess, operation)
+	}
+	for _, op := range req.Failure {
+		operation, err := toEtcdOperation(op)
+		if err != nil {
+			return nil, err
+		}
+		txn.OperationsOnFailure = append(txn.OperationsOnFailure, operation)
+	}
+	return &txn, nil
 }
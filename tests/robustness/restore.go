@@ -0,0 +1,132 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	clientsnapshot "go.etcd.io/etcd/client/v3/snapshot"
+	"go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+// RestoreSnapshotCluster saves snapshot of the member and starts a new single member cluster
+// restored from it. Options are used to configure the restored cluster, it needs to use
+// different ports than the live cluster. Returns the restored cluster and snapshot revision.
+func RestoreSnapshotCluster(ctx context.Context, t *testing.T, member e2e.EtcdProcess, opts ...e2e.EPClusterOption) (*e2e.EtcdProcessCluster, int64) {
+	lg := zaptest.NewLogger(t)
+	dbPath := filepath.Join(t.TempDir(), "snapshot.db")
+	if _, err := clientsnapshot.SaveWithVersion(ctx, lg, clientv3.Config{Endpoints: member.EndpointsGRPC()}, dbPath); err != nil {
+		t.Fatalf("Failed to save snapshot, err: %s", err)
+	}
+	manager := snapshot.NewV3(lg)
+	status, err := manager.Status(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to read snapshot status, err: %s", err)
+	}
+
+	cfg := e2e.NewConfig(append(opts, e2e.WithClusterSize(1))...)
+	clus, err := e2e.InitEtcdProcessCluster(t, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	memberCfg := clus.Procs[0].Config()
+	// Restore refuses to overwrite existing data directory.
+	if err = os.RemoveAll(memberCfg.DataDirPath); err != nil {
+		t.Fatal(err)
+	}
+	err = manager.Restore(snapshot.RestoreConfig{
+		SnapshotPath:        dbPath,
+		Name:                memberCfg.Name,
+		OutputDataDir:       memberCfg.DataDirPath,
+		PeerURLs:            []string{memberCfg.PeerURL.String()},
+		InitialCluster:      memberCfg.InitialCluster,
+		InitialClusterToken: memberCfg.InitialToken,
+	})
+	if err != nil {
+		t.Fatalf("Failed to restore snapshot, err: %s", err)
+	}
+	clus, err = e2e.StartEtcdProcessCluster(ctx, t, clus, cfg)
+	if err != nil {
+		t.Fatalf("Failed to start restored cluster, err: %s", err)
+	}
+	lg.Info("Restored cluster from snapshot", zap.String("member", member.Config().Name), zap.Int64("revision", status.Revision))
+	return clus, status.Revision
+}
+
+// AssertSnapshotMatchesLive compares the whole keyspace of cluster restored from a snapshot
+// taken at revision rev against the live cluster as of the same revision. Both clusters are
+// read at rev, so writes done to any of them after the snapshot don't matter. Keys are
+// compared one by one, including their revisions and versions, and using HashKV.
+func AssertSnapshotMatchesLive(ctx context.Context, t *testing.T, liveClus, restoredClus *e2e.EtcdProcessCluster, rev int64) {
+	live := keyspaceAt(ctx, t, liveClus.Procs[0], rev)
+	restored := keyspaceAt(ctx, t, restoredClus.Procs[0], rev)
+	if len(live) != len(restored) {
+		t.Errorf("Restored cluster has %d keys at revision %d, live cluster has %d", len(restored), rev, len(live))
+	}
+	for i := 0; i < min(len(live), len(restored)); i++ {
+		if !equalKeyValue(live[i], restored[i]) {
+			t.Errorf("Key mismatch at revision %d, live: %s, restored: %s", rev, live[i], restored[i])
+		}
+	}
+
+	liveHash, err := memberHashKVAt(ctx, liveClus.Procs[0], rev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restoredHash, err := memberHashKVAt(ctx, restoredClus.Procs[0], rev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if liveHash.CompactRevision != restoredHash.CompactRevision {
+		t.Fatalf("Cannot compare hashKV at revision %d, live cluster compacted at %d, restored cluster compacted at %d", rev, liveHash.CompactRevision, restoredHash.CompactRevision)
+	}
+	if liveHash.Hash != restoredHash.Hash {
+		t.Errorf("HashKV mismatch at revision %d: %s", rev, describeHashKVs([]memberHashKV{liveHash, restoredHash}))
+	}
+}
+
+func keyspaceAt(ctx context.Context, t *testing.T, member e2e.EtcdProcess, rev int64) []*mvccpb.KeyValue {
+	c, err := clientv3.New(clientv3.Config{
+		Endpoints:   member.EndpointsGRPC(),
+		Logger:      zap.NewNop(),
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	resp, err := c.Get(ctx, "\x00", clientv3.WithFromKey(), clientv3.WithRev(rev))
+	if err != nil {
+		t.Fatalf("Failed to read keyspace of member %q at revision %d, err: %s", member.Config().Name, rev, err)
+	}
+	return resp.Kvs
+}
+
+func equalKeyValue(kv1, kv2 *mvccpb.KeyValue) bool {
+	return bytes.Equal(kv1.Key, kv2.Key) && bytes.Equal(kv1.Value, kv2.Value) &&
+		kv1.CreateRevision == kv2.CreateRevision && kv1.ModRevision == kv2.ModRevision &&
+		kv1.Version == kv2.Version && kv1.Lease == kv2.Lease
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+)
+
+func TestSnapshotMatchesLive(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	c, err := client.NewRecordingClient(clus.EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	require.NoError(t, err)
+	defer c.Close()
+	for i := 0; i < 50; i++ {
+		_, err = c.Put(ctx, fmt.Sprintf("key%d", i%10), fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	_, err = c.Delete(ctx, "key0")
+	require.NoError(t, err)
+
+	restored, rev := RestoreSnapshotCluster(ctx, t, clus.Procs[0], e2e.WithBasePort(clus.NextBasePort()))
+	defer restored.Close()
+	// Writes after snapshot should not impact the comparison.
+	_, err = c.Put(ctx, "key1", "after-snapshot")
+	require.NoError(t, err)
+
+	AssertSnapshotMatchesLive(ctx, t, clus, restored, rev)
+}
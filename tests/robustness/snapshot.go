@@ -0,0 +1,308 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/failpoint"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+// AssertSnapshotRestartSafe calls writeFn until the member takes a snapshot, kills
+// the member and restarts it. Restarted member is expected to recover from the
+// snapshot and converge with the rest of the cluster without losing any write
+// acknowledged to the client. Returns report of the recorded writes.
+func AssertSnapshotRestartSafe(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, memberIdx int, writeFn func(ctx context.Context, c *client.RecordingClient) error) report.ClientReport {
+	lg := zaptest.NewLogger(t)
+	member := clus.Procs[memberIdx]
+	c, err := client.NewRecordingClient(clus.EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	status, err := c.Status(ctx, member.EndpointsGRPC()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Snapshot is triggered after applying SnapshotCount entries since the last one.
+	snapshotIndex := status.RaftIndex + clus.Cfg.ServerConfig.SnapshotCount + 1
+	for status.RaftIndex < snapshotIndex {
+		if err = writeFn(ctx, c); err != nil {
+			t.Fatalf("Failed to write, err: %s", err)
+		}
+		status, err = c.Status(ctx, member.EndpointsGRPC()[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lg.Info("Killing member after snapshot", zap.String("member", member.Config().Name), zap.Uint64("raft-index", status.RaftIndex))
+	if err = failpoint.KillAndWait(ctx, lg, member); err != nil {
+		t.Fatal(err)
+	}
+	if err = member.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.Report()
+	lastRevision := lastSuccessfulWriteRevision(r)
+	revision := waitForRevisionConvergence(ctx, t, c, clus)
+	if revision < lastRevision {
+		t.Fatalf("Lost committed writes, cluster converged at revision %d, last acknowledged write at revision %d", revision, lastRevision)
+	}
+	if err = CheckHashKV(ctx, clus, revision); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// AssertCatchUpViaSnapshot isolates a follower, writes until the leader compacts its raft log
+// past the follower index and compacts the keyspace, then heals the follower. Follower is expected
+// to catch up by receiving and applying a snapshot from the leader, and to converge with the rest
+// of the cluster. Returns raft index of the applied snapshot. Requires peer proxy.
+func AssertCatchUpViaSnapshot(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, memberIdx int) (snapshotIndex uint64) {
+	lg := zaptest.NewLogger(t)
+	leaderIdx := clus.WaitLeader(t)
+	if leaderIdx == memberIdx {
+		t.Fatalf("Member %q must be a follower to catch up via snapshot", clus.Procs[memberIdx].Config().Name)
+	}
+	leader, member := clus.Procs[leaderIdx], clus.Procs[memberIdx]
+	proxy := member.PeerProxy()
+	if proxy == nil {
+		t.Fatal("Catch up via snapshot requires peer proxy")
+	}
+	c, err := client.NewRecordingClient(leader.EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	memberStatus, err := c.Status(ctx, member.EndpointsGRPC()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg.Info("Isolating member", zap.String("member", member.Config().Name), zap.Uint64("raft-index", memberStatus.RaftIndex))
+	proxy.BlackholeTx()
+	proxy.BlackholeRx()
+	// Leader needs to compact raft log past the member index, for the member to require a snapshot.
+	entries := clus.Cfg.ServerConfig.SnapshotCount + clus.Cfg.ServerConfig.SnapshotCatchUpEntries + 1
+	for i := uint64(0); i < entries; i++ {
+		if _, err = c.Put(ctx, fmt.Sprintf("key%d", i%10), fmt.Sprintf("%d", i)); err != nil {
+			t.Fatalf("Failed to write, err: %s", err)
+		}
+	}
+	compactRevision := lastSuccessfulWriteRevision(c.Report())
+	if _, err = c.Compact(ctx, compactRevision, false); err != nil {
+		t.Fatalf("Failed to compact, err: %s", err)
+	}
+
+	lg.Info("Healing member", zap.String("member", member.Config().Name), zap.Int64("compact-revision", compactRevision))
+	proxy.UnblackholeTx()
+	proxy.UnblackholeRx()
+	sending := fmt.Sprintf(`"msg":"sending database snapshot".*"remote-peer-id":"%x"`, memberStatus.Header.MemberId)
+	if _, err = leader.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: sending, IsRegularExpr: true}); err != nil {
+		t.Fatalf("Leader didn't send snapshot to member %q, err: %s", member.Config().Name, err)
+	}
+	line, err := member.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: "applied snapshot"})
+	if err != nil {
+		t.Fatalf("Member %q didn't apply snapshot, err: %s", member.Config().Name, err)
+	}
+	var applied struct {
+		SnapshotIndex uint64 `json:"incoming-leader-snapshot-index"`
+	}
+	if err = json.Unmarshal([]byte(line), &applied); err != nil {
+		t.Fatalf("Failed to parse applied snapshot log %q, err: %s", line, err)
+	}
+	if applied.SnapshotIndex <= memberStatus.RaftIndex {
+		t.Errorf("Member %q applied snapshot at index %d, not past its index %d before isolation", member.Config().Name, applied.SnapshotIndex, memberStatus.RaftIndex)
+	}
+
+	revision := waitForRevisionConvergence(ctx, t, c, clus)
+	lg.Info("Member caught up via snapshot",
+		zap.String("member", member.Config().Name),
+		zap.Uint64("snapshot-index", applied.SnapshotIndex),
+		zap.Int64("compact-revision", compactRevision),
+		zap.Int64("converged-revision", revision),
+	)
+	if revision < compactRevision {
+		t.Fatalf("Member converged at revision %d, before compact revision %d", revision, compactRevision)
+	}
+	return applied.SnapshotIndex
+}
+
+// AssertFollowersCatchUpUnderDelay delays by latency ± jitter traffic of the leader peer proxy, which
+// carries raft messages streamed from the leader to followers, without partitioning any member.
+// Writes through the leader are expected to commit, with followers lagging behind the leader while
+// delayed and converging with it after delay is removed. Returns maximal observed follower lag in
+// revisions. Requires peer proxy.
+func AssertFollowersCatchUpUnderDelay(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, latency, jitter time.Duration) (maxLag int64) {
+	lg := zaptest.NewLogger(t)
+	leader := clus.Procs[clus.WaitLeader(t)]
+	proxy := leader.PeerProxy()
+	if proxy == nil {
+		t.Fatal("Catch up under delay requires peer proxy")
+	}
+	c, err := client.NewRecordingClient(leader.EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	lg.Info("Delaying leader peer traffic", zap.String("leader", leader.Config().Name), zap.Duration("latency", latency), zap.Duration("jitter", jitter))
+	proxy.DelayTx(latency, jitter)
+	proxy.DelayRx(latency, jitter)
+	for i := 0; i < 20; i++ {
+		resp, err := c.Put(ctx, fmt.Sprintf("key%d", i%10), fmt.Sprintf("%d", i))
+		if err != nil {
+			t.Fatalf("Failed to write, err: %s", err)
+		}
+		for _, member := range clus.Procs {
+			if member == leader {
+				continue
+			}
+			status, err := c.Status(ctx, member.EndpointsGRPC()[0])
+			if err != nil {
+				t.Fatalf("Failed to get status of member %q, err: %s", member.Config().Name, err)
+			}
+			maxLag = max(maxLag, resp.Header.Revision-status.Header.Revision)
+		}
+	}
+	proxy.UndelayTx()
+	proxy.UndelayRx()
+	if maxLag <= 0 {
+		t.Errorf("Followers didn't lag behind leader %q while delayed", leader.Config().Name)
+	}
+
+	revision := waitForRevisionConvergence(ctx, t, c, clus)
+	lg.Info("Followers caught up after delay",
+		zap.Int64("max-lag", maxLag),
+		zap.Int64("converged-revision", revision),
+	)
+	return maxLag
+}
+
+// snapshotTransferBandwidth is low enough to prolong snapshot transfer to a couple of seconds.
+const snapshotTransferBandwidth = 100 * 1024
+
+// AssertCompactDuringSnapshot blackholes a follower until it needs a snapshot to catch up,
+// throttles its peer link to prolong the snapshot transfer and compacts the leader
+// while the snapshot is being sent. Follower is expected to converge with the rest of
+// the cluster and CheckHashKV to pass. Requires peer proxy.
+func AssertCompactDuringSnapshot(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster) {
+	lg := zaptest.NewLogger(t)
+	leaderIdx := clus.WaitLeader(t)
+	leader := clus.Procs[leaderIdx]
+	follower := clus.Procs[(leaderIdx+1)%len(clus.Procs)]
+	proxy := follower.PeerProxy()
+	if proxy == nil {
+		t.Fatal("Compaction during snapshot requires peer proxy")
+	}
+	c, err := client.NewRecordingClient(leader.EndpointsGRPC(), identity.NewIDProvider(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	followerStatus, err := c.Status(ctx, follower.EndpointsGRPC()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg.Info("Blackholing follower", zap.String("member", follower.Config().Name), zap.Int64("revision", followerStatus.Header.Revision))
+	proxy.BlackholeTx()
+	proxy.BlackholeRx()
+	// Leader needs to compact raft log past the follower index, for the follower to require a snapshot.
+	entries := clus.Cfg.ServerConfig.SnapshotCount + clus.Cfg.ServerConfig.SnapshotCatchUpEntries + 1
+	for i := uint64(0); i < entries; i++ {
+		if _, err = c.Put(ctx, fmt.Sprintf("key%d", i%10), fmt.Sprintf("%d", i)); err != nil {
+			t.Fatalf("Failed to write, err: %s", err)
+		}
+	}
+
+	proxy.BandwidthDelay(snapshotTransferBandwidth)
+	proxy.UnblackholeTx()
+	proxy.UnblackholeRx()
+	defer proxy.UnbandwidthDelay()
+	if _, err = leader.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: "sending database snapshot"}); err != nil {
+		t.Fatalf("Leader didn't start sending snapshot, err: %s", err)
+	}
+	compactRevision := lastSuccessfulWriteRevision(c.Report())
+	lg.Info("Compacting during snapshot transfer", zap.String("leader", leader.Config().Name), zap.Int64("compact-revision", compactRevision))
+	if _, err = c.Compact(ctx, compactRevision, false); err != nil {
+		t.Fatalf("Failed to compact, err: %s", err)
+	}
+	proxy.UnbandwidthDelay()
+
+	revision := waitForRevisionConvergence(ctx, t, c, clus)
+	lg.Info("Members converged after compaction during snapshot",
+		zap.Int64("follower-revision-before", followerStatus.Header.Revision),
+		zap.Int64("compact-revision", compactRevision),
+		zap.Int64("converged-revision", revision),
+	)
+	if err = CheckHashKV(ctx, clus, revision); err != nil {
+		t.Fatalf("Hash mismatch after compaction during snapshot (compact revision %d, converged revision %d), err: %s", compactRevision, revision, err)
+	}
+}
+
+func lastSuccessfulWriteRevision(r report.ClientReport) (revision int64) {
+	for _, op := range r.KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		if request.IsRead() || response.Error != "" {
+			continue
+		}
+		revision = max(revision, response.Revision)
+	}
+	return revision
+}
+
+// waitForRevisionConvergence waits until all members report the same revision.
+func waitForRevisionConvergence(ctx context.Context, t *testing.T, c *client.RecordingClient, clus *e2e.EtcdProcessCluster) int64 {
+	for {
+		revisions := map[int64]struct{}{}
+		var revision int64
+		for _, member := range clus.Procs {
+			status, err := c.Status(ctx, member.EndpointsGRPC()[0])
+			if err != nil {
+				t.Logf("Failed to get status of member %q, err: %s", member.Config().Name, err)
+				revisions[0] = struct{}{}
+				continue
+			}
+			revision = status.Header.Revision
+			revisions[revision] = struct{}{}
+		}
+		if _, failed := revisions[0]; !failed && len(revisions) == 1 {
+			return revision
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Members didn't converge, err: %s", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
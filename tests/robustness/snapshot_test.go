@@ -0,0 +1,80 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+)
+
+func TestSnapshotRestartSafe(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithSnapshotCount(50), e2e.WithSnapshotCatchUpEntries(10))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	i := 0
+	r := AssertSnapshotRestartSafe(ctx, t, clus, 1, func(ctx context.Context, c *client.RecordingClient) error {
+		i++
+		_, err := c.Put(ctx, fmt.Sprintf("key%d", i%10), fmt.Sprintf("%d", i))
+		return err
+	})
+	require.NotEmpty(t, r.KeyValue)
+}
+
+func TestCompactDuringSnapshot(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithIsPeerTLS(true), e2e.WithPeerProxy(true), e2e.WithSnapshotCount(50), e2e.WithSnapshotCatchUpEntries(10))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	AssertCompactDuringSnapshot(ctx, t, clus)
+}
+
+func TestCatchUpViaSnapshot(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithIsPeerTLS(true), e2e.WithPeerProxy(true), e2e.WithSnapshotCount(50), e2e.WithSnapshotCatchUpEntries(10))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	follower := (clus.WaitLeader(t) + 1) % len(clus.Procs)
+	snapshotIndex := AssertCatchUpViaSnapshot(ctx, t, clus, follower)
+	t.Logf("Member caught up via snapshot at index %d", snapshotIndex)
+}
+
+func TestFollowersCatchUpUnderDelay(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithIsPeerTLS(true), e2e.WithPeerProxy(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	maxLag := AssertFollowersCatchUpUnderDelay(ctx, t, clus, 100*time.Millisecond, 20*time.Millisecond)
+	t.Logf("Followers lagged by up to %d revisions", maxLag)
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/failpoint"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+	"go.etcd.io/etcd/tests/v3/robustness/traffic"
+	"go.etcd.io/etcd/tests/v3/robustness/validate"
+)
+
+// SoakConfig configures a soak test run by RunSoak.
+type SoakConfig struct {
+	// Duration after which no new round is started.
+	Duration time.Duration
+	Traffic  traffic.Traffic
+	Profile  traffic.Profile
+	// Failpoint injected in every round, picked randomly for each round if nil.
+	Failpoint failpoint.Failpoint
+	// ReportDir is the directory reports of rounds are saved to, temporary directory if empty.
+	ReportDir string
+}
+
+// RunSoak runs rounds of traffic, each injecting a failpoint, until configured duration passes.
+// After every round, reports of the round are streamed to disk and checked by validations
+// that don't need the complete history, and hashes of members are compared at the revision
+// they converged to. Reports are dropped afterwards, so memory doesn't grow with duration and
+// the test can run for hours. Linearizability and watch guarantees requiring a replay are not
+// validated, as they need the complete history. Test fails at the end of the first round
+// that detects a violation. Returns number of completed rounds.
+func RunSoak(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, cfg SoakConfig) (rounds int) {
+	lg := zaptest.NewLogger(t)
+	reportDir := cfg.ReportDir
+	if reportDir == "" {
+		reportDir = t.TempDir()
+	}
+	ids := identity.NewIDProvider()
+	stopLeaderMonitor := StartLeaderUniquenessMonitor(ctx, t, clus, 100*time.Millisecond)
+	defer stopLeaderMonitor()
+
+	start := time.Now()
+	for ; time.Since(start) < cfg.Duration; rounds++ {
+		fp := cfg.Failpoint
+		if fp == nil {
+			var err error
+			fp, err = failpoint.PickRandom(clus, cfg.Profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		lg.Info("Starting soak round", zap.Int("round", rounds), zap.String("failpoint", fp.Name()))
+		reports := runSoakRound(ctx, t, lg, clus, cfg, fp, ids)
+		persistSoakRound(t, filepath.Join(reportDir, fmt.Sprintf("round-%d.ndjson", rounds)), reports)
+		if err := validate.ValidateWithoutHistory(lg, validate.Config{ExpectRevisionUnique: cfg.Traffic.ExpectUniqueRevision()}, reports); err != nil {
+			t.Fatalf("Soak round %d failed validation, err: %s", rounds, err)
+		}
+		revision := soakRoundRevision(ctx, t, clus, ids)
+		if err := CheckHashKV(ctx, clus, revision); err != nil {
+			t.Fatalf("Soak round %d failed integrity check, err: %s", rounds, err)
+		}
+		if t.Failed() {
+			t.FailNow()
+		}
+		lg.Info("Finished soak round", zap.Int("round", rounds), zap.Int64("revision", revision), zap.Duration("elapsed", time.Since(start)))
+	}
+	return rounds
+}
+
+func runSoakRound(ctx context.Context, t *testing.T, lg *zap.Logger, clus *e2e.EtcdProcessCluster, cfg SoakConfig, fp failpoint.Failpoint, ids identity.Provider) (reports []report.ClientReport) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	g := errgroup.Group{}
+	var operationReport, failpointClientReport []report.ClientReport
+	failpointInjected := make(chan report.FailpointInjection, 1)
+	// Operations of a round share base time, so they can be merged on a single timeline.
+	baseTime := time.Now()
+	g.Go(func() error {
+		defer close(failpointInjected)
+		// Give some time for traffic to reach qps target before injecting failpoint.
+		time.Sleep(time.Second)
+		fr, err := failpoint.Inject(ctx, t, lg, clus, fp, baseTime, ids)
+		if err != nil {
+			t.Error(err)
+			cancel()
+		}
+		// Give some time for traffic to reach qps target after injecting failpoint.
+		time.Sleep(time.Second)
+		if fr != nil {
+			failpointInjected <- fr.FailpointInjection
+			failpointClientReport = fr.Client
+		}
+		return nil
+	})
+	g.Go(func() error {
+		operationReport = traffic.SimulateTraffic(ctx, t, lg, clus, cfg.Profile, cfg.Traffic, failpointInjected, baseTime, ids)
+		return nil
+	})
+	g.Wait()
+	return append(operationReport, failpointClientReport...)
+}
+
+// persistSoakRound writes reports of a round to a single report stream file.
+func persistSoakRound(t *testing.T, path string, reports []report.ClientReport) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := client.WriteReports(f, reports...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// soakRoundRevision waits for members to converge after a round and returns their revision.
+func soakRoundRevision(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster, ids identity.Provider) int64 {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	c, err := client.NewRecordingClient(clus.EndpointsGRPC(), ids, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	return waitForRevisionConvergence(ctx, t, c, clus)
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/failpoint"
+	"go.etcd.io/etcd/tests/v3/robustness/traffic"
+)
+
+// TestSoakSmoke runs a short soak to be run in CI, longer runs only differ by duration.
+func TestSoakSmoke(t *testing.T) {
+	testRunner.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3))
+	require.NoError(t, err)
+	defer forcestopCluster(clus)
+
+	rounds := RunSoak(ctx, t, clus, SoakConfig{
+		Duration:  30 * time.Second,
+		Traffic:   traffic.EtcdPut,
+		Profile:   traffic.LowTraffic,
+		Failpoint: failpoint.KillFailpoint,
+	})
+	require.Positive(t, rounds)
+}
@@ -287,7 +287,7 @@ func (c etcdTrafficClient) Request(ctx context.Context, request etcdRequestType,
 		}
 	case Compact:
 		var resp *clientv3.CompactResponse
-		resp, err = c.client.Compact(opCtx, lastRev)
+		resp, err = c.client.Compact(opCtx, lastRev, false)
 		if resp != nil {
 			rev = resp.Header.Revision
 		}
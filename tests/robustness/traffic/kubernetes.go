@@ -271,7 +271,7 @@ func (k kubernetesClient) RequestProgress(ctx context.Context) error {
 }
 
 func (k kubernetesClient) Compact(ctx context.Context, rev int64) error {
-	_, err := k.client.Compact(ctx, rev)
+	_, err := k.client.Compact(ctx, rev, false)
 	return err
 }
 
@@ -0,0 +1,115 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokeCompareAndSwap = errors.New("broke compare and swap - key changed outside of a recorded swap")
+
+// ValidateCompareAndSwap checks that key was only changed by compare and swap transactions,
+// conditioned on key mod revision. Every value observed by reads and watches needs to be
+// written by a successful swap at the observed revision, or by a swap with unknown result.
+// A successful swap expecting revision R, applied at revision X, requires no other known
+// write to the key between R and X.
+func ValidateCompareAndSwap(lg *zap.Logger, reports []report.ClientReport, key string) (err error) {
+	confirmed := map[int64]casSwap{}
+	unknown := map[model.ValueOrHash]struct{}{}
+	observed := map[int64]model.ValueOrHash{}
+	for _, r := range reports {
+		for _, op := range r.KeyValue {
+			request := op.Input.(model.EtcdRequest)
+			response := op.Output.(model.MaybeEtcdResponse)
+			if s, ok := compareAndSwap(request, key); ok {
+				switch {
+				case response.Error == "" && response.Txn != nil && !response.Txn.Failure:
+					confirmed[response.Revision] = s
+				case response.Error != "" && response.Indeterminate:
+					unknown[s.value] = struct{}{}
+				}
+				continue
+			}
+			_, kvs := observedKeyValues(request, response)
+			for _, kv := range kvs {
+				if kv.Key == key {
+					observed[kv.ModRevision] = kv.Value
+				}
+			}
+		}
+		for _, watch := range r.Watch {
+			for _, resp := range watch.Responses {
+				for _, event := range resp.Events {
+					if event.Key == key && event.Type == model.PutOperation {
+						observed[event.Revision] = event.Value
+					}
+				}
+			}
+		}
+	}
+	for revision, value := range observed {
+		if s, ok := confirmed[revision]; ok && s.value == value {
+			continue
+		}
+		if _, ok := unknown[value]; ok {
+			continue
+		}
+		lg.Error("Value not written by compare and swap", zap.String("key", key), zap.Any("value", value), zap.Int64("revision", revision))
+		err = errBrokeCompareAndSwap
+	}
+	for revision, s := range confirmed {
+		for write := range knownWrites(observed, confirmed) {
+			if write > s.expectedRevision && write < revision {
+				lg.Error("Swap applied over unexpected write", zap.String("key", key), zap.Int64("revision", revision), zap.Int64("expected-revision", s.expectedRevision), zap.Int64("write-revision", write))
+				err = errBrokeCompareAndSwap
+			}
+		}
+	}
+	return err
+}
+
+type casSwap struct {
+	expectedRevision int64
+	value            model.ValueOrHash
+}
+
+func knownWrites(observed map[int64]model.ValueOrHash, confirmed map[int64]casSwap) map[int64]struct{} {
+	writes := map[int64]struct{}{}
+	for revision := range observed {
+		writes[revision] = struct{}{}
+	}
+	for revision := range confirmed {
+		writes[revision] = struct{}{}
+	}
+	return writes
+}
+
+func compareAndSwap(request model.EtcdRequest, key string) (s casSwap, ok bool) {
+	if request.Type != model.Txn || len(request.Txn.Conditions) != 1 || len(request.Txn.OperationsOnSuccess) != 1 {
+		return s, false
+	}
+	cond, op := request.Txn.Conditions[0], request.Txn.OperationsOnSuccess[0]
+	if cond.Key != key || !cond.IsModRevisionEqual() || op.Type != model.PutOperation || op.Put.Key != key {
+		return s, false
+	}
+	s.expectedRevision = cond.ExpectedRevision
+	s.value = op.Put.Value
+	return s, true
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateCompareAndSwap(t *testing.T) {
+	tcs := []struct {
+		name        string
+		operations  []porcupine.Operation
+		expectError error
+	}{
+		{
+			name: "Values set by swaps",
+			operations: []porcupine.Operation{
+				{Input: casRequest("a", 0, "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+				{Input: casRequest("a", 0, "1"), Output: model.MaybeEtcdResponse{EtcdResponse: model.EtcdResponse{Txn: &model.TxnResponse{Failure: true}, Revision: 2}}},
+				{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2))},
+				{Input: casRequest("a", 2, "2"), Output: model.MaybeEtcdResponse{Error: "timeout", Indeterminate: true}},
+				{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(3, keyValue("a", "2", 3))},
+			},
+		},
+		{
+			name: "Value not set by any swap",
+			operations: []porcupine.Operation{
+				{Input: casRequest("a", 0, "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+				{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(3, keyValue("a", "5", 3))},
+			},
+			expectError: errBrokeCompareAndSwap,
+		},
+		{
+			name: "Swap applied over another write",
+			operations: []porcupine.Operation{
+				{Input: casRequest("a", 0, "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+				{Input: casRequest("a", 0, "2"), Output: txnResponse(3, model.EtcdOperationResult{})},
+			},
+			expectError: errBrokeCompareAndSwap,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCompareAndSwap(zaptest.NewLogger(t), []report.ClientReport{{KeyValue: tc.operations}}, "a")
+			if err != tc.expectError {
+				t.Errorf("ValidateCompareAndSwap(...), got: %q, want: %q", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func casRequest(key string, expectedRevision int64, value string) model.EtcdRequest {
+	request := putRequest(key, value)
+	request.Txn.Conditions = []model.EtcdCondition{{Key: key, ExpectedRevision: expectedRevision}}
+	return request
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokeCompactionMonotonic = errors.New("broke compaction monotonic - compaction revision must never decrease")
+
+// compactionObservation represents compaction revision observed by client between start and end.
+// Exact observations report the compaction revision, others only guarantee it was at least revision.
+type compactionObservation struct {
+	start, end int64
+	revision   int64
+	exact      bool
+	source     string
+}
+
+// ValidateCompactionMonotonic checks that compaction revision observed by the client never decreases.
+// Successful compactions and watches canceled due to compaction observe the exact compaction revision,
+// while compactions rejected as already compacted only observe its lower bound.
+// Status responses don't include compaction revision so they cannot be used.
+func ValidateCompactionMonotonic(r report.ClientReport) error {
+	observations := compactionObservations(r)
+	sort.Slice(observations, func(i, j int) bool {
+		return observations[i].end < observations[j].end
+	})
+	// highest[i] is the observation with the highest revision among observations[:i+1].
+	highest := make([]compactionObservation, len(observations))
+	for i, o := range observations {
+		highest[i] = o
+		if i > 0 && highest[i-1].revision > o.revision {
+			highest[i] = highest[i-1]
+		}
+	}
+	for _, o := range observations {
+		if !o.exact {
+			continue
+		}
+		// Only observations that finished before this one started are ordered before it.
+		i := sort.Search(len(observations), func(i int) bool {
+			return observations[i].end >= o.start
+		})
+		if i == 0 {
+			continue
+		}
+		if prev := highest[i-1]; prev.revision > o.revision {
+			return fmt.Errorf("%w, client: %d, %s observed compaction revision %d, after %s observed %d", errBrokeCompactionMonotonic, r.ClientID, o.source, o.revision, prev.source, prev.revision)
+		}
+	}
+	return nil
+}
+
+func compactionObservations(r report.ClientReport) (observations []compactionObservation) {
+	for _, op := range r.KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		if request.Type != model.Compact || response.Error != "" {
+			continue
+		}
+		switch response.ClientError {
+		case "":
+			observations = append(observations, compactionObservation{start: op.Call, end: op.Return, revision: request.Compact.Revision, exact: true, source: "compact"})
+		case mvcc.ErrCompacted.Error():
+			observations = append(observations, compactionObservation{start: op.Call, end: op.Return, revision: request.Compact.Revision, source: "rejected compact"})
+		}
+	}
+	for _, op := range r.Watch {
+		for _, resp := range op.Responses {
+			if resp.CompactRevision == 0 {
+				continue
+			}
+			observations = append(observations, compactionObservation{start: resp.Time.Nanoseconds(), end: resp.Time.Nanoseconds(), revision: resp.CompactRevision, exact: true, source: "watch"})
+		}
+	}
+	return observations
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateCompactionMonotonic(t *testing.T) {
+	compacted := model.MaybeEtcdResponse{EtcdResponse: model.EtcdResponse{Compact: &model.CompactResponse{}, Revision: -1}}
+	alreadyCompacted := model.MaybeEtcdResponse{EtcdResponse: model.EtcdResponse{ClientError: mvcc.ErrCompacted.Error()}}
+	tcs := []struct {
+		name        string
+		report      report.ClientReport
+		expectError error
+	}{
+		{
+			name: "Increasing compactions",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: compactRequest(5), Output: compacted, Call: 1, Return: 2},
+					{Input: compactRequest(3), Output: alreadyCompacted, Call: 3, Return: 4},
+					{Input: compactRequest(10), Output: compacted, Call: 5, Return: 6},
+				},
+				Watch: []model.WatchOperation{
+					{Responses: []model.WatchResponse{{Canceled: true, CompactRevision: 10, Time: 7}}},
+				},
+			},
+		},
+		{
+			name: "Concurrent observations are not ordered",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: compactRequest(10), Output: compacted, Call: 1, Return: 4},
+				},
+				Watch: []model.WatchOperation{
+					{Responses: []model.WatchResponse{{Canceled: true, CompactRevision: 5, Time: 2}}},
+				},
+			},
+		},
+		{
+			name: "Compaction regressed",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: compactRequest(10), Output: compacted, Call: 1, Return: 2},
+					{Input: compactRequest(5), Output: compacted, Call: 3, Return: 4},
+				},
+			},
+			expectError: errBrokeCompactionMonotonic,
+		},
+		{
+			name: "Watch observed compaction regression",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: compactRequest(10), Output: compacted, Call: 1, Return: 2},
+				},
+				Watch: []model.WatchOperation{
+					{Responses: []model.WatchResponse{{Canceled: true, CompactRevision: 5, Time: 3}}},
+				},
+			},
+			expectError: errBrokeCompactionMonotonic,
+		},
+		{
+			name: "Compaction regressed below already compacted revision",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: compactRequest(10), Output: alreadyCompacted, Call: 1, Return: 2},
+					{Input: compactRequest(5), Output: compacted, Call: 3, Return: 4},
+				},
+			},
+			expectError: errBrokeCompactionMonotonic,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCompactionMonotonic(tc.report)
+			if !errors.Is(err, tc.expectError) {
+				t.Errorf("ValidateCompactionMonotonic(...), got: %v, expected: %v", err, tc.expectError)
+			}
+		})
+	}
+}
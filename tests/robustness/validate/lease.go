@@ -0,0 +1,198 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokeLeaseRevokeDeletesKeys = errors.New("broke LeaseRevokeDeletesKeys - keys attached to a revoked lease must be deleted at the revoke revision")
+
+// unknownLease marks writes observed only through watch events, which don't include the lease.
+const unknownLease = -1
+
+// leaseWrite represents a write to key at revision, together with lease the key was attached to.
+type leaseWrite struct {
+	revision int64
+	leaseID  int64
+	deleted  bool
+}
+
+// rangeDelete is a successful delete of key range at revision.
+type rangeDelete struct {
+	options  model.DeleteOptions
+	revision int64
+}
+
+// ValidateLeaseRevokeDeletesKeys checks that after a successful lease revoke, all keys that
+// the client attached to the lease are deleted at the revoke revision.
+// Key is attached to the lease by its last write before the revoke, so keys that were
+// overwritten or deleted in the meantime, for example reattached to other lease, are not checked.
+// Deletion is verified both with watch events and reads that observe the revoke revision.
+func ValidateLeaseRevokeDeletesKeys(r report.ClientReport) error {
+	writes := leaseKeyWrites(r)
+	events := keyWatchEvents(r)
+	for _, op := range r.KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		if request.Type != model.LeaseRevoke || response.Error != "" || response.ClientError != "" || response.LeaseRevoke == nil {
+			continue
+		}
+		leaseID := request.LeaseRevoke.LeaseID
+		revokeRevision := response.Revision
+		for key, leaseWrites := range writes {
+			attached, ok := attachedAtRevoke(leaseWrites, revokeRevision)
+			if !ok || attached.leaseID != leaseID {
+				continue
+			}
+			if event, ok := firstEventAfter(events[key], attached.revision); ok && (event.Type != model.DeleteOperation || event.Revision != revokeRevision) {
+				return fmt.Errorf("%w, client: %d, lease: %d, revoke revision: %d, key %q attached at revision %d was next observed in watch as %s at revision %d", errBrokeLeaseRevokeDeletesKeys, r.ClientID, leaseID, revokeRevision, key, attached.revision, event.Type, event.Revision)
+			}
+			for _, read := range r.KeyValue {
+				if read.Call < op.Return {
+					continue
+				}
+				readRevision, kvs := observedKeyValues(read.Input.(model.EtcdRequest), read.Output.(model.MaybeEtcdResponse))
+				if readRevision < revokeRevision {
+					continue
+				}
+				for _, kv := range kvs {
+					if kv.Key == key && kv.ModRevision < revokeRevision {
+						return fmt.Errorf("%w, client: %d, lease: %d, revoke revision: %d, key %q attached at revision %d was read at revision %d", errBrokeLeaseRevokeDeletesKeys, r.ClientID, leaseID, revokeRevision, key, kv.ModRevision, readRevision)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// leaseKeyWrites returns writes to each key sorted by revision.
+// Writes done by the client have known lease, other writes are only observed by watch.
+func leaseKeyWrites(r report.ClientReport) map[string][]leaseWrite {
+	known := map[string]map[int64]leaseWrite{}
+	record := func(key string, write leaseWrite) {
+		if known[key] == nil {
+			known[key] = map[int64]leaseWrite{}
+		}
+		known[key][write.revision] = write
+	}
+	var rangeDeletes []rangeDelete
+	for _, op := range r.KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		if request.Type != model.Txn || response.Error != "" || response.PartialResponse || response.Txn == nil {
+			continue
+		}
+		results := request.Txn.ExecutedResults(response.Txn)
+		for i, etcdOp := range request.Txn.ExecutedOperations(response.Txn) {
+			switch etcdOp.Type {
+			case model.PutOperation:
+				record(etcdOp.Put.Key, leaseWrite{revision: response.Revision, leaseID: etcdOp.Put.LeaseID})
+			case model.DeleteOperation:
+				if i >= len(results) || results[i].Deleted == 0 {
+					continue
+				}
+				if etcdOp.Delete.End != "" {
+					rangeDeletes = append(rangeDeletes, rangeDelete{options: etcdOp.Delete, revision: response.Revision})
+					continue
+				}
+				record(etcdOp.Delete.Key, leaseWrite{revision: response.Revision, deleted: true})
+			}
+		}
+	}
+	for _, op := range r.Watch {
+		for _, resp := range op.Responses {
+			for _, event := range resp.Events {
+				if _, ok := known[event.Key][event.Revision]; ok {
+					continue
+				}
+				if event.Type == model.DeleteOperation {
+					record(event.Key, leaseWrite{revision: event.Revision, deleted: true})
+				} else {
+					record(event.Key, leaseWrite{revision: event.Revision, leaseID: unknownLease})
+				}
+			}
+		}
+	}
+	// Deleted keys of range delete are not known, so it is applied to all known keys in range.
+	// Marking key that didn't exist at delete revision as deleted doesn't change its lease.
+	for _, d := range rangeDeletes {
+		for key := range known {
+			if d.options.Contains(key) {
+				record(key, leaseWrite{revision: d.revision, deleted: true})
+			}
+		}
+	}
+	writes := map[string][]leaseWrite{}
+	for key, revisions := range known {
+		for _, write := range revisions {
+			writes[key] = append(writes[key], write)
+		}
+		sort.Slice(writes[key], func(i, j int) bool {
+			return writes[key][i].revision < writes[key][j].revision
+		})
+	}
+	return writes
+}
+
+// keyWatchEvents returns events observed by watches for each key sorted by revision.
+func keyWatchEvents(r report.ClientReport) map[string][]model.WatchEvent {
+	events := map[string][]model.WatchEvent{}
+	for _, op := range r.Watch {
+		for _, resp := range op.Responses {
+			for _, event := range resp.Events {
+				events[event.Key] = append(events[event.Key], event)
+			}
+		}
+	}
+	for key := range events {
+		sort.SliceStable(events[key], func(i, j int) bool {
+			return events[key][i].Revision < events[key][j].Revision
+		})
+	}
+	return events
+}
+
+// attachedAtRevoke returns the last write to key before revoke at revision.
+// Revoke that deleted no keys doesn't increase revision, so puts at revoke revision happened before it,
+// while delete at revoke revision is the revoke itself.
+func attachedAtRevoke(writes []leaseWrite, revision int64) (leaseWrite, bool) {
+	i := sort.Search(len(writes), func(i int) bool {
+		return writes[i].revision > revision
+	})
+	if i > 0 && writes[i-1].revision == revision && writes[i-1].deleted {
+		i--
+	}
+	if i == 0 || writes[i-1].deleted {
+		return leaseWrite{}, false
+	}
+	return writes[i-1], true
+}
+
+func firstEventAfter(events []model.WatchEvent, revision int64) (model.WatchEvent, bool) {
+	i := sort.Search(len(events), func(i int) bool {
+		return events[i].Revision > revision
+	})
+	if i == len(events) {
+		return model.WatchEvent{}, false
+	}
+	return events[i], true
+}
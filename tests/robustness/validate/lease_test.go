@@ -0,0 +1,189 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateLeaseRevokeDeletesKeys(t *testing.T) {
+	tcs := []struct {
+		name        string
+		report      report.ClientReport
+		expectError error
+	}{
+		{
+			name: "Watch observes deletion of all keys at revoke revision",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{})},
+					{Input: putRequestWithLease("b", "2", 1), Output: txnResponse(3, model.EtcdOperationResult{})},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(4)},
+				},
+				Watch: []model.WatchOperation{
+					{
+						Responses: []model.WatchResponse{
+							{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+							{Events: []model.WatchEvent{putWatchEvent("b", "2", 3, true)}},
+							{Events: []model.WatchEvent{deleteWatchEvent("a", 4), deleteWatchEvent("b", 4)}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Watch observes deletion at different revision",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{})},
+					{Input: putRequestWithLease("b", "2", 1), Output: txnResponse(3, model.EtcdOperationResult{})},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(4)},
+				},
+				Watch: []model.WatchOperation{
+					{
+						Responses: []model.WatchResponse{
+							{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+							{Events: []model.WatchEvent{putWatchEvent("b", "2", 3, true)}},
+							{Events: []model.WatchEvent{deleteWatchEvent("a", 4)}},
+							{Events: []model.WatchEvent{deleteWatchEvent("b", 5)}},
+						},
+					},
+				},
+			},
+			expectError: errBrokeLeaseRevokeDeletesKeys,
+		},
+		{
+			name: "Watch observes put after revoke instead of deletion",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{})},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(3)},
+				},
+				Watch: []model.WatchOperation{
+					{
+						Responses: []model.WatchResponse{
+							{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+							{Events: []model.WatchEvent{putWatchEvent("a", "2", 4, false)}},
+						},
+					},
+				},
+			},
+			expectError: errBrokeLeaseRevokeDeletesKeys,
+		},
+		{
+			name: "Key reattached to other lease before revoke is not deleted",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{})},
+					{Input: putRequestWithLease("b", "2", 1), Output: txnResponse(3, model.EtcdOperationResult{})},
+					{Input: putRequestWithLease("b", "3", 2), Output: txnResponse(4, model.EtcdOperationResult{})},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(5)},
+					{Input: rangeRequest("a", "z", 0, 0), Output: rangeResponseWithRevision(5, keyValue("b", "3", 4))},
+				},
+				Watch: []model.WatchOperation{
+					{
+						Responses: []model.WatchResponse{
+							{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+							{Events: []model.WatchEvent{putWatchEvent("b", "2", 3, true)}},
+							{Events: []model.WatchEvent{putWatchEvent("b", "3", 4, false)}},
+							{Events: []model.WatchEvent{deleteWatchEvent("a", 5)}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Key overwritten by other client before revoke is not deleted",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{})},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(3)},
+					{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(3, keyValue("a", "2", 3))},
+				},
+				Watch: []model.WatchOperation{
+					{
+						Responses: []model.WatchResponse{
+							{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+							{Events: []model.WatchEvent{putWatchEvent("a", "2", 3, false)}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Read after revoke doesn't return attached key",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 0, Return: 1},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(3), Call: 2, Return: 3},
+					{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(3), Call: 4, Return: 5},
+				},
+			},
+		},
+		{
+			name: "Read after revoke returns attached key",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 0, Return: 1},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(3), Call: 2, Return: 3},
+					{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(3, keyValue("a", "1", 2)), Call: 4, Return: 5},
+				},
+			},
+			expectError: errBrokeLeaseRevokeDeletesKeys,
+		},
+		{
+			name: "Read concurrent with revoke returns attached key",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 0, Return: 1},
+					{Input: leaseRevokeRequest(1), Output: leaseRevokeResponse(3), Call: 2, Return: 5},
+					{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2)), Call: 3, Return: 4},
+				},
+			},
+		},
+		{
+			name: "Failed revoke is ignored",
+			report: report.ClientReport{
+				KeyValue: []porcupine.Operation{
+					{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 0, Return: 1},
+					{Input: leaseRevokeRequest(1), Output: errorResponse(errors.New("timeout")), Call: 2, Return: 3},
+					{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2)), Call: 4, Return: 5},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateLeaseRevokeDeletesKeys(tc.report)
+			if !errors.Is(err, tc.expectError) {
+				t.Errorf("ValidateLeaseRevokeDeletesKeys(...), got: %v, want: %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func leaseRevokeRequest(leaseID int64) model.EtcdRequest {
+	return model.EtcdRequest{Type: model.LeaseRevoke, LeaseRevoke: &model.LeaseRevokeRequest{LeaseID: leaseID}}
+}
+
+func leaseRevokeResponse(revision int64) model.MaybeEtcdResponse {
+	return model.MaybeEtcdResponse{EtcdResponse: model.EtcdResponse{Revision: revision, LeaseRevoke: &model.LeaseRevokeResponse{}}}
+}
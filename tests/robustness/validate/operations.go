@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap"
 
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/tests/v3/robustness/model"
 	"go.etcd.io/etcd/tests/v3/robustness/report"
 )
@@ -30,6 +31,7 @@ import (
 var (
 	errRespNotMatched         = errors.New("response didn't match expected")
 	errFutureRevRespRequested = errors.New("request about a future rev with response")
+	errNotCompactedRevision   = errors.New("compacted response for a rev that was not compacted")
 )
 
 func validateLinearizableOperationsAndVisualize(lg *zap.Logger, operations []porcupine.Operation, timeout time.Duration) (result porcupine.CheckResult, visualize func(basepath string) error) {
@@ -74,7 +76,7 @@ func filterSerializableOperations(clients []report.ClientReport) []porcupine.Ope
 	for _, client := range clients {
 		for _, op := range client.KeyValue {
 			request := op.Input.(model.EtcdRequest)
-			if request.Type == model.Range && request.Range.Revision != 0 {
+			if request.Type == model.Range && !request.Range.IsLinearizable() {
 				resp = append(resp, op)
 			}
 		}
@@ -86,7 +88,19 @@ func validateSerializableRead(lg *zap.Logger, replay *model.EtcdReplay, request
 	if response.PartialResponse || response.Error != "" {
 		return nil
 	}
-	state, err := replay.StateForRevision(request.Range.Revision)
+	if response.ClientError == mvcc.ErrCompacted.Error() {
+		if compactRevision := replay.CompactRevision(); request.Range.Revision >= compactRevision {
+			lg.Error("Failed validating serializable operation", zap.Any("request", request), zap.Int64("compact-revision", compactRevision))
+			return errNotCompactedRevision
+		}
+		return nil
+	}
+	// Serializable range without revision is served at revision of the contacted member.
+	revision := request.Range.Revision
+	if revision == 0 {
+		revision = response.Revision
+	}
+	state, err := replay.StateForRevision(revision)
 	if err != nil {
 		if response.Error == model.ErrEtcdFutureRev.Error() {
 			return nil
@@ -22,6 +22,7 @@ import (
 	"github.com/anishathalye/porcupine"
 	"go.uber.org/zap/zaptest"
 
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/tests/v3/robustness/model"
 )
 
@@ -81,7 +82,7 @@ func TestValidateSerializableOperations(t *testing.T) {
 				},
 				{
 					Input: rangeRequest("a", "z", 4, 2),
-					Output: rangeResponse(3,
+					Output: limitedRangeResponse(3,
 						keyValue("a", "1", 2),
 						keyValue("b", "2", 3),
 					),
@@ -220,6 +221,83 @@ func TestValidateSerializableOperations(t *testing.T) {
 			},
 			expectError: errFutureRevRespRequested.Error(),
 		},
+		{
+			name: "Compacted rev",
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				putRequest("b", "2"),
+				compactRequest(3),
+			},
+			operations: []porcupine.Operation{
+				{
+					Input:  rangeRequest("a", "z", 2, 0),
+					Output: model.MaybeEtcdResponse{EtcdResponse: model.EtcdResponse{ClientError: mvcc.ErrCompacted.Error()}},
+				},
+			},
+		},
+		{
+			name: "Compacted rev that was not compacted",
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				putRequest("b", "2"),
+				compactRequest(2),
+			},
+			operations: []porcupine.Operation{
+				{
+					Input:  rangeRequest("a", "z", 2, 0),
+					Output: model.MaybeEtcdResponse{EtcdResponse: model.EtcdResponse{ClientError: mvcc.ErrCompacted.Error()}},
+				},
+			},
+			expectError: errNotCompactedRevision.Error(),
+		},
+		{
+			name: "Serializable read of stale state",
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				putRequest("a", "2"),
+			},
+			operations: []porcupine.Operation{
+				{
+					Input:  serializableRangeRequest("a", "z"),
+					Output: rangeResponseWithRevision(1),
+				},
+				{
+					Input:  serializableRangeRequest("a", "z"),
+					Output: rangeResponseWithRevision(2, keyValue("a", "1", 2)),
+				},
+				{
+					Input:  serializableRangeRequest("a", "z"),
+					Output: rangeResponseWithRevision(3, keyValue("a", "2", 3)),
+				},
+			},
+		},
+		{
+			name: "Serializable read not matching state at its revision",
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				putRequest("a", "2"),
+			},
+			operations: []porcupine.Operation{
+				{
+					Input:  serializableRangeRequest("a", "z"),
+					Output: rangeResponseWithRevision(2, keyValue("a", "2", 3)),
+				},
+			},
+			expectError: errRespNotMatched.Error(),
+		},
+		{
+			name: "Serializable read of future revision",
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+			},
+			operations: []porcupine.Operation{
+				{
+					Input:  serializableRangeRequest("a", "z"),
+					Output: rangeResponseWithRevision(3, keyValue("a", "2", 3)),
+				},
+			},
+			expectError: errFutureRevRespRequested.Error(),
+		},
 		{
 			name: "Future rev failure",
 			persistedRequests: []model.EtcdRequest{
@@ -250,6 +328,12 @@ func TestValidateSerializableOperations(t *testing.T) {
 	}
 }
 
+func serializableRangeRequest(start, end string) model.EtcdRequest {
+	request := rangeRequest(start, end, 0, 0)
+	request.Range.Serializable = true
+	return request
+}
+
 func rangeRequest(start, end string, rev, limit int64) model.EtcdRequest {
 	return model.EtcdRequest{
 		Type: model.Range,
@@ -278,6 +362,12 @@ func rangeResponse(count int64, kvs ...model.KeyValue) model.MaybeEtcdResponse {
 	}
 }
 
+func limitedRangeResponse(count int64, kvs ...model.KeyValue) model.MaybeEtcdResponse {
+	resp := rangeResponse(count, kvs...)
+	resp.Range.More = true
+	return resp
+}
+
 func errorResponse(err error) model.MaybeEtcdResponse {
 	return model.MaybeEtcdResponse{
 		Error: err.Error(),
@@ -0,0 +1,76 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/anishathalye/porcupine"
+	"github.com/google/go-cmp/cmp"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+)
+
+// ValidatePaginatedRange checks that pages of a paginated range, read at the same revision,
+// cover exactly the keys returned by an unlimited range at that revision, without gaps or overlaps.
+// Every page except the last one is expected to report more keys.
+func ValidatePaginatedRange(pages []porcupine.Operation, full porcupine.Operation) error {
+	fullRevision, fullResponse, err := rangeResult(full)
+	if err != nil {
+		return err
+	}
+	var kvs []model.KeyValue
+	for i, page := range pages {
+		revision, response, err := rangeResult(page)
+		if err != nil {
+			return fmt.Errorf("page %d: %w", i, err)
+		}
+		if revision != fullRevision {
+			return fmt.Errorf("page %d read at revision %d, expected %d", i, revision, fullRevision)
+		}
+		if last := i == len(pages)-1; response.More == last {
+			return fmt.Errorf("page %d has unexpected more flag: %t", i, response.More)
+		}
+		for _, kv := range response.KVs {
+			if len(kvs) != 0 && kv.Key <= kvs[len(kvs)-1].Key {
+				return fmt.Errorf("page %d overlaps with previous pages on key %q", i, kv.Key)
+			}
+			kvs = append(kvs, kv)
+		}
+	}
+	if len(kvs) == 0 {
+		kvs = []model.KeyValue{}
+	}
+	if diff := cmp.Diff(fullResponse.KVs, kvs); diff != "" {
+		return fmt.Errorf("pages don't match unlimited range, diff: %s", diff)
+	}
+	return nil
+}
+
+func rangeResult(op porcupine.Operation) (revision int64, response *model.RangeResponse, err error) {
+	request := op.Input.(model.EtcdRequest)
+	resp := op.Output.(model.MaybeEtcdResponse)
+	if request.Type != model.Range {
+		return 0, nil, fmt.Errorf("expected range request, got %s", request.Type)
+	}
+	if resp.Error != "" || resp.Range == nil {
+		return 0, nil, fmt.Errorf("range failed: %s", resp.Error)
+	}
+	revision = request.Range.Revision
+	if revision == 0 {
+		revision = resp.Revision
+	}
+	return revision, resp.Range, nil
+}
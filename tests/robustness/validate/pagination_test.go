@@ -0,0 +1,76 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+)
+
+func TestValidatePaginatedRange(t *testing.T) {
+	full := porcupine.Operation{Input: rangeRequest("a", "z", 4, 0), Output: rangeResponse(3, keyValue("a", "1", 2), keyValue("b", "2", 3), keyValue("c", "3", 4))}
+	tcs := []struct {
+		name        string
+		pages       []porcupine.Operation
+		expectError bool
+	}{
+		{
+			name: "Pages cover the range",
+			pages: []porcupine.Operation{
+				{Input: rangeRequest("a", "z", 4, 2), Output: limitedRangeResponse(3, keyValue("a", "1", 2), keyValue("b", "2", 3))},
+				{Input: rangeRequest("b\x00", "z", 4, 2), Output: rangeResponse(1, keyValue("c", "3", 4))},
+			},
+		},
+		{
+			name: "Pages overlap",
+			pages: []porcupine.Operation{
+				{Input: rangeRequest("a", "z", 4, 2), Output: limitedRangeResponse(3, keyValue("a", "1", 2), keyValue("b", "2", 3))},
+				{Input: rangeRequest("b", "z", 4, 2), Output: rangeResponse(2, keyValue("b", "2", 3), keyValue("c", "3", 4))},
+			},
+			expectError: true,
+		},
+		{
+			name: "Pages have a gap",
+			pages: []porcupine.Operation{
+				{Input: rangeRequest("a", "z", 4, 1), Output: limitedRangeResponse(3, keyValue("a", "1", 2))},
+				{Input: rangeRequest("c", "z", 4, 1), Output: rangeResponse(1, keyValue("c", "3", 4))},
+			},
+			expectError: true,
+		},
+		{
+			name: "Page read at different revision",
+			pages: []porcupine.Operation{
+				{Input: rangeRequest("a", "z", 3, 0), Output: rangeResponse(2, keyValue("a", "1", 2), keyValue("b", "2", 3))},
+			},
+			expectError: true,
+		},
+		{
+			name: "Last page reports more",
+			pages: []porcupine.Operation{
+				{Input: rangeRequest("a", "z", 4, 3), Output: limitedRangeResponse(3, keyValue("a", "1", 2), keyValue("b", "2", 3), keyValue("c", "3", 4))},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePaginatedRange(tc.pages, full)
+			if (err != nil) != tc.expectError {
+				t.Errorf("ValidatePaginatedRange(...), got: %v, expectError: %t", err, tc.expectError)
+			}
+		})
+	}
+}
@@ -38,9 +38,14 @@ func relevantOperations(reports []report.ClientReport) []porcupine.Operation {
 			request := op.Input.(model.EtcdRequest)
 			resp := op.Output.(model.MaybeEtcdResponse)
 			// Remove failed read requests as they are not relevant for linearization.
-			if resp.Error == "" || !request.IsRead() {
-				ops = append(ops, op)
+			if resp.Error != "" && request.IsRead() {
+				continue
+			}
+			// Serializable reads are not linearizable, they are validated separately.
+			if request.Type == model.Range && request.Range.Serializable {
+				continue
 			}
+			ops = append(ops, op)
 		}
 	}
 	return ops
@@ -219,7 +224,11 @@ func operationReturnTime(operations []porcupine.Operation) map[model.EtcdOperati
 		case model.Range:
 		case model.LeaseGrant:
 		case model.LeaseRevoke:
+		case model.LeaseKeepAlive:
+		case model.Defragment:
 		case model.Compact:
+		case model.Snapshot:
+		case model.MoveLeader:
 		default:
 			panic(fmt.Sprintf("Unknown request type: %q", request.Type))
 		}
@@ -67,7 +67,7 @@ func TestPatchHistory(t *testing.T) {
 				putRequest("key", "value"),
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -81,7 +81,7 @@ func TestPatchHistory(t *testing.T) {
 				putRequest("key2", "value"),
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 3, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 3, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 				{Return: 4, Output: putResponse(model.EtcdOperationResult{})},
 			},
 		},
@@ -133,7 +133,7 @@ func TestPatchHistory(t *testing.T) {
 				putRequestWithLease("key", "value", 123),
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -147,7 +147,7 @@ func TestPatchHistory(t *testing.T) {
 				putRequestWithLease("key2", "value", 234),
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 3, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 3, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 				{Return: 4, Output: putResponse(model.EtcdOperationResult{})},
 			},
 		},
@@ -185,7 +185,7 @@ func TestPatchHistory(t *testing.T) {
 			},
 			watchOperations: watchDeleteEvent("key", 2, 3),
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000004, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000004, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 				{Return: 4, Output: putResponse(model.EtcdOperationResult{})},
 			},
 		},
@@ -212,7 +212,7 @@ func TestPatchHistory(t *testing.T) {
 				putRequest("key", "value"),
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -221,7 +221,7 @@ func TestPatchHistory(t *testing.T) {
 				h.AppendTxn(nil, []clientv3.Op{clientv3.OpDelete("key")}, []clientv3.Op{}, 1, 2, nil, errors.New("failed"))
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -239,7 +239,7 @@ func TestPatchHistory(t *testing.T) {
 				h.AppendTxn(nil, []clientv3.Op{clientv3.OpPut("key", "value")}, []clientv3.Op{clientv3.OpDelete("key")}, 1, 2, nil, errors.New("failed"))
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -248,7 +248,7 @@ func TestPatchHistory(t *testing.T) {
 				h.AppendTxn(nil, []clientv3.Op{clientv3.OpDelete("key")}, []clientv3.Op{clientv3.OpPut("key", "value")}, 1, 2, nil, errors.New("failed"))
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -267,7 +267,7 @@ func TestPatchHistory(t *testing.T) {
 				putRequest("key", "value"),
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000000, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -276,7 +276,7 @@ func TestPatchHistory(t *testing.T) {
 				h.AppendTxn(nil, []clientv3.Op{}, []clientv3.Op{clientv3.OpDelete("key")}, 1, 2, nil, errors.New("failed"))
 			},
 			expectedRemainingOperations: []porcupine.Operation{
-				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed"}},
+				{Return: 1000000001, Output: model.MaybeEtcdResponse{Error: "failed", Indeterminate: true, Failure: model.FailureServerError}},
 			},
 		},
 		{
@@ -314,6 +314,7 @@ func TestPatchHistory(t *testing.T) {
 			if diff := cmp.Diff(tc.expectedRemainingOperations, operations,
 				cmpopts.EquateEmpty(),
 				cmpopts.IgnoreFields(porcupine.Operation{}, "Input", "Call", "ClientId"),
+				cmpopts.IgnoreFields(model.MaybeEtcdResponse{}, "CallDuration", "Header"),
 			); diff != "" {
 				t.Errorf("Response didn't match expected, diff:\n%s", diff)
 			}
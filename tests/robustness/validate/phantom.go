@@ -0,0 +1,115 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokePhantomEvents = errors.New("broke PhantomEvents - every watch event must originate from a write request")
+
+// originWrite is a write request that could have caused a watch event.
+// Revision is zero if request failed, as it could have been persisted at any revision.
+type originWrite struct {
+	value    model.ValueOrHash
+	revision int64
+}
+
+// ValidatePhantomEvents checks that every watch event in report merged with report.MergeReports
+// originates from a write request of one of the merged clients. Put events need a put of the same
+// key and value, and delete events a delete of the key. Requests that succeeded must match event revision.
+// Deletion of keys attached to a lease is accepted as it can be caused by lease revoke or expiry.
+// Reports of all clients writing to the cluster must be merged, as their writes cannot be accounted for otherwise.
+func ValidatePhantomEvents(merged report.ClientReport) error {
+	puts, deletes, leased := originWrites(merged)
+	for _, op := range merged.Watch {
+		for _, resp := range op.Responses {
+			for _, event := range resp.Events {
+				switch event.Type {
+				case model.PutOperation:
+					if !hasOriginWrite(puts[event.Key], event.Value, event.Revision) {
+						return fmt.Errorf("%w, put event of key %q with value %+v at revision %d", errBrokePhantomEvents, event.Key, event.Value, event.Revision)
+					}
+				case model.DeleteOperation:
+					if _, ok := leased[event.Key]; ok {
+						continue
+					}
+					if !hasOriginWrite(deletes[event.Key], model.ValueOrHash{}, event.Revision) {
+						return fmt.Errorf("%w, delete event of key %q at revision %d", errBrokePhantomEvents, event.Key, event.Revision)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func originWrites(r report.ClientReport) (puts, deletes map[string][]originWrite, leased map[string]struct{}) {
+	puts, deletes, leased = map[string][]originWrite{}, map[string][]originWrite{}, map[string]struct{}{}
+	var rangeDeletes []rangeDelete
+	for _, op := range r.KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		if request.Type != model.Txn {
+			continue
+		}
+		var revision int64
+		var operations []model.EtcdOperation
+		if response.Error != "" || response.Txn == nil {
+			// Either branch could have been executed.
+			operations = request.Txn.AllOperations()
+		} else {
+			revision = response.Revision
+			operations = request.Txn.ExecutedOperations(response.Txn)
+		}
+		for _, etcdOp := range operations {
+			switch etcdOp.Type {
+			case model.PutOperation:
+				puts[etcdOp.Put.Key] = append(puts[etcdOp.Put.Key], originWrite{value: etcdOp.Put.Value, revision: revision})
+				if etcdOp.Put.LeaseID != 0 {
+					leased[etcdOp.Put.Key] = struct{}{}
+				}
+			case model.DeleteOperation:
+				if etcdOp.Delete.End != "" {
+					rangeDeletes = append(rangeDeletes, rangeDelete{options: etcdOp.Delete, revision: revision})
+					continue
+				}
+				deletes[etcdOp.Delete.Key] = append(deletes[etcdOp.Delete.Key], originWrite{revision: revision})
+			}
+		}
+	}
+	// Keys need to be put before they are deleted, so range delete can only delete keys with put.
+	for _, d := range rangeDeletes {
+		for key := range puts {
+			if d.options.Contains(key) {
+				deletes[key] = append(deletes[key], originWrite{revision: d.revision})
+			}
+		}
+	}
+	return puts, deletes, leased
+}
+
+func hasOriginWrite(writes []originWrite, value model.ValueOrHash, revision int64) bool {
+	for _, write := range writes {
+		if write.value == value && (write.revision == 0 || write.revision == revision) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidatePhantomEvents(t *testing.T) {
+	tcs := []struct {
+		name        string
+		reports     []report.ClientReport
+		expectError error
+	}{
+		{
+			name: "Events originate from writes of other clients",
+			reports: []report.ClientReport{
+				{
+					ClientID: 1,
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+				},
+				{
+					ClientID: 2,
+					KeyValue: []porcupine.Operation{
+						{Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1}), Call: 3, Return: 4},
+					},
+				},
+				{
+					ClientID: 3,
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), deleteWatchEvent("a", 3)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Event from failed write at any revision",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: errorResponse(errors.New("timeout")), Call: 1, Return: 2},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 5, true)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Event originates from put in nested txn",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: nestedPutRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{Txn: &model.TxnResponse{Results: []model.EtcdOperationResult{{}}}}), Call: 1, Return: 2},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Event from put in nested txn of failed write",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: nestedPutRequest("a", "1"), Output: errorResponse(errors.New("timeout")), Call: 1, Return: 2},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 5, true)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Deletion of leased key",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequestWithLease("a", "1", 1), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), deleteWatchEvent("a", 3)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Deletion by range delete",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+						{Input: putRequest("b", "1"), Output: txnResponse(3, model.EtcdOperationResult{}), Call: 3, Return: 4},
+						{Input: deleteRangeRequest("a", "c"), Output: txnResponse(4, model.EtcdOperationResult{Deleted: 2}), Call: 5, Return: 6},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), putWatchEvent("b", "1", 3, true), deleteWatchEvent("a", 4), deleteWatchEvent("b", 4)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Delete event of key outside of deleted range",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+						{Input: putRequest("c", "1"), Output: txnResponse(3, model.EtcdOperationResult{}), Call: 3, Return: 4},
+						{Input: deleteRangeRequest("a", "c"), Output: txnResponse(4, model.EtcdOperationResult{Deleted: 1}), Call: 5, Return: 6},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), putWatchEvent("c", "1", 3, true), deleteWatchEvent("c", 4)}},
+							},
+						},
+					},
+				},
+			},
+			expectError: errBrokePhantomEvents,
+		},
+		{
+			name: "Put event with value never written",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+				},
+				{
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), putWatchEvent("a", "phantom", 3, false)}},
+							},
+						},
+					},
+				},
+			},
+			expectError: errBrokePhantomEvents,
+		},
+		{
+			name: "Put event at revision different than acknowledged",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 3, true)}},
+							},
+						},
+					},
+				},
+			},
+			expectError: errBrokePhantomEvents,
+		},
+		{
+			name: "Delete event of key never deleted",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+					Watch: []model.WatchOperation{
+						{
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), deleteWatchEvent("a", 3)}},
+							},
+						},
+					},
+				},
+			},
+			expectError: errBrokePhantomEvents,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePhantomEvents(report.MergeReports(tc.reports))
+			if !errors.Is(err, tc.expectError) {
+				t.Errorf("ValidatePhantomEvents(...), got: %v, want: %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func nestedPutRequest(key, value string) model.EtcdRequest {
+	return model.EtcdRequest{
+		Type: model.Txn,
+		Txn: &model.TxnRequest{
+			OperationsOnSuccess: []model.EtcdOperation{
+				{Type: model.TxnOperation, Txn: putRequest(key, value).Txn},
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokeReadAfterWriteCrossClient = errors.New("broke ReadAfterWriteCrossClient - linearizable read invoked after a write of another client returned must reflect it")
+
+// completedWrite is a put that succeeded, with time its response was returned to the client.
+type completedWrite struct {
+	clientID int
+	key      string
+	revision int64
+	returned int64
+}
+
+// ValidateReadAfterWriteCrossClient checks that linearizable ranges in report merged with
+// report.MergeReports reflect puts of other clients that returned before the range was invoked.
+// Range must be served at the put revision or later, and must not return the key with a lower mod revision.
+// Missing key is accepted, as it could have been deleted after the put.
+func ValidateReadAfterWriteCrossClient(merged report.ClientReport) error {
+	writes := completedWrites(merged)
+	for _, op := range merged.KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		if request.Type != model.Range || !request.Range.IsLinearizable() {
+			continue
+		}
+		if response.Error != "" || response.PartialResponse || response.ClientError != "" || response.Range == nil {
+			continue
+		}
+		for _, write := range writes {
+			if write.clientID == op.ClientId || write.returned >= op.Call || !keyInRange(write.key, request.Range.RangeOptions) {
+				continue
+			}
+			if response.Revision < write.revision {
+				return fmt.Errorf("%w, range of client %d served at revision %d, before put of key %q by client %d at revision %d", errBrokeReadAfterWriteCrossClient, op.ClientId, response.Revision, write.key, write.clientID, write.revision)
+			}
+			for _, kv := range response.Range.KVs {
+				if kv.Key == write.key && kv.ModRevision < write.revision {
+					return fmt.Errorf("%w, range of client %d returned key %q with mod revision %d, before put by client %d at revision %d", errBrokeReadAfterWriteCrossClient, op.ClientId, kv.Key, kv.ModRevision, write.clientID, write.revision)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func completedWrites(r report.ClientReport) (writes []completedWrite) {
+	for _, op := range r.KeyValue {
+		request := op.Input.(model.EtcdRequest)
+		response := op.Output.(model.MaybeEtcdResponse)
+		if request.Type != model.Txn || response.Error != "" || response.PartialResponse || response.Txn == nil {
+			continue
+		}
+		for _, etcdOp := range request.Txn.ExecutedOperations(response.Txn) {
+			if etcdOp.Type == model.PutOperation {
+				writes = append(writes, completedWrite{clientID: op.ClientId, key: etcdOp.Put.Key, revision: response.Revision, returned: op.Return})
+			}
+		}
+	}
+	return writes
+}
+
+func keyInRange(key string, options model.RangeOptions) bool {
+	if options.End == "" {
+		return key == options.Start
+	}
+	return key >= options.Start && (options.End == model.FromKey || key < options.End)
+}
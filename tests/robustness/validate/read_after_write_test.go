@@ -0,0 +1,150 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateReadAfterWriteCrossClient(t *testing.T) {
+	tcs := []struct {
+		name        string
+		reports     []report.ClientReport
+		expectError error
+	}{
+		{
+			name: "Read after write of other client reflects it",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 1, Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+				},
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 2, Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2)), Call: 3, Return: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "Read concurrent with write of other client may not reflect it",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 1, Input: putRequest("a", "2"), Output: txnResponse(3, model.EtcdOperationResult{}), Call: 1, Return: 4},
+					},
+				},
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 2, Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2)), Call: 2, Return: 3},
+					},
+				},
+			},
+		},
+		{
+			name: "Serializable read after write of other client may be stale",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 1, Input: putRequest("a", "2"), Output: txnResponse(3, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+				},
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 2, Input: serializableRangeRequest("a", ""), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2)), Call: 3, Return: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "Read at revision before write of other client is not linearizable",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 1, Input: putRequest("a", "2"), Output: txnResponse(3, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+				},
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 2, Input: rangeRequest("a", "", 2, 0), Output: rangeResponseWithRevision(3, keyValue("a", "1", 2)), Call: 3, Return: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "Read after write of other client doesn't return deleted key",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 1, Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 1, Return: 2},
+						{ClientId: 1, Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1}), Call: 3, Return: 4},
+					},
+				},
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 2, Input: rangeRequest("a", "b", 0, 0), Output: rangeResponseWithRevision(3), Call: 5, Return: 6},
+					},
+				},
+			},
+		},
+		{
+			name: "Read after write of other client returns stale value",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 1, Input: putRequest("a", "2"), Output: txnResponse(3, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+				},
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 2, Input: rangeRequest("a", "b", 0, 0), Output: rangeResponseWithRevision(3, keyValue("a", "1", 2)), Call: 3, Return: 4},
+					},
+				},
+			},
+			expectError: errBrokeReadAfterWriteCrossClient,
+		},
+		{
+			name: "Read after write of other client served at older revision",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 1, Input: putRequest("a", "2"), Output: txnResponse(3, model.EtcdOperationResult{}), Call: 1, Return: 2},
+					},
+				},
+				{
+					KeyValue: []porcupine.Operation{
+						{ClientId: 2, Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2)), Call: 3, Return: 4},
+					},
+				},
+			},
+			expectError: errBrokeReadAfterWriteCrossClient,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateReadAfterWriteCrossClient(report.MergeReports(tc.reports))
+			if !errors.Is(err, tc.expectError) {
+				t.Errorf("ValidateReadAfterWriteCrossClient(...), got: %v, want: %v", err, tc.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokeNoResurrection = errors.New("broke NoResurrection - a key deleted at revision R must not be observed above R unless it was re-created")
+
+// ValidateNoResurrection checks that keys observed by reads and watch events were not deleted
+// between their mod revision and the revision they were observed at, by a single key or range delete.
+// Re-created keys are accepted, as they have mod revision higher than the delete.
+func ValidateNoResurrection(lg *zap.Logger, reports []report.ClientReport) (err error) {
+	lg.Info("Validating no resurrection")
+	deletes := deleteRevisions(reports)
+	for _, r := range reports {
+		for _, op := range r.KeyValue {
+			request := op.Input.(model.EtcdRequest)
+			response := op.Output.(model.MaybeEtcdResponse)
+			readRevision, kvs := observedKeyValues(request, response)
+			for _, kv := range kvs {
+				if deleteRevision := deletes.between(kv.Key, kv.ModRevision, readRevision); deleteRevision != 0 {
+					lg.Error("Broke no resurrection", zap.Int("client", r.ClientID), zap.String("key", kv.Key), zap.Int64("mod-revision", kv.ModRevision), zap.Int64("delete-revision", deleteRevision), zap.Int64("read-revision", readRevision))
+					err = errBrokeNoResurrection
+				}
+			}
+		}
+		for _, watch := range r.Watch {
+			for _, resp := range watch.Responses {
+				for _, event := range resp.Events {
+					if event.PrevValue == nil {
+						continue
+					}
+					// Previous value was observed just before the event revision.
+					if deleteRevision := deletes.between(event.Key, event.PrevValue.ModRevision, event.Revision-1); deleteRevision != 0 {
+						lg.Error("Broke no resurrection", zap.Int("client", r.ClientID), zap.String("key", event.Key), zap.Int64("mod-revision", event.PrevValue.ModRevision), zap.Int64("delete-revision", deleteRevision), zap.Int64("event-revision", event.Revision))
+						err = errBrokeNoResurrection
+					}
+				}
+			}
+		}
+	}
+	return err
+}
+
+// keyDeletes are deletes done by successful client requests.
+type keyDeletes struct {
+	// keys are revisions at which keys were deleted by single key deletes.
+	keys   map[string][]int64
+	ranges []rangeDelete
+}
+
+// deleteRevisions returns deletes done by successful client requests.
+func deleteRevisions(reports []report.ClientReport) keyDeletes {
+	deletes := keyDeletes{keys: map[string][]int64{}}
+	for _, r := range reports {
+		for _, op := range r.KeyValue {
+			request := op.Input.(model.EtcdRequest)
+			response := op.Output.(model.MaybeEtcdResponse)
+			if request.Type != model.Txn || response.Error != "" || response.PartialResponse || response.Txn == nil {
+				continue
+			}
+			results := request.Txn.ExecutedResults(response.Txn)
+			for i, etcdOp := range request.Txn.ExecutedOperations(response.Txn) {
+				if etcdOp.Type != model.DeleteOperation || i >= len(results) || results[i].Deleted == 0 {
+					continue
+				}
+				if etcdOp.Delete.End != "" {
+					deletes.ranges = append(deletes.ranges, rangeDelete{options: etcdOp.Delete, revision: response.Revision})
+					continue
+				}
+				deletes.keys[etcdOp.Delete.Key] = append(deletes.keys[etcdOp.Delete.Key], response.Revision)
+			}
+		}
+	}
+	return deletes
+}
+
+// observedKeyValues returns key values returned by a read together with revision they were read at.
+func observedKeyValues(request model.EtcdRequest, response model.MaybeEtcdResponse) (revision int64, kvs []model.KeyValue) {
+	if response.Error != "" || response.PartialResponse || response.ClientError != "" {
+		return 0, nil
+	}
+	switch request.Type {
+	case model.Range:
+		if response.Range == nil {
+			return 0, nil
+		}
+		revision = request.Range.Revision
+		if revision == 0 {
+			revision = response.Revision
+		}
+		return revision, response.Range.KVs
+	case model.Txn:
+		if response.Txn == nil {
+			return 0, nil
+		}
+		ops := request.Txn.ExecutedOperations(response.Txn)
+		// Reads in the same transaction as writes observe intermediate state.
+		if hasWriteOperation(ops) {
+			return 0, nil
+		}
+		results := request.Txn.ExecutedResults(response.Txn)
+		for i, etcdOp := range ops {
+			if etcdOp.Type == model.RangeOperation && i < len(results) {
+				kvs = append(kvs, results[i].KVs...)
+			}
+		}
+		return response.Revision, kvs
+	default:
+		return 0, nil
+	}
+}
+
+// between returns revision of delete of key that happened after modRevision and not later than readRevision.
+func (d keyDeletes) between(key string, modRevision, readRevision int64) int64 {
+	for _, revision := range d.keys[key] {
+		if revision > modRevision && revision <= readRevision {
+			return revision
+		}
+	}
+	for _, r := range d.ranges {
+		if r.revision > modRevision && r.revision <= readRevision && r.options.Contains(key) {
+			return r.revision
+		}
+	}
+	return 0
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateNoResurrection(t *testing.T) {
+	tcs := []struct {
+		name        string
+		reports     []report.ClientReport
+		expectError error
+	}{
+		{
+			name: "Read after delete doesn't return deleted key",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+						{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(3)},
+					},
+				},
+			},
+		},
+		{
+			name: "Read before delete returns key",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+						{Input: rangeRequest("a", "", 2, 0), Output: rangeResponseWithRevision(3, keyValue("a", "1", 2))},
+					},
+				},
+			},
+		},
+		{
+			name: "Read after delete returns deleted key",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+						{Input: rangeRequest("a", "z", 0, 0), Output: rangeResponseWithRevision(3, keyValue("a", "1", 2))},
+					},
+				},
+			},
+			expectError: errBrokeNoResurrection,
+		},
+		{
+			name: "Read after range delete returns deleted key",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRangeRequest("a", "b"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+						{Input: rangeRequest("a", "z", 0, 0), Output: rangeResponseWithRevision(3, keyValue("a", "1", 2))},
+					},
+				},
+			},
+			expectError: errBrokeNoResurrection,
+		},
+		{
+			name: "Read after range delete returns key outside of range",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("b", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRangeRequest("a", "b"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+						{Input: rangeRequest("a", "z", 0, 0), Output: rangeResponseWithRevision(3, keyValue("b", "1", 2))},
+					},
+				},
+			},
+		},
+		{
+			name: "Read after re-create returns new key",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+						{Input: putRequest("a", "2"), Output: txnResponse(4, model.EtcdOperationResult{})},
+						{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(4, keyValue("a", "2", 4))},
+					},
+				},
+			},
+		},
+		{
+			name: "Delete of not existing key doesn't count",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: deleteRequest("a"), Output: txnResponse(1, model.EtcdOperationResult{Deleted: 0})},
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2))},
+					},
+				},
+			},
+		},
+		{
+			name: "Watch event after delete has deleted prevValue",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+					},
+				},
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{Key: "a", WithPrevKV: true},
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEventWithPrevKV("a", "2", 4, false, "1", 2)}},
+							},
+						},
+					},
+				},
+			},
+			expectError: errBrokeNoResurrection,
+		},
+		{
+			name: "Watch event after re-create has new prevValue",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: deleteRequest("a"), Output: txnResponse(3, model.EtcdOperationResult{Deleted: 1})},
+						{Input: putRequest("a", "2"), Output: txnResponse(4, model.EtcdOperationResult{})},
+					},
+				},
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{Key: "a", WithPrevKV: true},
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEventWithPrevKV("a", "3", 5, false, "2", 4)}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateNoResurrection(zaptest.NewLogger(t), tc.reports)
+			if err != tc.expectError {
+				t.Errorf("ValidateNoResurrection(...), got: %v, want: %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func txnResponse(revision int64, result ...model.EtcdOperationResult) model.MaybeEtcdResponse {
+	resp := putResponse(result...)
+	resp.Revision = revision
+	return resp
+}
+
+func rangeResponseWithRevision(revision int64, kvs ...model.KeyValue) model.MaybeEtcdResponse {
+	resp := rangeResponse(int64(len(kvs)), kvs...)
+	resp.Revision = revision
+	return resp
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokeRetryIdempotency = errors.New("broke retry idempotency - a put was applied at more than one revision")
+
+// ValidateRetryIdempotency checks that each put, possibly retried by client after
+// a transient error, was applied at most once. Puts are unique, so the same key
+// and value observed at different revisions means the put was applied twice.
+// Revisions are taken from successful responses, reads and watch events.
+// Deletes are not unique and cannot be checked this way.
+func ValidateRetryIdempotency(lg *zap.Logger, reports []report.ClientReport) (err error) {
+	type put struct {
+		key   string
+		value model.ValueOrHash
+	}
+	revisions := map[put]map[int64]struct{}{}
+	add := func(key string, value model.ValueOrHash, revision int64) {
+		p := put{key: key, value: value}
+		if _, ok := revisions[p]; !ok {
+			revisions[p] = map[int64]struct{}{}
+		}
+		revisions[p][revision] = struct{}{}
+	}
+	for _, r := range reports {
+		for _, op := range r.KeyValue {
+			request := op.Input.(model.EtcdRequest)
+			response := op.Output.(model.MaybeEtcdResponse)
+			if request.Type == model.Txn && response.Error == "" && !response.PartialResponse && response.Txn != nil {
+				for _, etcdOp := range request.Txn.ExecutedOperations(response.Txn) {
+					if etcdOp.Type == model.PutOperation {
+						add(etcdOp.Put.Key, etcdOp.Put.Value, response.Revision)
+					}
+				}
+			}
+			_, kvs := observedKeyValues(request, response)
+			for _, kv := range kvs {
+				add(kv.Key, kv.Value, kv.ModRevision)
+			}
+		}
+		for _, watch := range r.Watch {
+			for _, resp := range watch.Responses {
+				for _, event := range resp.Events {
+					if event.Type == model.PutOperation {
+						add(event.Key, event.Value, event.Revision)
+					}
+				}
+			}
+		}
+	}
+	for p, revs := range revisions {
+		if len(revs) > 1 {
+			applied := make([]int64, 0, len(revs))
+			for rev := range revs {
+				applied = append(applied, rev)
+			}
+			lg.Error("Put applied more than once", zap.String("key", p.key), zap.Any("value", p.value), zap.Int64s("revisions", applied))
+			err = errBrokeRetryIdempotency
+		}
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateRetryIdempotency(t *testing.T) {
+	timeout := model.MaybeEtcdResponse{Error: "timeout", Indeterminate: true}
+	tcs := []struct {
+		name        string
+		reports     []report.ClientReport
+		expectError error
+	}{
+		{
+			name: "Retried put applied once",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: timeout},
+						{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+						{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2))},
+					},
+					Watch: []model.WatchOperation{
+						{Responses: []model.WatchResponse{{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}}}},
+					},
+				},
+			},
+		},
+		{
+			name: "Retried put applied twice",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: timeout},
+						{Input: putRequest("a", "1"), Output: txnResponse(3, model.EtcdOperationResult{})},
+					},
+					Watch: []model.WatchOperation{
+						{Responses: []model.WatchResponse{{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), putWatchEvent("a", "1", 3, false)}}}},
+					},
+				},
+			},
+			expectError: errBrokeRetryIdempotency,
+		},
+		{
+			name: "Retried put applied twice observed by read",
+			reports: []report.ClientReport{
+				{
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: timeout},
+						{Input: rangeRequest("a", "", 0, 0), Output: rangeResponseWithRevision(2, keyValue("a", "1", 2))},
+						{Input: putRequest("a", "1"), Output: txnResponse(3, model.EtcdOperationResult{})},
+					},
+				},
+			},
+			expectError: errBrokeRetryIdempotency,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRetryIdempotency(zaptest.NewLogger(t), tc.reports)
+			if err != tc.expectError {
+				t.Errorf("ValidateRetryIdempotency(...), got: %q, want: %q", err, tc.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var (
+	errStuckApply      = errors.New("apply stuck - commit index kept growing while applied index didn't change")
+	errApplyLagBounded = errors.New("broke ApplyLagBounded - number of committed but not applied entries exceeded the bound")
+)
+
+// ValidateApplyProgress checks status observations of each endpoint for a stuck apply,
+// reported when gap between committed and applied index grew in maxStalled consecutive
+// observations without applied index changing.
+func ValidateApplyProgress(lg *zap.Logger, reports []report.ClientReport, maxStalled int) (err error) {
+	observations := map[string][]model.StatusObservation{}
+	for _, r := range reports {
+		for _, status := range r.Status {
+			observations[status.Endpoint] = append(observations[status.Endpoint], status)
+		}
+	}
+	for endpoint, statuses := range observations {
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Time < statuses[j].Time
+		})
+		stalled := 0
+		for i := 1; i < len(statuses); i++ {
+			prev, cur := statuses[i-1], statuses[i]
+			if cur.AppliedIndex == prev.AppliedIndex && cur.CommitIndex-cur.AppliedIndex > prev.CommitIndex-prev.AppliedIndex {
+				stalled++
+			} else {
+				stalled = 0
+			}
+			if stalled == maxStalled {
+				lg.Error("Apply stuck", zap.String("endpoint", endpoint), zap.Uint64("applied-index", cur.AppliedIndex), zap.Uint64("commit-index", cur.CommitIndex), zap.Duration("time", cur.Time))
+				err = errStuckApply
+			}
+		}
+	}
+	return err
+}
+
+// ValidateApplyLagBounded checks that in no status observation of the client the number of
+// committed entries not yet applied exceeded maxLag.
+func ValidateApplyLagBounded(r report.ClientReport, maxLag uint64) error {
+	for _, status := range r.Status {
+		if lag := status.ApplyLag(); lag > maxLag {
+			return fmt.Errorf("%w, client: %d, endpoint: %s, time: %s, commit index: %d, applied index: %d, lag: %d, max lag: %d", errApplyLagBounded, r.ClientID, status.Endpoint, status.Time, status.CommitIndex, status.AppliedIndex, lag, maxLag)
+		}
+	}
+	return nil
+}
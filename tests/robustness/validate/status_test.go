@@ -0,0 +1,104 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateApplyProgress(t *testing.T) {
+	tcs := []struct {
+		name        string
+		indexes     [][2]uint64
+		expectError error
+	}{
+		{
+			name:    "Applied index follows commit index",
+			indexes: [][2]uint64{{10, 10}, {12, 11}, {14, 14}, {20, 18}},
+		},
+		{
+			name:    "Gap growing briefly",
+			indexes: [][2]uint64{{10, 10}, {12, 10}, {14, 10}, {16, 16}},
+		},
+		{
+			name:        "Gap growing persistently",
+			indexes:     [][2]uint64{{10, 10}, {12, 10}, {14, 10}, {16, 10}},
+			expectError: errStuckApply,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var statuses []model.StatusObservation
+			for i, index := range tc.indexes {
+				statuses = append(statuses, model.StatusObservation{
+					Endpoint:     "a",
+					Time:         time.Duration(i),
+					CommitIndex:  index[0],
+					AppliedIndex: index[1],
+				})
+			}
+			err := ValidateApplyProgress(zaptest.NewLogger(t), []report.ClientReport{{Status: statuses}}, 3)
+			if err != tc.expectError {
+				t.Errorf("ValidateApplyProgress(...), got: %q, want: %q", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func TestValidateApplyLagBounded(t *testing.T) {
+	tcs := []struct {
+		name        string
+		indexes     [][2]uint64
+		expectError error
+	}{
+		{
+			name:    "Lag within bound",
+			indexes: [][2]uint64{{10, 10}, {15, 11}, {20, 20}},
+		},
+		{
+			name:    "Applied index reported ahead of commit index",
+			indexes: [][2]uint64{{10, 11}},
+		},
+		{
+			name:        "Lag exceeding bound",
+			indexes:     [][2]uint64{{10, 10}, {20, 14}, {30, 30}},
+			expectError: errApplyLagBounded,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var statuses []model.StatusObservation
+			for i, index := range tc.indexes {
+				statuses = append(statuses, model.StatusObservation{
+					Endpoint:     "a",
+					Time:         time.Duration(i),
+					CommitIndex:  index[0],
+					AppliedIndex: index[1],
+				})
+			}
+			err := ValidateApplyLagBounded(report.ClientReport{Status: statuses}, 5)
+			if !errors.Is(err, tc.expectError) {
+				t.Errorf("ValidateApplyLagBounded(...), got: %v, want: %v", err, tc.expectError)
+			}
+		})
+	}
+}
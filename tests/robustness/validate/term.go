@@ -0,0 +1,44 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var errBrokeRaftTermMonotonic = errors.New("broke RaftTermMonotonic - raft term in response headers must not decrease")
+
+// ValidateRaftTermMonotonic checks that raft term in response headers observed by the client
+// never decreases. Terms are compared between responses of the same member, as a member
+// partitioned from the cluster can still serve serializable requests at an older term.
+// Operations without response header, like failed requests, are skipped.
+func ValidateRaftTermMonotonic(r report.ClientReport) error {
+	lastHeader := map[uint64]model.ResponseHeader{}
+	for i, op := range r.KeyValue {
+		header := op.Output.(model.MaybeEtcdResponse).Header
+		if header == (model.ResponseHeader{}) {
+			continue
+		}
+		if last, ok := lastHeader[header.MemberID]; ok && header.RaftTerm < last.RaftTerm {
+			return fmt.Errorf("%w, client: %d, member: %x, operation: %d, term: %d, previous term: %d", errBrokeRaftTermMonotonic, r.ClientID, header.MemberID, i, header.RaftTerm, last.RaftTerm)
+		}
+		lastHeader[header.MemberID] = header
+	}
+	return nil
+}
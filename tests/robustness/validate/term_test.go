@@ -0,0 +1,87 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateRaftTermMonotonic(t *testing.T) {
+	tcs := []struct {
+		name        string
+		headers     []model.ResponseHeader
+		expectError error
+	}{
+		{
+			name: "Term growing",
+			headers: []model.ResponseHeader{
+				{Revision: 1, RaftTerm: 2, MemberID: 1},
+				{Revision: 2, RaftTerm: 2, MemberID: 1},
+				{Revision: 3, RaftTerm: 3, MemberID: 1},
+			},
+		},
+		{
+			name: "Failed requests are skipped",
+			headers: []model.ResponseHeader{
+				{Revision: 1, RaftTerm: 2, MemberID: 1},
+				{},
+				{Revision: 2, RaftTerm: 2, MemberID: 1},
+			},
+		},
+		{
+			name: "Lower term from a different member",
+			headers: []model.ResponseHeader{
+				{Revision: 2, RaftTerm: 3, MemberID: 1},
+				{Revision: 1, RaftTerm: 2, MemberID: 2},
+			},
+		},
+		{
+			name: "Term decreasing",
+			headers: []model.ResponseHeader{
+				{Revision: 1, RaftTerm: 3, MemberID: 1},
+				{Revision: 2, RaftTerm: 2, MemberID: 1},
+			},
+			expectError: errBrokeRaftTermMonotonic,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRaftTermMonotonic(headersReport(tc.headers...))
+			if !errors.Is(err, tc.expectError) {
+				t.Errorf("ValidateRaftTermMonotonic(...), got: %v, want: %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
+// headersReport returns report with a range operation for each header.
+func headersReport(headers ...model.ResponseHeader) report.ClientReport {
+	r := report.ClientReport{}
+	for i, header := range headers {
+		r.KeyValue = append(r.KeyValue, porcupine.Operation{
+			Input:  model.EtcdRequest{Type: model.Range, Range: &model.RangeRequest{RangeOptions: model.RangeOptions{Start: "key"}}},
+			Output: model.MaybeEtcdResponse{Header: header},
+			Call:   int64(2 * i),
+			Return: int64(2*i + 1),
+		})
+	}
+	return r
+}
@@ -52,9 +52,46 @@ func ValidateAndReturnVisualize(t *testing.T, lg *zap.Logger, cfg Config, report
 	if err != nil {
 		t.Errorf("Failed validating serializable operations, err: %s", err)
 	}
+	err = ValidateNoResurrection(lg, reports)
+	if err != nil {
+		t.Errorf("Failed validating no resurrection, err: %s", err)
+	}
 	return visualize
 }
 
+// ValidateWithoutHistory runs validations that only need operations of the given reports and not
+// the history since the cluster started, so they can be run on part of the history, like a round
+// of a soak test. Watch guarantees checked against a replay of the history are skipped.
+func ValidateWithoutHistory(lg *zap.Logger, cfg Config, reports []report.ClientReport) error {
+	for _, r := range reports {
+		if err := validateFilter(lg, r); err != nil {
+			return err
+		}
+		if err := validateOrdered(lg, r); err != nil {
+			return err
+		}
+		if err := validateUnique(lg, cfg.ExpectRevisionUnique, r); err != nil {
+			return err
+		}
+		if err := validateAtomic(lg, r); err != nil {
+			return err
+		}
+		if err := validateBookmarkable(lg, r); err != nil {
+			return err
+		}
+		if err := ValidateCompactionMonotonic(r); err != nil {
+			return err
+		}
+		if err := ValidateRaftTermMonotonic(r); err != nil {
+			return err
+		}
+	}
+	if err := ValidateRetryIdempotency(lg, reports); err != nil {
+		return err
+	}
+	return ValidateReadAfterWriteCrossClient(report.MergeReports(reports))
+}
+
 type Config struct {
 	ExpectRevisionUnique bool
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/anishathalye/porcupine"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zaptest"
 
@@ -1620,6 +1621,72 @@ func TestValidateWatch(t *testing.T) {
 			},
 			expectError: errBrokeIsCreate.Error(),
 		},
+		{
+			name: "IsModify - recreated key marked as modified - fail",
+			reports: []report.ClientReport{
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{
+								Key: "a",
+							},
+							Responses: []model.WatchResponse{
+								{
+									Events: []model.WatchEvent{
+										putWatchEvent("a", "1", 2, true),
+										putWatchEvent("a", "2", 3, false),
+										deleteWatchEvent("a", 4),
+										{PersistedEvent: model.PersistedEvent{
+											Event:    model.Event{Type: model.PutOperation, Key: "a", Value: model.ToValueOrHash("4")},
+											Revision: 5,
+											IsCreate: true,
+											IsModify: true,
+										}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				putRequest("a", "2"),
+				deleteRequest("a"),
+				putRequest("a", "4"),
+			},
+			expectError: errBrokeIsModify.Error(),
+		},
+		{
+			name: "IsModify - update not marked as modified - fail",
+			reports: []report.ClientReport{
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{
+								Key: "a",
+							},
+							Responses: []model.WatchResponse{
+								{
+									Events: []model.WatchEvent{
+										putWatchEvent("a", "1", 2, true),
+										{PersistedEvent: model.PersistedEvent{
+											Event:    model.Event{Type: model.PutOperation, Key: "a", Value: model.ToValueOrHash("2")},
+											Revision: 3,
+										}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				putRequest("a", "2"),
+			},
+			expectError: errBrokeIsModify.Error(),
+		},
 		{
 			name: "PrevKV - no previous values - pass",
 			reports: []report.ClientReport{
@@ -1867,6 +1934,63 @@ func TestValidateWatch(t *testing.T) {
 			},
 			expectError: errBrokeFilter.Error(),
 		},
+		{
+			name: "Filter put - delete events with prevKV - pass",
+			reports: []report.ClientReport{
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{
+								Key:        "a",
+								WithPrevKV: true,
+								FilterPut:  true,
+							},
+							Responses: []model.WatchResponse{
+								{
+									Events: []model.WatchEvent{
+										deleteWatchEventWithPrevKV("a", 4, "2", 3),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				putRequest("a", "2"),
+				deleteRequest("a"),
+				putRequest("a", "4"),
+			},
+		},
+		{
+			name: "Filter delete - delete event delivered - fail",
+			reports: []report.ClientReport{
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{
+								Key:          "a",
+								FilterDelete: true,
+							},
+							Responses: []model.WatchResponse{
+								{
+									Events: []model.WatchEvent{
+										putWatchEvent("a", "1", 2, true),
+										deleteWatchEvent("a", 3),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			persistedRequests: []model.EtcdRequest{
+				putRequest("a", "1"),
+				deleteRequest("a"),
+			},
+			expectError: errBrokeFilter.Error(),
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1883,6 +2007,361 @@ func TestValidateWatch(t *testing.T) {
 	}
 }
 
+func TestValidateWithoutHistory(t *testing.T) {
+	tcs := []struct {
+		name        string
+		reports     []report.ClientReport
+		expectError error
+	}{
+		{
+			name: "Ordered events - pass",
+			reports: []report.ClientReport{
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{WithPrefix: true},
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+								{Events: []model.WatchEvent{putWatchEvent("b", "2", 3, true)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Unordered events - fail",
+			reports: []report.ClientReport{
+				{
+					Watch: []model.WatchOperation{
+						{
+							Request: model.WatchRequest{WithPrefix: true},
+							Responses: []model.WatchResponse{
+								{Events: []model.WatchEvent{putWatchEvent("b", "2", 3, true)}},
+								{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+							},
+						},
+					},
+				},
+			},
+			expectError: errBrokeOrdered,
+		},
+		{
+			name: "Term decreasing - fail",
+			reports: []report.ClientReport{
+				headersReport(
+					model.ResponseHeader{Revision: 1, RaftTerm: 3, MemberID: 1},
+					model.ResponseHeader{Revision: 2, RaftTerm: 2, MemberID: 1},
+				),
+			},
+			expectError: errBrokeRaftTermMonotonic,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWithoutHistory(zaptest.NewLogger(t), Config{}, tc.reports)
+			assert.ErrorIs(t, err, tc.expectError)
+		})
+	}
+}
+
+func TestValidateWatchPrevValues(t *testing.T) {
+	writes := []porcupine.Operation{
+		{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{})},
+		{Input: putRequest("a", "2"), Output: txnResponse(3, model.EtcdOperationResult{})},
+		{Input: deleteRequest("a"), Output: txnResponse(4, model.EtcdOperationResult{Deleted: 1})},
+	}
+	tcs := []struct {
+		name        string
+		events      []model.WatchEvent
+		expectError error
+	}{
+		{
+			name: "Previous values match write history",
+			events: []model.WatchEvent{
+				putWatchEvent("a", "1", 2, true),
+				putWatchEventWithPrevKV("a", "2", 3, false, "1", 2),
+				deleteWatchEventWithPrevKV("a", 4, "2", 3),
+				putWatchEvent("a", "3", 5, true),
+			},
+		},
+		{
+			name: "Previous value of overwrite has wrong value",
+			events: []model.WatchEvent{
+				putWatchEventWithPrevKV("a", "2", 3, false, "2", 2),
+			},
+			expectError: errBrokePrevKV,
+		},
+		{
+			name: "Previous value of overwrite is stale",
+			events: []model.WatchEvent{
+				deleteWatchEventWithPrevKV("a", 4, "1", 2),
+			},
+			expectError: errBrokePrevKV,
+		},
+		{
+			name: "Previous value of create after delete",
+			events: []model.WatchEvent{
+				putWatchEventWithPrevKV("a", "3", 5, true, "2", 3),
+			},
+			expectError: errBrokePrevKV,
+		},
+		{
+			name: "Previous value written by unknown write",
+			events: []model.WatchEvent{
+				putWatchEventWithPrevKV("b", "2", 6, false, "1", 5),
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			reports := []report.ClientReport{
+				{
+					ClientID: 1,
+					KeyValue: writes,
+					Watch: []model.WatchOperation{
+						{
+							Request:   model.WatchRequest{Key: "a", WithPrefix: true, WithPrevKV: true},
+							Responses: []model.WatchResponse{{Events: tc.events}},
+						},
+					},
+				},
+			}
+			err := ValidateWatchPrevValues(zaptest.NewLogger(t), reports)
+			if err != tc.expectError {
+				t.Errorf("ValidateWatchPrevValues(...), got: %q, want: %q", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func TestValidateExactlyOnce(t *testing.T) {
+	tcs := []struct {
+		name        string
+		op          model.WatchOperation
+		expectError bool
+	}{
+		{
+			name: "Events delivered once",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "a", Revision: 2},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+					{Events: []model.WatchEvent{putWatchEvent("a", "2", 3, false)}},
+				},
+			},
+		},
+		{
+			name: "Event redelivered after reconnect",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "a", Revision: 2},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true), putWatchEvent("a", "2", 3, false)}},
+					{Events: []model.WatchEvent{putWatchEvent("a", "2", 3, false)}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Event before start revision",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "a", Revision: 3},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Prefix events delivered once",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "key", Revision: 2, WithPrefix: true},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("key1", "1", 2, true), putWatchEvent("key2", "2", 3, true)}},
+				},
+			},
+		},
+		{
+			name: "Event outside of watched prefix",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "key", Revision: 2, WithPrefix: true},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("key1", "1", 2, true), putWatchEvent("other", "2", 3, true)}},
+				},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateExactlyOnce(tc.op)
+			if (err != nil) != tc.expectError {
+				t.Errorf("ValidateExactlyOnce(...), got: %v, expectError: %t", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func TestValidateMultiKeyWatchOrder(t *testing.T) {
+	tcs := []struct {
+		name        string
+		op          model.WatchOperation
+		expectError bool
+	}{
+		{
+			name: "Interleaved keys ordered by revision",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "key", WithPrefix: true},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("key1", "1", 2, true), putWatchEvent("key2", "2", 3, true)}},
+					{Events: []model.WatchEvent{putWatchEvent("key1", "3", 4, false)}},
+				},
+			},
+		},
+		{
+			name: "Keys modified in the same transaction",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "key", WithPrefix: true},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("key1", "1", 2, true), putWatchEvent("key2", "2", 2, true)}},
+					{Events: []model.WatchEvent{putWatchEvent("key3", "3", 3, true)}},
+				},
+			},
+		},
+		{
+			name: "Key delivered out of order within response",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "key", WithPrefix: true},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("key1", "1", 3, true), putWatchEvent("key2", "2", 2, true)}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Key delivered out of order across responses",
+			op: model.WatchOperation{
+				Request: model.WatchRequest{Key: "key", WithPrefix: true},
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("key1", "1", 3, true)}},
+					{Events: []model.WatchEvent{putWatchEvent("key2", "2", 2, true)}},
+				},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateMultiKeyWatchOrder(tc.op)
+			if (err != nil) != tc.expectError {
+				t.Errorf("ValidateMultiKeyWatchOrder(...), got: %v, expectError: %t", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func TestAssertWatchLiveness(t *testing.T) {
+	tcs := []struct {
+		name        string
+		op          model.WatchOperation
+		expectError bool
+	}{
+		{
+			name: "Events and progress notifications within bound",
+			op: model.WatchOperation{
+				Responses: []model.WatchResponse{
+					{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}, Time: time.Second},
+					{IsProgressNotify: true, Revision: 2, Time: 2 * time.Second},
+					{IsProgressNotify: true, Revision: 2, Time: 3 * time.Second},
+				},
+			},
+		},
+		{
+			name: "Gap exceeding bound",
+			op: model.WatchOperation{
+				Responses: []model.WatchResponse{
+					{IsProgressNotify: true, Revision: 2, Time: time.Second},
+					{IsProgressNotify: true, Revision: 2, Time: 5 * time.Second},
+				},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := AssertWatchLiveness(tc.op, 2*time.Second)
+			if (err != nil) != tc.expectError {
+				t.Errorf("AssertWatchLiveness(...), got: %v, expectError: %t", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func TestValidateProgressNotifyLatency(t *testing.T) {
+	tcs := []struct {
+		name        string
+		report      report.ClientReport
+		expectError bool
+	}{
+		{
+			name: "Progress notification after request within bound",
+			report: report.ClientReport{
+				ProgressRequests: []time.Duration{2 * time.Second},
+				Watch: []model.WatchOperation{{
+					Responses: []model.WatchResponse{
+						{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}, Time: time.Second},
+						{IsProgressNotify: true, Revision: 2, Time: 2500 * time.Millisecond},
+					},
+				}},
+			},
+		},
+		{
+			name: "Request before watch started is ignored",
+			report: report.ClientReport{
+				ProgressRequests: []time.Duration{time.Second},
+				Watch: []model.WatchOperation{{
+					Responses: []model.WatchResponse{
+						{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}, Time: 2 * time.Second},
+					},
+				}},
+			},
+		},
+		{
+			name: "Progress notification exceeding bound",
+			report: report.ClientReport{
+				ProgressRequests: []time.Duration{2 * time.Second},
+				Watch: []model.WatchOperation{{
+					Responses: []model.WatchResponse{
+						{Events: []model.WatchEvent{putWatchEvent("a", "1", 2, true)}, Time: time.Second},
+						{IsProgressNotify: true, Revision: 2, Time: 5 * time.Second},
+					},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Watch never made progress after request",
+			report: report.ClientReport{
+				ProgressRequests: []time.Duration{2 * time.Second},
+				Watch: []model.WatchOperation{{
+					Responses: []model.WatchResponse{
+						{IsProgressNotify: true, Revision: 2, Time: time.Second},
+					},
+				}},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateProgressNotifyLatency(tc.report, time.Second)
+			if (err != nil) != tc.expectError {
+				t.Errorf("ValidateProgressNotifyLatency(...), got: %v, expectError: %t", err, tc.expectError)
+			}
+		})
+	}
+}
+
 func putWatchEvent(key, value string, rev int64, isCreate bool) model.WatchEvent {
 	return model.WatchEvent{
 		PersistedEvent: putPersistedEvent(key, value, rev, isCreate),
@@ -1924,6 +2403,7 @@ func putPersistedEvent(key, value string, rev int64, isCreate bool) model.Persis
 		},
 		Revision: rev,
 		IsCreate: isCreate,
+		IsModify: !isCreate,
 	}
 }
 
@@ -1988,6 +2468,12 @@ func deleteRequest(key string) model.EtcdRequest {
 	}
 }
 
+func deleteRangeRequest(start, end string) model.EtcdRequest {
+	req := deleteRequest(start)
+	req.Txn.OperationsOnSuccess[0].Delete.End = end
+	return req
+}
+
 func compactRequest(revision int64) model.EtcdRequest {
 	return model.EtcdRequest{
 		Type: model.Compact,
@@ -16,6 +16,8 @@ package validate
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -34,6 +36,7 @@ var (
 	errBrokeResumable    = errors.New("broke Resumable - A broken watch can be resumed by establishing a new watch starting after the last revision received in a watch event before the break, so long as the revision is in the history window")
 	errBrokePrevKV       = errors.New("incorrect event prevValue")
 	errBrokeIsCreate     = errors.New("incorrect event IsCreate")
+	errBrokeIsModify     = errors.New("incorrect event IsModify")
 	errBrokeFilter       = errors.New("event not matching watch filter")
 )
 
@@ -77,6 +80,10 @@ func validateWatch(lg *zap.Logger, cfg Config, reports []report.ClientReport, re
 		if err != nil {
 			return err
 		}
+		err = validateIsModify(lg, replay, r)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -164,6 +171,78 @@ func validateUnique(lg *zap.Logger, expectUniqueRevision bool, report report.Cli
 	return err
 }
 
+// ValidateExactlyOnce checks that watch delivered events starting from the requested revision,
+// each of them at most once and only for keys in the watched range.
+func ValidateExactlyOnce(op model.WatchOperation) error {
+	for _, resp := range op.Responses {
+		for _, event := range resp.Events {
+			if !event.Match(op.Request) {
+				return fmt.Errorf("%w, key: %q, revision: %d, watch key: %q, with prefix: %t", errBrokeFilter, event.Key, event.Revision, op.Request.Key, op.Request.WithPrefix)
+			}
+		}
+	}
+	if duplicates := op.Duplicates(); len(duplicates) != 0 {
+		return fmt.Errorf("%w, key: %q, revision: %d", errBrokeUnique, duplicates[0].Key, duplicates[0].Revision)
+	}
+	if first := firstWatchEvent(op); first != nil && op.Request.Revision != 0 && first.Revision < op.Request.Revision {
+		return fmt.Errorf("first event revision %d is lower than watch start revision %d", first.Revision, op.Request.Revision)
+	}
+	return nil
+}
+
+// ValidateMultiKeyWatchOrder checks that watch covering multiple keys delivered events
+// with non-decreasing revisions across all keys. Equal revisions are allowed as
+// single transaction can modify multiple keys.
+func ValidateMultiKeyWatchOrder(op model.WatchOperation) error {
+	var last *model.WatchEvent
+	for _, resp := range op.Responses {
+		for i, event := range resp.Events {
+			if last != nil && event.Revision < last.Revision {
+				return fmt.Errorf("%w, key %q at revision %d delivered after key %q at revision %d", errBrokeOrdered, event.Key, event.Revision, last.Key, last.Revision)
+			}
+			last = &resp.Events[i]
+		}
+	}
+	return nil
+}
+
+// ValidateProgressNotifyLatency checks that every progress request of the client was followed by
+// a progress notification on each of its watches within maxLatency. Only requests sent after the
+// watch received its first response are considered, as watch start time is not recorded.
+// Watch that never made progress after the request, for example after blackhole was removed, is reported.
+func ValidateProgressNotifyLatency(r report.ClientReport, maxLatency time.Duration) error {
+	for _, op := range r.Watch {
+		if len(op.Responses) == 0 {
+			continue
+		}
+		for _, requestTime := range r.ProgressRequests {
+			if requestTime < op.Responses[0].Time {
+				continue
+			}
+			latency, ok := op.ProgressNotifyLatency(requestTime)
+			if !ok {
+				return fmt.Errorf("watch on key %q didn't receive progress notification after progress request at %s", op.Request.Key, requestTime)
+			}
+			if latency > maxLatency {
+				return fmt.Errorf("watch on key %q received progress notification %s after progress request at %s, exceeding %s", op.Request.Key, latency, requestTime, maxLatency)
+			}
+		}
+	}
+	return nil
+}
+
+// AssertWatchLiveness checks that gap between any two consecutive watch responses, either
+// events or progress notifications, doesn't exceed maxGap. Requires watch with progress
+// notify, as otherwise quiet watch is indistinguishable from a dead one.
+func AssertWatchLiveness(op model.WatchOperation, maxGap time.Duration) error {
+	for i := 1; i < len(op.Responses); i++ {
+		if gap := op.Responses[i].Time - op.Responses[i-1].Time; gap > maxGap {
+			return fmt.Errorf("watch on key %q didn't receive response for %s, between %s and %s, exceeding %s", op.Request.Key, gap, op.Responses[i-1].Time, op.Responses[i].Time, maxGap)
+		}
+	}
+	return nil
+}
+
 func validateAtomic(lg *zap.Logger, report report.ClientReport) (err error) {
 	for _, op := range report.Watch {
 		var lastEventRevision int64 = 1
@@ -210,7 +289,7 @@ func validateReliable(lg *zap.Logger, replay *model.EtcdReplay, report report.Cl
 		if detected {
 			wantEvents = newWantEvents
 		}
-		if diff := cmp.Diff(wantEvents, gotEvents, cmpopts.IgnoreFields(model.PersistedEvent{}, "IsCreate")); diff != "" {
+		if diff := cmp.Diff(wantEvents, gotEvents, cmpopts.IgnoreFields(model.PersistedEvent{}, "IsCreate", "IsModify")); diff != "" {
 			lg.Error("Broke watch guarantee", zap.String("guarantee", "reliable"), zap.Int("client", report.ClientID), zap.String("diff", diff))
 			err = errBrokeReliable
 		}
@@ -282,6 +361,85 @@ func validatePrevKV(lg *zap.Logger, replay *model.EtcdReplay, report report.Clie
 	return err
 }
 
+// ValidateWatchPrevValues checks PrevValue of watch events against the write history
+// recorded by clients, combining successful writes and observed watch events.
+// Create events must not have PrevValue. For other events, PrevValue has to match
+// the value written at its mod revision, with no other write to the key until the event.
+// As write history is incomplete, PrevValue is only checked against known writes.
+func ValidateWatchPrevValues(lg *zap.Logger, reports []report.ClientReport) (err error) {
+	writes := keyWrites(reports)
+	for _, r := range reports {
+		for _, op := range r.Watch {
+			for _, resp := range op.Responses {
+				for _, event := range resp.Events {
+					if event.PrevValue == nil {
+						continue
+					}
+					if event.IsCreate {
+						lg.Error("Create event with prevValue", zap.Int("client", r.ClientID), zap.Any("event", event))
+						err = errBrokePrevKV
+						continue
+					}
+					for _, write := range writes[event.Key] {
+						if write.Revision == event.PrevValue.ModRevision && (write.Type != model.PutOperation || write.Value != event.PrevValue.Value) {
+							lg.Error("Incorrect event prevValue field", zap.Int("client", r.ClientID), zap.Any("event", event), zap.Any("write", write))
+							err = errBrokePrevKV
+						}
+						if write.Revision > event.PrevValue.ModRevision && write.Revision < event.Revision {
+							lg.Error("Event prevValue overwritten before event", zap.Int("client", r.ClientID), zap.Any("event", event), zap.Any("write", write))
+							err = errBrokePrevKV
+						}
+					}
+				}
+			}
+		}
+	}
+	return err
+}
+
+// keyWrites returns writes per key, known from successful client requests and watch events.
+func keyWrites(reports []report.ClientReport) map[string][]model.PersistedEvent {
+	writes := map[string]map[int64]model.PersistedEvent{}
+	add := func(event model.PersistedEvent) {
+		if _, ok := writes[event.Key]; !ok {
+			writes[event.Key] = map[int64]model.PersistedEvent{}
+		}
+		writes[event.Key][event.Revision] = event
+	}
+	for _, r := range reports {
+		for _, op := range r.KeyValue {
+			request := op.Input.(model.EtcdRequest)
+			response := op.Output.(model.MaybeEtcdResponse)
+			if request.Type != model.Txn || response.Error != "" || response.PartialResponse || response.Txn == nil {
+				continue
+			}
+			results := request.Txn.ExecutedResults(response.Txn)
+			for i, etcdOp := range request.Txn.ExecutedOperations(response.Txn) {
+				switch {
+				case etcdOp.Type == model.PutOperation:
+					add(model.PersistedEvent{Event: model.Event{Type: model.PutOperation, Key: etcdOp.Put.Key, Value: etcdOp.Put.Value}, Revision: response.Revision})
+				case etcdOp.Type == model.DeleteOperation && etcdOp.Delete.End == "" && i < len(results) && results[i].Deleted > 0:
+					add(model.PersistedEvent{Event: model.Event{Type: model.DeleteOperation, Key: etcdOp.Delete.Key}, Revision: response.Revision})
+				}
+			}
+		}
+		for _, op := range r.Watch {
+			for _, resp := range op.Responses {
+				for _, event := range resp.Events {
+					add(event.PersistedEvent)
+				}
+			}
+		}
+	}
+	result := map[string][]model.PersistedEvent{}
+	for key, events := range writes {
+		for _, event := range events {
+			result[key] = append(result[key], event)
+		}
+	}
+	return result
+}
+
 func validateIsCreate(lg *zap.Logger, replay *model.EtcdReplay, report report.ClientReport) (err error) {
 	for _, op := range report.Watch {
 		for _, resp := range op.Responses {
@@ -303,6 +461,26 @@ func validateIsCreate(lg *zap.Logger, replay *model.EtcdReplay, report report.Cl
 	return err
 }
 
+func validateIsModify(lg *zap.Logger, replay *model.EtcdReplay, report report.ClientReport) (err error) {
+	for _, op := range report.Watch {
+		for _, resp := range op.Responses {
+			for _, event := range resp.Events {
+				state, err2 := replay.StateForRevision(event.Revision - 1)
+				if err2 != nil {
+					panic(err2)
+				}
+				// Only a put to a key that has an entry in our history modifies it.
+				_, prevKeyExists := state.KeyValues[event.Key]
+				if event.IsModify != (event.Type == model.PutOperation && prevKeyExists) {
+					lg.Error("Incorrect event IsModify field", zap.Int("client", report.ClientID), zap.Any("event", event))
+					err = errBrokeIsModify
+				}
+			}
+		}
+	}
+	return err
+}
+
 func firstExpectedRevision(op model.WatchOperation) int64 {
 	if op.Request.Revision != 0 {
 		return op.Request.Revision
@@ -0,0 +1,115 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+// OperationID identifies a key value operation by client and its position in client report.
+type OperationID struct {
+	ClientID int
+	Index    int
+}
+
+// AssertLinearizableAt verifies that effect of a write operation was applied within [minRev, maxRev] revision window.
+// Revision is taken from the operation response, reads and watch events that observed the written value.
+// Additionally, linearizable reads known to happen before or after the operation bound the revision.
+// Puts need to be unique, as required by the validation.
+func AssertLinearizableAt(reports []report.ClientReport, opID OperationID, minRev, maxRev int64) error {
+	op, err := findOperation(reports, opID)
+	if err != nil {
+		return err
+	}
+	request := op.Input.(model.EtcdRequest)
+	if request.Type != model.Txn {
+		return fmt.Errorf("operation %+v is not a write, got %s", opID, request.Type)
+	}
+	puts := map[model.KeyValue]struct{}{}
+	for _, etcdOp := range request.Txn.AllOperations() {
+		if etcdOp.Type == model.PutOperation {
+			puts[model.KeyValue{Key: etcdOp.Put.Key, ValueRevision: model.ValueRevision{Value: etcdOp.Put.Value}}] = struct{}{}
+		}
+	}
+
+	var observed []int64
+	response := op.Output.(model.MaybeEtcdResponse)
+	if response.Error == "" && !response.PartialResponse {
+		observed = append(observed, response.Revision)
+	}
+	lowerBound, upperBound := int64(0), int64(math.MaxInt64)
+	for _, r := range reports {
+		for _, read := range r.KeyValue {
+			readRequest := read.Input.(model.EtcdRequest)
+			readResponse := read.Output.(model.MaybeEtcdResponse)
+			revision, kvs := observedKeyValues(readRequest, readResponse)
+			for _, kv := range kvs {
+				if _, found := puts[model.KeyValue{Key: kv.Key, ValueRevision: model.ValueRevision{Value: kv.Value}}]; found {
+					observed = append(observed, kv.ModRevision)
+				}
+			}
+			// Only linearizable reads are ordered in real time with the operation.
+			if revision == 0 || !readRequest.IsRead() || (readRequest.Type == model.Range && !readRequest.Range.IsLinearizable()) {
+				continue
+			}
+			if read.Return < op.Call {
+				lowerBound = max(lowerBound, revision+1)
+			}
+			if op.Return != -1 && read.Call > op.Return {
+				upperBound = min(upperBound, revision)
+			}
+		}
+		for _, watch := range r.Watch {
+			for _, resp := range watch.Responses {
+				for _, event := range resp.Events {
+					if event.Type != model.PutOperation {
+						continue
+					}
+					if _, found := puts[model.KeyValue{Key: event.Key, ValueRevision: model.ValueRevision{Value: event.Value}}]; found {
+						observed = append(observed, event.Revision)
+					}
+				}
+			}
+		}
+	}
+	for _, revision := range observed {
+		if revision < minRev || revision > maxRev {
+			return fmt.Errorf("operation %+v observed at revision %d, outside of window [%d, %d]", opID, revision, minRev, maxRev)
+		}
+	}
+	if upperBound < minRev || lowerBound > maxRev {
+		return fmt.Errorf("operation %+v bounded by surrounding reads to [%d, %d], outside of window [%d, %d]", opID, lowerBound, upperBound, minRev, maxRev)
+	}
+	return nil
+}
+
+func findOperation(reports []report.ClientReport, opID OperationID) (porcupine.Operation, error) {
+	for _, r := range reports {
+		if r.ClientID != opID.ClientID {
+			continue
+		}
+		if opID.Index < 0 || opID.Index >= len(r.KeyValue) {
+			return porcupine.Operation{}, fmt.Errorf("operation %+v out of range, client has %d operations", opID, len(r.KeyValue))
+		}
+		return r.KeyValue[opID.Index], nil
+	}
+	return porcupine.Operation{}, fmt.Errorf("client %d not found", opID.ClientID)
+}
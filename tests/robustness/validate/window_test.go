@@ -0,0 +1,130 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/anishathalye/porcupine"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestAssertLinearizableAt(t *testing.T) {
+	history := []report.ClientReport{
+		{
+			ClientID: 1,
+			KeyValue: []porcupine.Operation{
+				{Input: putRequest("a", "1"), Output: txnResponse(2, model.EtcdOperationResult{}), Call: 0, Return: 1},
+				{Input: putRequest("b", "1"), Output: model.MaybeEtcdResponse{Error: "timeout", Indeterminate: true}, Call: 2, Return: -1},
+			},
+		},
+		{
+			ClientID: 2,
+			KeyValue: []porcupine.Operation{
+				{Input: rangeRequest("b", "", 0, 0), Output: rangeResponseWithRevision(2), Call: 1, Return: 2},
+				{Input: rangeRequest("b", "", 0, 0), Output: rangeResponseWithRevision(4, keyValue("b", "1", 4)), Call: 5, Return: 6},
+			},
+			Watch: []model.WatchOperation{
+				{Responses: []model.WatchResponse{{Events: []model.WatchEvent{putWatchEvent("b", "1", 4, true)}}}},
+			},
+		},
+	}
+	tcs := []struct {
+		name           string
+		reports        []report.ClientReport
+		opID           OperationID
+		minRev, maxRev int64
+		expectError    bool
+	}{
+		{
+			name:    "Successful put within window",
+			reports: history,
+			opID:    OperationID{ClientID: 1, Index: 0},
+			minRev:  2,
+			maxRev:  2,
+		},
+		{
+			name:        "Successful put after window",
+			reports:     history,
+			opID:        OperationID{ClientID: 1, Index: 0},
+			minRev:      0,
+			maxRev:      1,
+			expectError: true,
+		},
+		{
+			name:    "Failed put observed by read and watch within window",
+			reports: history,
+			opID:    OperationID{ClientID: 1, Index: 1},
+			minRev:  3,
+			maxRev:  5,
+		},
+		{
+			name:        "Failed put observed after window",
+			reports:     history,
+			opID:        OperationID{ClientID: 1, Index: 1},
+			minRev:      2,
+			maxRev:      3,
+			expectError: true,
+		},
+		{
+			name: "Put bounded by read that started after it returned",
+			reports: []report.ClientReport{
+				{
+					ClientID: 1,
+					KeyValue: []porcupine.Operation{
+						{Input: putRequest("a", "1"), Output: model.MaybeEtcdResponse{Error: "failed"}, Call: 0, Return: 1},
+						{Input: rangeRequest("b", "", 0, 0), Output: rangeResponseWithRevision(3), Call: 2, Return: 3},
+					},
+				},
+			},
+			opID:        OperationID{ClientID: 1, Index: 0},
+			minRev:      4,
+			maxRev:      10,
+			expectError: true,
+		},
+		{
+			name: "Put bounded by read that returned before it started",
+			reports: []report.ClientReport{
+				{
+					ClientID: 1,
+					KeyValue: []porcupine.Operation{
+						{Input: rangeRequest("b", "", 0, 0), Output: rangeResponseWithRevision(5), Call: 0, Return: 1},
+						{Input: putRequest("a", "1"), Output: model.MaybeEtcdResponse{Error: "timeout", Indeterminate: true}, Call: 2, Return: -1},
+					},
+				},
+			},
+			opID:        OperationID{ClientID: 1, Index: 1},
+			minRev:      2,
+			maxRev:      5,
+			expectError: true,
+		},
+		{
+			name:        "Unknown operation",
+			reports:     history,
+			opID:        OperationID{ClientID: 1, Index: 2},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := AssertLinearizableAt(tc.reports, tc.opID, tc.minRev, tc.maxRev)
+			if (err != nil) != tc.expectError {
+				t.Errorf("Unexpected error, expectError: %t, got: %v", tc.expectError, err)
+			}
+		})
+	}
+}